@@ -13,6 +13,9 @@ import (
 	"github.com/kirananto/review-system/internal/db"
 	"github.com/kirananto/review-system/internal/logger"
 	models "github.com/kirananto/review-system/internal/models"
+	"github.com/kirananto/review-system/internal/moderation"
+	"github.com/kirananto/review-system/internal/notify"
+	"github.com/kirananto/review-system/internal/webhook"
 )
 
 func main() {
@@ -30,11 +33,24 @@ func main() {
 	dataSource := db.NewDataSource(cfg.Database.DSN)
 
 	//TODO: Move Auto-Migration to CI/CD instead of running on every start
-	dataSource.Db.AutoMigrate(&models.Provider{}, &models.Hotel{}, &models.Review{}, &models.ProviderHotel{}, &models.AuditLog{})
+	dataSource.Db.AutoMigrate(&models.Provider{}, &models.Hotel{}, &models.Review{}, &models.ReviewResponse{}, &models.ProviderHotel{}, &models.AuditLog{}, &models.WebhookSubscription{}, &models.WebhookDelivery{})
 
 	log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+	moderationRules, err := moderation.NewPipeline(cfg.Moderation.RulesPath, log)
+	if err != nil {
+		log.Error(err, "Failed to load moderation rules")
+		os.Exit(1)
+	}
+	notifier, err := notify.NewNotifier(cfg.Notifications.Path, log)
+	if err != nil {
+		log.Error(err, "Failed to load notifications")
+		os.Exit(1)
+	}
+
 	repository := repository.NewReviewRepository(dataSource)
-	service := service.NewReviewService(repository, log)
+	// Events are queued here and delivered by the server
+	dispatcher := webhook.NewDispatcher(repository, webhook.NewClient(webhook.DefaultTimeout), log)
+	service := service.NewReviewService(repository, log, moderationRules, dispatcher, notifier)
 
 	if len(os.Args) < 2 {
 		fmt.Println("Usage: go run main.go <file-path>")
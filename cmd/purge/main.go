@@ -0,0 +1,47 @@
+// Command purge permanently deletes the hotels, providers, provider hotels and
+// reviews soft-deleted longer than the retention window ago. Deployed, the same
+// job runs daily on an EventBridge schedule.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/kirananto/review-system/internal/api/repository"
+	"github.com/kirananto/review-system/internal/api/service"
+	"github.com/kirananto/review-system/internal/config"
+	"github.com/kirananto/review-system/internal/db"
+	"github.com/kirananto/review-system/internal/logger"
+)
+
+func main() {
+
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	cfg, err := config.LoadConfig("./")
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	dataSource := db.NewDataSource(cfg.Database.DSN)
+	log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+
+	repository := repository.NewReviewRepository(dataSource)
+	service := service.NewPurgeService(repository, log)
+
+	retention := time.Duration(cfg.Purge.RetentionDays) * 24 * time.Hour
+	result, err := service.Purge(retention)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Failed to purge deleted entities: %v", err))
+		os.Exit(1)
+	}
+
+	fmt.Printf("Purged %d reviews, %d provider hotels, %d hotels and %d providers deleted before %s\n",
+		result.Reviews, result.ProviderHotels, result.Hotels, result.Providers, result.DeletedBefore.Format(time.RFC3339))
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	_ "github.com/kirananto/review-system/docs"
@@ -31,11 +32,16 @@ func main() {
 	// Create server config
 	serverCfg := &server.ServerConfig{
 		DatabaseDSN: appCfg.Database.DSN,
+		APIKeys:     appCfg.Auth.APIKeys,
 		Port:        os.Getenv("PORT"),
+		GRPCPort:    os.Getenv("GRPC_PORT"),
 		RunMode:     os.Getenv("RUN_MODE"),
 		LogConfig: logger.LogConfig{
 			LogLevel: os.Getenv("LOG_LEVEL"),
 		},
+		ModerationRulesPath: appCfg.Moderation.RulesPath,
+		NotificationsPath:   appCfg.Notifications.Path,
+		PurgeRetention:      time.Duration(appCfg.Purge.RetentionDays) * 24 * time.Hour,
 	}
 
 	// Create and start server
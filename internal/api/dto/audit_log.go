@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+type AuditLogsQueryParams struct {
+	Limit       int       `schema:"limit"`
+	Offset      int       `schema:"offset"`
+	FileName    string    `schema:"file_name"`
+	CreatedFrom time.Time `schema:"created_from"`
+	CreatedTo   time.Time `schema:"created_to"`
+}
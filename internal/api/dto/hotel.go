@@ -1,11 +1,69 @@
 package dto
 
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// DefaultRadiusKm is the search radius around near when radius_km is not set.
+const DefaultRadiusKm = 10
+
 type HotelRequestBody struct {
 	HotelName string `json:"hotel_name" validate:"required"`
+	models.HotelLocation
 }
 
 type HotelsQueryParams struct {
-	Limit  int    `schema:"limit"`
-	Offset int    `schema:"offset"`
-	Name   string `schema:"name"`
+	Limit          int       `schema:"limit"`
+	Offset         int       `schema:"offset"`
+	Name           string    `schema:"name"`
+	ProviderID     uint      `schema:"provider_id"`
+	City           string    `schema:"city"`
+	Country        string    `schema:"country"`
+	Near           *GeoPoint `schema:"near"`
+	RadiusKm       float64   `schema:"radius_km"`
+	IncludeDeleted bool      `schema:"include_deleted"`
+	ResponseOptions
+}
+
+// Radius returns the search radius around Near in kilometres.
+func (q *HotelsQueryParams) Radius() float64 {
+	if q.RadiusKm == 0 {
+		return DefaultRadiusKm
+	}
+	return q.RadiusKm
+}
+
+// GeoPoint is a position in decimal degrees.
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// ParseGeoPoint parses a "lat,lng" pair such as "10.77,106.70".
+func ParseGeoPoint(value string) (GeoPoint, error) {
+	latText, lngText, ok := strings.Cut(value, ",")
+	if !ok {
+		return GeoPoint{}, fmt.Errorf("expected lat,lng")
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latText), 64)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("invalid latitude: %w", err)
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(lngText), 64)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("invalid longitude: %w", err)
+	}
+	// NaN compares false against every bound, so it is rejected explicitly
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return GeoPoint{}, fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return GeoPoint{}, fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return GeoPoint{Lat: lat, Lng: lng}, nil
 }
@@ -0,0 +1,135 @@
+package dto
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// RatingHistogramBuckets is the number of one-point buckets ratings from 0 to
+// 10 are grouped into. A rating of exactly 10 falls in the last bucket.
+const RatingHistogramBuckets = 10
+
+// HotelSummary aggregates what we know about a hotel's ratings across providers.
+type HotelSummary struct {
+	HotelID          uint              `json:"hotel_id"`
+	HotelName        string            `json:"hotel_name"`
+	ReviewCount      int               `json:"review_count"`
+	AverageRating    *float64          `json:"average_rating"`
+	LatestReviewDate *time.Time        `json:"latest_review_date"`
+	RatingHistogram  []RatingBucket    `json:"rating_histogram"`
+	Providers        []ProviderSummary `json:"providers"`
+}
+
+// RatingBucket counts the reviews rated from Min up to, but not including, Max.
+type RatingBucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max"`
+	Count int `json:"count"`
+}
+
+// ProviderSummary combines the reviews we store from a provider with the overall
+// score and grades the provider reports for the hotel. ProviderReviewCount is
+// the provider's own count, which includes reviews we never received.
+type ProviderSummary struct {
+	ProviderID          uint            `json:"provider_id"`
+	ProviderName        string          `json:"provider_name"`
+	ReviewCount         int             `json:"review_count"`
+	AverageRating       *float64        `json:"average_rating"`
+	LatestReviewDate    *time.Time      `json:"latest_review_date"`
+	OverallScore        *float64        `json:"overall_score"`
+	ProviderReviewCount *int            `json:"provider_review_count"`
+	Grades              json.RawMessage `json:"grades" swaggertype:"object"`
+}
+
+// ProviderReviewStats aggregates the stored reviews of a hotel from one provider.
+type ProviderReviewStats struct {
+	ProviderID       uint
+	ProviderName     string
+	ReviewCount      int
+	AverageRating    float64
+	LatestReviewDate time.Time
+}
+
+// RatingBucketCount is the number of reviews in one histogram bucket.
+type RatingBucketCount struct {
+	Bucket int
+	Count  int
+}
+
+// ProviderHotelScore is a provider-hotel mapping along with the provider's name.
+type ProviderHotelScore struct {
+	models.ProviderHotel
+	ProviderName string
+}
+
+// NewHotelSummary builds the summary of a hotel from its aggregated reviews and
+// provider scores. Providers are listed by name, whether they have stored
+// reviews, reported scores, or both.
+func NewHotelSummary(hotel *models.Hotel, stats []*ProviderReviewStats, buckets []*RatingBucketCount, scores []*ProviderHotelScore) *HotelSummary {
+	summary := &HotelSummary{
+		HotelID:         hotel.ID,
+		HotelName:       hotel.HotelName,
+		RatingHistogram: make([]RatingBucket, RatingHistogramBuckets),
+		Providers:       []ProviderSummary{},
+	}
+
+	for i := range summary.RatingHistogram {
+		summary.RatingHistogram[i] = RatingBucket{Min: i, Max: i + 1}
+	}
+	for _, bucket := range buckets {
+		if bucket.Bucket >= 0 && bucket.Bucket < RatingHistogramBuckets {
+			summary.RatingHistogram[bucket.Bucket].Count = bucket.Count
+		}
+	}
+
+	providers := make(map[uint]*ProviderSummary)
+	providerSummary := func(id uint, name string) *ProviderSummary {
+		if p, ok := providers[id]; ok {
+			return p
+		}
+		p := &ProviderSummary{ProviderID: id, ProviderName: name, Grades: json.RawMessage(`{}`)}
+		providers[id] = p
+		return p
+	}
+
+	var ratingSum float64
+	for _, stat := range stats {
+		p := providerSummary(stat.ProviderID, stat.ProviderName)
+		p.ReviewCount = stat.ReviewCount
+		p.AverageRating = roundRating(stat.AverageRating)
+		latest := stat.LatestReviewDate
+		p.LatestReviewDate = &latest
+
+		summary.ReviewCount += stat.ReviewCount
+		ratingSum += stat.AverageRating * float64(stat.ReviewCount)
+		if summary.LatestReviewDate == nil || latest.After(*summary.LatestReviewDate) {
+			summary.LatestReviewDate = &latest
+		}
+	}
+	if summary.ReviewCount > 0 {
+		summary.AverageRating = roundRating(ratingSum / float64(summary.ReviewCount))
+	}
+
+	for _, score := range scores {
+		p := providerSummary(score.ProviderID, score.ProviderName)
+		overallScore := score.OverallScore
+		reviewCount := score.ReviewCount
+		p.OverallScore = &overallScore
+		p.ProviderReviewCount = &reviewCount
+		if len(score.Grades) > 0 && string(score.Grades) != "null" {
+			p.Grades = score.Grades
+		}
+	}
+
+	for _, p := range providers {
+		summary.Providers = append(summary.Providers, *p)
+	}
+	sort.Slice(summary.Providers, func(i, j int) bool {
+		return summary.Providers[i].ProviderName < summary.Providers[j].ProviderName
+	})
+
+	return summary
+}
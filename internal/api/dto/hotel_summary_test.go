@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHotelSummary(t *testing.T) {
+	hotel := &models.Hotel{ID: 10984, HotelName: "Oscar Saigon Hotel"}
+	april := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+	may := time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("reviews and scores", func(t *testing.T) {
+		stats := []*ProviderReviewStats{
+			{ProviderID: 332, ProviderName: "Agoda", ReviewCount: 3, AverageRating: 8, LatestReviewDate: april},
+			{ProviderID: 7, ProviderName: "Booking.com", ReviewCount: 1, AverageRating: 6.4, LatestReviewDate: may},
+		}
+		buckets := []*RatingBucketCount{{Bucket: 6, Count: 1}, {Bucket: 8, Count: 3}}
+		scores := []*ProviderHotelScore{
+			{ProviderHotel: models.ProviderHotel{ProviderID: 332, HotelID: 10984, OverallScore: 7.9, ReviewCount: 7070, Grades: json.RawMessage(`{"Location":9.1}`)}, ProviderName: "Agoda"},
+			{ProviderHotel: models.ProviderHotel{ProviderID: 9, HotelID: 10984, OverallScore: 8.5, ReviewCount: 120}, ProviderName: "Expedia"},
+		}
+
+		summary := NewHotelSummary(hotel, stats, buckets, scores)
+
+		assert.Equal(t, uint(10984), summary.HotelID)
+		assert.Equal(t, 4, summary.ReviewCount)
+		assert.Equal(t, 7.6, *summary.AverageRating)
+		assert.Equal(t, may, *summary.LatestReviewDate)
+
+		assert.Len(t, summary.RatingHistogram, RatingHistogramBuckets)
+		assert.Equal(t, RatingBucket{Min: 6, Max: 7, Count: 1}, summary.RatingHistogram[6])
+		assert.Equal(t, RatingBucket{Min: 8, Max: 9, Count: 3}, summary.RatingHistogram[8])
+		assert.Equal(t, 0, summary.RatingHistogram[9].Count)
+
+		assert.Len(t, summary.Providers, 3)
+		agoda := summary.Providers[0]
+		assert.Equal(t, "Agoda", agoda.ProviderName)
+		assert.Equal(t, 3, agoda.ReviewCount)
+		assert.Equal(t, 7.9, *agoda.OverallScore)
+		assert.Equal(t, 7070, *agoda.ProviderReviewCount)
+		assert.JSONEq(t, `{"Location":9.1}`, string(agoda.Grades))
+
+		booking := summary.Providers[1]
+		assert.Equal(t, "Booking.com", booking.ProviderName)
+		assert.Nil(t, booking.OverallScore)
+		assert.JSONEq(t, `{}`, string(booking.Grades))
+
+		expedia := summary.Providers[2]
+		assert.Equal(t, 0, expedia.ReviewCount)
+		assert.Nil(t, expedia.AverageRating)
+		assert.Nil(t, expedia.LatestReviewDate)
+	})
+
+	t.Run("no reviews", func(t *testing.T) {
+		summary := NewHotelSummary(hotel, nil, nil, nil)
+
+		assert.Equal(t, 0, summary.ReviewCount)
+		assert.Nil(t, summary.AverageRating)
+		assert.Nil(t, summary.LatestReviewDate)
+		assert.Len(t, summary.RatingHistogram, RatingHistogramBuckets)
+		assert.NotNil(t, summary.Providers)
+		assert.Empty(t, summary.Providers)
+	})
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGeoPoint(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    GeoPoint
+		expectedErr string
+	}{
+		{value: "10.7769,106.7009", expected: GeoPoint{Lat: 10.7769, Lng: 106.7009}},
+		{value: " -33.86 , 151.21 ", expected: GeoPoint{Lat: -33.86, Lng: 151.21}},
+		{value: "10.7769", expectedErr: "expected lat,lng"},
+		{value: "north,106.7", expectedErr: `invalid latitude: strconv.ParseFloat: parsing "north": invalid syntax`},
+		{value: "95,106.7", expectedErr: "latitude must be between -90 and 90"},
+		{value: "10,NaN", expectedErr: "longitude must be between -180 and 180"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			point, err := ParseGeoPoint(tt.value)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, point)
+		})
+	}
+}
+
+func TestHotelsQueryParams_Radius(t *testing.T) {
+	assert.Equal(t, float64(DefaultRadiusKm), (&HotelsQueryParams{}).Radius())
+	assert.Equal(t, 2.5, (&HotelsQueryParams{RadiusKm: 2.5}).Radius())
+}
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// Moderation actions and the status each one moves a review to.
+const (
+	ModerationApprove = "approve"
+	ModerationReject  = "reject"
+	ModerationFlag    = "flag"
+)
+
+// ModerationTransition is the status an action sets and the statuses it can
+// be applied to.
+type ModerationTransition struct {
+	To   string
+	From []string
+}
+
+// ModerationTransitions defines the review status lifecycle. Pending reviews
+// can be approved, rejected or flagged; flagged ones approved or rejected;
+// published ones taken down by rejecting or flagging them; and rejected ones
+// approved on appeal.
+var ModerationTransitions = map[string]ModerationTransition{
+	ModerationApprove: {
+		To:   models.ReviewStatusPublished,
+		From: []string{models.ReviewStatusPending, models.ReviewStatusFlagged, models.ReviewStatusRejected},
+	},
+	ModerationReject: {
+		To:   models.ReviewStatusRejected,
+		From: []string{models.ReviewStatusPending, models.ReviewStatusFlagged, models.ReviewStatusPublished},
+	},
+	ModerationFlag: {
+		To:   models.ReviewStatusFlagged,
+		From: []string{models.ReviewStatusPending, models.ReviewStatusPublished},
+	},
+}
+
+// ModerationRequestBody moderates a single review. A reason is required to
+// reject or flag a review.
+type ModerationRequestBody struct {
+	Reason string `json:"reason"`
+	// Moderator is the name of the API key making the decision. It is never
+	// read from the request.
+	Moderator string `json:"-"`
+}
+
+// BatchModerationRequestBody applies the same moderation action to several
+// reviews.
+type BatchModerationRequestBody struct {
+	IDs []uint `json:"ids"`
+	ModerationRequestBody
+}
+
+// ReviewModeration is the moderation decision written to reviews.
+type ReviewModeration struct {
+	Status    string
+	Reason    string
+	Moderator string
+	At        time.Time
+}
+
+// BatchModerationResult reports which reviews of a batch were moderated.
+// Skipped reviews either do not exist or can not take the action from their
+// current status.
+type BatchModerationResult struct {
+	Status    string `json:"status"`
+	Moderated []uint `json:"moderated"`
+	Skipped   []uint `json:"skipped"`
+}
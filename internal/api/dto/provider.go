@@ -1,7 +1,14 @@
 package dto
 
+type ProviderRequestBody struct {
+	Name string `json:"name" validate:"required"`
+}
+
 type ProvidersQueryParams struct {
-	Limit  int    `schema:"limit"`
-	Offset int    `schema:"offset"`
-	Name   string `schema:"name"`
+	Limit          int    `schema:"limit"`
+	Offset         int    `schema:"offset"`
+	Name           string `schema:"name"`
+	HotelID        uint   `schema:"hotel_id"`
+	IncludeDeleted bool   `schema:"include_deleted"`
+	ResponseOptions
 }
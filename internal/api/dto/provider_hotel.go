@@ -1,8 +1,28 @@
 package dto
 
+import "encoding/json"
+
+// ProviderHotelRequestBody is used to create a provider-hotel mapping.
+type ProviderHotelRequestBody struct {
+	ProviderID   uint            `json:"provider_id" validate:"required"`
+	HotelID      uint            `json:"hotel_id" validate:"required"`
+	OverallScore float64         `json:"overall_score"`
+	ReviewCount  int             `json:"review_count"`
+	Grades       json.RawMessage `json:"grades" swaggertype:"object"`
+}
+
+// ProviderHotelStatsBody is used to update the stats of a provider-hotel mapping.
+type ProviderHotelStatsBody struct {
+	OverallScore float64         `json:"overall_score"`
+	ReviewCount  int             `json:"review_count"`
+	Grades       json.RawMessage `json:"grades" swaggertype:"object"`
+}
+
 type ProviderHotelsQueryParams struct {
-	Limit      int  `schema:"limit"`
-	Offset     int  `schema:"offset"`
-	HotelID    uint `schema:"hotel_id"`
-	ProviderID uint `schema:"provider_id"`
+	Limit          int  `schema:"limit"`
+	Offset         int  `schema:"offset"`
+	HotelID        uint `schema:"hotel_id"`
+	ProviderID     uint `schema:"provider_id"`
+	IncludeDeleted bool `schema:"include_deleted"`
+	ResponseOptions
 }
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// PurgeResult counts the soft-deleted rows a purge removed for good.
+type PurgeResult struct {
+	// DeletedBefore is the end of the retention window. Rows deleted before it
+	// were purged.
+	DeletedBefore  time.Time `json:"deleted_before"`
+	Reviews        int64     `json:"reviews"`
+	ProviderHotels int64     `json:"provider_hotels"`
+	Hotels         int64     `json:"hotels"`
+	Providers      int64     `json:"providers"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// RangeEnd is the inclusive end of a date range, given either as an RFC 3339
+// timestamp or as a plain YYYY-MM-DD date. A plain date includes the whole day.
+type RangeEnd struct {
+	time.Time
+	// DateOnly is whether the end was given as a plain date.
+	DateOnly bool
+}
+
+// ParseRangeEnd parses an RFC 3339 timestamp or a YYYY-MM-DD date.
+func ParseRangeEnd(value string) (RangeEnd, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return RangeEnd{Time: t, DateOnly: true}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return RangeEnd{}, err
+	}
+	return RangeEnd{Time: t}, nil
+}
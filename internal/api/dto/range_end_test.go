@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRangeEnd(t *testing.T) {
+	end, err := ParseRangeEnd("2025-01-31")
+	assert.NoError(t, err)
+	assert.Equal(t, RangeEnd{Time: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), DateOnly: true}, end)
+
+	end, err = ParseRangeEnd("2025-01-31T00:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, RangeEnd{Time: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)}, end)
+
+	_, err = ParseRangeEnd("yesterday")
+	assert.Error(t, err)
+}
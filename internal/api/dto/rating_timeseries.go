@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"math"
+	"time"
+)
+
+// Bucket sizes of a rating time series.
+const (
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+type RatingTimeseriesQueryParams struct {
+	Interval   string    `schema:"interval"`
+	ProviderID uint      `schema:"provider_id"`
+	From       time.Time `schema:"from"`
+	To         RangeEnd  `schema:"to"`
+}
+
+// RatingTimeseries is the rating trend of a hotel, optionally for one provider.
+type RatingTimeseries struct {
+	HotelID    uint                     `json:"hotel_id"`
+	ProviderID *uint                    `json:"provider_id"`
+	Interval   string                   `json:"interval"`
+	Points     []*RatingTimeseriesPoint `json:"points"`
+}
+
+// RatingTimeseriesPoint aggregates the reviews dated within one bucket. Weeks
+// start on Monday and buckets are in UTC. The averages and percentiles are nil
+// for buckets without reviews.
+type RatingTimeseriesPoint struct {
+	Start         time.Time `json:"start"`
+	ReviewCount   int       `json:"review_count"`
+	AverageRating *float64  `json:"average_rating"`
+	P25           *float64  `json:"p25"`
+	Median        *float64  `json:"median"`
+	P75           *float64  `json:"p75"`
+	P90           *float64  `json:"p90"`
+}
+
+// NewRatingTimeseries builds a time series from the non-empty buckets returned
+// by the database, which must be in order. The gaps between them are filled with
+// empty buckets so that charts have an evenly spaced axis.
+func NewRatingTimeseries(hotelID uint, params *RatingTimeseriesQueryParams, points []*RatingTimeseriesPoint) *RatingTimeseries {
+	series := &RatingTimeseries{
+		HotelID:  hotelID,
+		Interval: params.Interval,
+		Points:   []*RatingTimeseriesPoint{},
+	}
+	if params.ProviderID != 0 {
+		providerID := params.ProviderID
+		series.ProviderID = &providerID
+	}
+
+	for _, point := range points {
+		if n := len(series.Points); n > 0 {
+			for start := nextBucket(series.Points[n-1].Start, params.Interval); start.Before(point.Start); start = nextBucket(start, params.Interval) {
+				series.Points = append(series.Points, &RatingTimeseriesPoint{Start: start})
+			}
+		}
+
+		point.Start = point.Start.UTC()
+		point.AverageRating = roundRatingPtr(point.AverageRating)
+		point.P25 = roundRatingPtr(point.P25)
+		point.Median = roundRatingPtr(point.Median)
+		point.P75 = roundRatingPtr(point.P75)
+		point.P90 = roundRatingPtr(point.P90)
+		series.Points = append(series.Points, point)
+	}
+
+	return series
+}
+
+func nextBucket(start time.Time, interval string) time.Time {
+	if interval == IntervalWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+func roundRatingPtr(rating *float64) *float64 {
+	if rating == nil {
+		return nil
+	}
+	return roundRating(*rating)
+}
+
+// roundRating rounds an average rating to two decimal places.
+func roundRating(rating float64) *float64 {
+	rounded := math.Round(rating*100) / 100
+	return &rounded
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRatingTimeseries(t *testing.T) {
+	float := func(f float64) *float64 { return &f }
+
+	t.Run("fills empty months", func(t *testing.T) {
+		params := &RatingTimeseriesQueryParams{Interval: IntervalMonth, ProviderID: 332}
+		points := []*RatingTimeseriesPoint{
+			{Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ReviewCount: 2, AverageRating: float(7.333333), Median: float(7.5)},
+			{Start: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), ReviewCount: 1, AverageRating: float(9), Median: float(9)},
+		}
+
+		series := NewRatingTimeseries(10984, params, points)
+
+		assert.Equal(t, uint(10984), series.HotelID)
+		assert.Equal(t, uint(332), *series.ProviderID)
+		assert.Equal(t, IntervalMonth, series.Interval)
+		assert.Len(t, series.Points, 4)
+		assert.Equal(t, 7.33, *series.Points[0].AverageRating)
+		assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), series.Points[1].Start)
+		assert.Equal(t, 0, series.Points[1].ReviewCount)
+		assert.Nil(t, series.Points[1].AverageRating)
+		assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), series.Points[2].Start)
+		assert.Equal(t, 1, series.Points[3].ReviewCount)
+	})
+
+	t.Run("fills empty weeks", func(t *testing.T) {
+		params := &RatingTimeseriesQueryParams{Interval: IntervalWeek}
+		points := []*RatingTimeseriesPoint{
+			{Start: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), ReviewCount: 1},
+			{Start: time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC), ReviewCount: 1},
+		}
+
+		series := NewRatingTimeseries(1, params, points)
+
+		assert.Nil(t, series.ProviderID)
+		assert.Len(t, series.Points, 3)
+		assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), series.Points[1].Start)
+	})
+
+	t.Run("no reviews", func(t *testing.T) {
+		series := NewRatingTimeseries(1, &RatingTimeseriesQueryParams{Interval: IntervalMonth}, nil)
+		assert.NotNil(t, series.Points)
+		assert.Empty(t, series.Points)
+	})
+}
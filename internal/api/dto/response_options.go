@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Relations that can be embedded in review and provider-hotel responses.
+const (
+	RelationProvider = "provider"
+	RelationHotel    = "hotel"
+	RelationResponse = "response"
+)
+
+// ReviewRelations and ProviderHotelRelations list what include accepts for
+// reviews and provider-hotel mappings. Providers and hotels have no relations.
+var (
+	ReviewRelations        = []string{RelationProvider, RelationHotel, RelationResponse}
+	ProviderHotelRelations = []string{RelationProvider, RelationHotel}
+)
+
+// ResponseOptions shape the body of list and detail responses. Include names
+// the related entities to embed and Fields the top-level fields to keep, each
+// as a comma-separated list.
+type ResponseOptions struct {
+	Include string `schema:"include"`
+	Fields  string `schema:"fields"`
+}
+
+// NewResponseOptions reads the include and fields query parameters of a detail
+// request, which has no other query parameters to decode.
+func NewResponseOptions(query url.Values) ResponseOptions {
+	return ResponseOptions{
+		Include: query.Get("include"),
+		Fields:  query.Get("fields"),
+	}
+}
+
+// IncludeList returns the relations to embed.
+func (o ResponseOptions) IncludeList() []string {
+	return splitList(o.Include)
+}
+
+// FieldList returns the fields to keep. Embedded relations are always kept, so
+// fields does not need to repeat them. An empty list keeps every field.
+func (o ResponseOptions) FieldList() []string {
+	fields := splitList(o.Fields)
+	if len(fields) == 0 {
+		return nil
+	}
+	return append(fields, o.IncludeList()...)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,25 +1,113 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
 
-type ReviewRequest struct {
-	ProductID  string  `json:"product_id"`
-	Rating     float32 `json:"rating"`
-	ExternalID string  `json:"external_id"`
-	Comment    string  `json:"comment"`
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// ReviewRequestBody is used to create a review or fully replace an existing one.
+type ReviewRequestBody struct {
+	ID           uint            `json:"id"`
+	ProviderID   uint            `json:"provider_id"`
+	HotelID      uint            `json:"hotel_id"`
+	Rating       float64         `json:"rating"`
+	Title        string          `json:"title"`
+	Comment      string          `json:"comment"`
+	Lang         string          `json:"lang"`
+	ReviewDate   time.Time       `json:"review_date"`
+	ReviewerInfo json.RawMessage `json:"reviewer_info" swaggertype:"object"`
 }
 
-type ReviewResponse struct {
-	ID        string    `json:"id"`
-	ProductID string    `json:"product_id"`
-	Rating    float32   `json:"rating"`
-	Comment   string    `json:"comment"`
-	CreatedAt time.Time `json:"created_at"`
+// ReviewPatchBody is used to partially update a review. Only the fields that
+// are present in the request are changed.
+type ReviewPatchBody struct {
+	ProviderID   *uint           `json:"provider_id"`
+	HotelID      *uint           `json:"hotel_id"`
+	Rating       *float64        `json:"rating"`
+	Title        *string         `json:"title"`
+	Comment      *string         `json:"comment"`
+	Lang         *string         `json:"lang"`
+	ReviewDate   *time.Time      `json:"review_date"`
+	ReviewerInfo json.RawMessage `json:"reviewer_info" swaggertype:"object"`
 }
 
+// ReviewSortFields lists the fields the reviews list can be sorted by. A field
+// prefixed with "-" sorts in descending order.
+var ReviewSortFields = []string{"review_date", "rating", "created_at"}
+
+// Pagination modes of the reviews list.
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+)
+
 type ReviewQueryParams struct {
-	Limit      int  `schema:"limit"`
-	Offset     int  `schema:"offset"`
-	HotelID    uint `schema:"hotel_id"`
-	ProviderID uint `schema:"provider_id"`
+	Limit          int       `schema:"limit"`
+	Offset         int       `schema:"offset"`
+	HotelID        uint      `schema:"hotel_id"`
+	ProviderID     uint      `schema:"provider_id"`
+	MinRating      *float64  `schema:"min_rating"`
+	MaxRating      *float64  `schema:"max_rating"`
+	ReviewDateFrom time.Time `schema:"review_date_from"`
+	ReviewDateTo   RangeEnd  `schema:"review_date_to"`
+	Lang           string    `schema:"lang"`
+	TravelerType   string    `schema:"traveler_type"`
+	Country        string    `schema:"country"`
+	HasComment     *bool     `schema:"has_comment"`
+	Q              string    `schema:"q"`
+	Sort           string    `schema:"sort"`
+	Pagination     string    `schema:"pagination"`
+	Cursor         string    `schema:"cursor"`
+	Status         string    `schema:"status"`
+	IncludeDeleted bool      `schema:"include_deleted"`
+	ResponseOptions
+}
+
+// StatusList returns the moderation statuses to list, published reviews only
+// unless the status parameter asks for others.
+func (q *ReviewQueryParams) StatusList() []string {
+	statuses := splitList(q.Status)
+	if len(statuses) == 0 {
+		return []string{models.ReviewStatusPublished}
+	}
+	return statuses
+}
+
+// CursorMode reports whether the request pages with cursors rather than offsets.
+// Passing a cursor implies cursor pagination.
+func (q *ReviewQueryParams) CursorMode() bool {
+	return q.Pagination == PaginationCursor || q.Cursor != ""
+}
+
+// ParseReviewSort splits a sort parameter into its column and direction. An
+// empty or unknown sort is the default order, most recently updated first.
+func ParseReviewSort(sort string) (field string, desc bool) {
+	field = strings.TrimPrefix(sort, "-")
+	if !slices.Contains(ReviewSortFields, field) {
+		return "updated_at", true
+	}
+	return field, strings.HasPrefix(sort, "-")
+}
+
+// ReviewPage is one page of the reviews list in cursor mode. Next and Prev are
+// nil at either end of the list.
+type ReviewPage struct {
+	Reviews []*models.Review
+	Total   int
+	Next    *string
+	Prev    *string
+}
+
+// ReviewSearchResult is a review matched by a full-text search, with its
+// relevance and a highlighted excerpt of the comment.
+type ReviewSearchResult struct {
+	models.Review
+	Rank float64 `json:"rank"`
+	// Snippet is HTML: the comment is escaped and the matching words are
+	// wrapped in <mark>.
+	Snippet string `json:"snippet"`
 }
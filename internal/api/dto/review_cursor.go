@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/kirananto/review-system/internal/models"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ReviewCursor marks a position in the reviews list for keyset pagination. It
+// holds the sort value and ID of the review a page starts after, or before when
+// paging backwards. Clients treat the encoded cursor as opaque.
+type ReviewCursor struct {
+	Sort     string     `json:"s"`
+	Time     *time.Time `json:"t,omitempty"`
+	Rating   *float64   `json:"r,omitempty"`
+	ID       uint       `json:"id"`
+	Backward bool       `json:"b,omitempty"`
+}
+
+// NewReviewCursor returns a cursor positioned at review for the given sort.
+func NewReviewCursor(sort string, review *models.Review, backward bool) *ReviewCursor {
+	cursor := &ReviewCursor{Sort: sort, ID: review.ID, Backward: backward}
+
+	field, _ := ParseReviewSort(sort)
+	switch field {
+	case "rating":
+		cursor.Rating = &review.Rating
+	case "review_date":
+		cursor.Time = &review.ReviewDate
+	case "created_at":
+		cursor.Time = &review.CreatedAt
+	default:
+		cursor.Time = &review.UpdatedAt
+	}
+	return cursor
+}
+
+// DecodeReviewCursor parses a cursor produced by Encode.
+func DecodeReviewCursor(encoded string) (*ReviewCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor ReviewCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.ID == 0 || (cursor.Time == nil) == (cursor.Rating == nil) {
+		return nil, ErrInvalidCursor
+	}
+	if field, _ := ParseReviewSort(cursor.Sort); (field == "rating") != (cursor.Rating != nil) {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// Encode returns the opaque form of the cursor used in query parameters.
+func (c *ReviewCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Value returns the sort value the cursor is positioned at.
+func (c *ReviewCursor) Value() interface{} {
+	if c.Rating != nil {
+		return *c.Rating
+	}
+	return *c.Time
+}
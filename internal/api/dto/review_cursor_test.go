@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewCursor_RoundTrip(t *testing.T) {
+	review := &models.Review{
+		ID:         42,
+		Rating:     7.5,
+		ReviewDate: time.Date(2025, 4, 10, 5, 37, 0, 123456000, time.UTC),
+	}
+
+	t.Run("time sort", func(t *testing.T) {
+		cursor := NewReviewCursor("-review_date", review, true)
+
+		decoded, err := DecodeReviewCursor(cursor.Encode())
+		assert.NoError(t, err)
+		assert.Equal(t, "-review_date", decoded.Sort)
+		assert.Equal(t, uint(42), decoded.ID)
+		assert.True(t, decoded.Backward)
+		assert.True(t, review.ReviewDate.Equal(decoded.Value().(time.Time)))
+	})
+
+	t.Run("rating sort", func(t *testing.T) {
+		cursor := NewReviewCursor("rating", review, false)
+
+		decoded, err := DecodeReviewCursor(cursor.Encode())
+		assert.NoError(t, err)
+		assert.False(t, decoded.Backward)
+		assert.Equal(t, 7.5, decoded.Value())
+	})
+}
+
+func TestDecodeReviewCursor_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "not base64", encoded: "%%%"},
+		{name: "not json", encoded: "bm90IGpzb24"},
+		{name: "missing id", encoded: (&ReviewCursor{Sort: "rating", Rating: new(float64)}).Encode()},
+		{name: "missing value", encoded: (&ReviewCursor{Sort: "rating", ID: 1}).Encode()},
+		{name: "value does not match sort", encoded: (&ReviewCursor{Sort: "rating", Time: &time.Time{}, ID: 1}).Encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeReviewCursor(tt.encoded)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package dto
+
+// Formats a review export can be streamed in.
+const (
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatCSV    = "csv"
+)
+
+// ReviewExportQueryParams selects the reviews to export with the same filters
+// and sort as the reviews list. Paging parameters do not apply to exports.
+type ReviewExportQueryParams struct {
+	ReviewQueryParams
+	Format string `schema:"format"`
+}
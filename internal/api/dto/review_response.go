@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"math"
+	"time"
+)
+
+// ReviewResponseRequestBody is a hotel's response to a review, as posted
+// through the API.
+type ReviewResponseRequestBody struct {
+	ResponderName string `json:"responder_name"`
+	Body          string `json:"body"`
+}
+
+type ResponseMetricsQueryParams struct {
+	ProviderID uint      `schema:"provider_id"`
+	From       time.Time `schema:"from"`
+	To         RangeEnd  `schema:"to"`
+}
+
+// ReviewResponseStats aggregates the responses to the reviews of a hotel. The
+// response times are nil when no response has a date.
+type ReviewResponseStats struct {
+	ReviewCount          int
+	ResponseCount        int
+	AverageResponseHours *float64
+	MedianResponseHours  *float64
+}
+
+// HotelResponseMetrics reports how often and how quickly a hotel responds to
+// its reviews, optionally for one provider. ResponseRate is the share of
+// reviews with a response, from 0 to 1, and is nil for hotels without reviews.
+// Response times run from the review date to the response date.
+type HotelResponseMetrics struct {
+	HotelID              uint     `json:"hotel_id"`
+	ProviderID           *uint    `json:"provider_id"`
+	ReviewCount          int      `json:"review_count"`
+	ResponseCount        int      `json:"response_count"`
+	ResponseRate         *float64 `json:"response_rate"`
+	AverageResponseHours *float64 `json:"average_response_hours"`
+	MedianResponseHours  *float64 `json:"median_response_hours"`
+}
+
+// NewHotelResponseMetrics builds the response metrics of a hotel from the
+// aggregated responses to its reviews.
+func NewHotelResponseMetrics(hotelID uint, params *ResponseMetricsQueryParams, stats *ReviewResponseStats) *HotelResponseMetrics {
+	metrics := &HotelResponseMetrics{
+		HotelID:              hotelID,
+		ReviewCount:          stats.ReviewCount,
+		ResponseCount:        stats.ResponseCount,
+		AverageResponseHours: roundRatingPtr(stats.AverageResponseHours),
+		MedianResponseHours:  roundRatingPtr(stats.MedianResponseHours),
+	}
+	if params.ProviderID != 0 {
+		providerID := params.ProviderID
+		metrics.ProviderID = &providerID
+	}
+	if stats.ReviewCount > 0 {
+		rate := math.Round(float64(stats.ResponseCount)/float64(stats.ReviewCount)*10000) / 10000
+		metrics.ResponseRate = &rate
+	}
+	return metrics
+}
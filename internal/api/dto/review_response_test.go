@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHotelResponseMetrics(t *testing.T) {
+	t.Run("with responses", func(t *testing.T) {
+		average, median := 40.123, 26.0
+		stats := &ReviewResponseStats{ReviewCount: 3, ResponseCount: 2, AverageResponseHours: &average, MedianResponseHours: &median}
+
+		metrics := NewHotelResponseMetrics(7, &ResponseMetricsQueryParams{ProviderID: 2}, stats)
+
+		assert.Equal(t, uint(7), metrics.HotelID)
+		assert.Equal(t, uint(2), *metrics.ProviderID)
+		assert.Equal(t, 2, metrics.ResponseCount)
+		assert.Equal(t, 0.6667, *metrics.ResponseRate)
+		assert.Equal(t, 40.12, *metrics.AverageResponseHours)
+		assert.Equal(t, 26.0, *metrics.MedianResponseHours)
+	})
+
+	t.Run("without reviews", func(t *testing.T) {
+		metrics := NewHotelResponseMetrics(7, &ResponseMetricsQueryParams{}, &ReviewResponseStats{})
+
+		assert.Nil(t, metrics.ProviderID)
+		assert.Nil(t, metrics.ResponseRate)
+		assert.Nil(t, metrics.AverageResponseHours)
+	})
+}
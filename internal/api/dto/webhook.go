@@ -0,0 +1,42 @@
+package dto
+
+import "github.com/kirananto/review-system/internal/models"
+
+// WebhookSubscriptionRequestBody is used to create a webhook subscription or
+// fully replace an existing one.
+type WebhookSubscriptionRequestBody struct {
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	EventTypes  []string `json:"event_types"`
+	HotelID     uint     `json:"hotel_id"`
+	ProviderID  uint     `json:"provider_id"`
+	Disabled    bool     `json:"disabled"`
+	// Secret signs the deliveries. A secret is generated when a subscription
+	// is created without one, and kept when it is updated without one.
+	Secret string `json:"secret"`
+}
+
+// WebhookSubscriptionWithSecret is a subscription along with its secret. The
+// secret is only returned when it is set, by creating the subscription or
+// rotating its secret.
+type WebhookSubscriptionWithSecret struct {
+	*models.WebhookSubscription
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookSubscriptionsQueryParams struct {
+	Limit      int    `schema:"limit"`
+	Offset     int    `schema:"offset"`
+	EventType  string `schema:"event_type"`
+	HotelID    uint   `schema:"hotel_id"`
+	ProviderID uint   `schema:"provider_id"`
+}
+
+type WebhookDeliveriesQueryParams struct {
+	Limit          int    `schema:"limit"`
+	Offset         int    `schema:"offset"`
+	SubscriptionID uint   `schema:"subscription_id"`
+	EventType      string `schema:"event_type"`
+	EventID        string `schema:"event_id"`
+	Status         string `schema:"status"`
+}
@@ -0,0 +1,109 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/kirananto/review-system/internal/logger"
+)
+
+// latestReviewsKey identifies the latest reviews of a hotel.
+type latestReviewsKey struct {
+	hotelID uint
+	limit   int
+}
+
+// loaders batch the relations resolved during one request. Fetched entities
+// get their resolvers right away, so entities fetched together are siblings.
+type loaders struct {
+	hotels         *loader[uint, *hotelResolver]
+	providers      *loader[uint, *providerResolver]
+	hotelProviders *loader[uint, []*providerHotelResolver]
+	providerHotels *loader[uint, []*providerHotelResolver]
+	latestReviews  *loader[latestReviewsKey, []*reviewResolver]
+}
+
+func newLoaders(services Services, log *logger.Logger) *loaders {
+	return &loaders{
+		hotels: newLoader(func(ctx context.Context, ids []uint) (map[uint]*hotelResolver, error) {
+			hotels, errorDetails := services.Hotels.GetHotelsByIDs(ids)
+			if errorDetails != nil {
+				return nil, serviceError(ctx, log, errorDetails)
+			}
+			byID := make(map[uint]*hotelResolver, len(hotels))
+			for _, hotel := range newHotelResolvers(hotels) {
+				byID[hotel.hotel.ID] = hotel
+			}
+			return byID, nil
+		}),
+		providers: newLoader(func(ctx context.Context, ids []uint) (map[uint]*providerResolver, error) {
+			providers, errorDetails := services.Providers.GetProvidersByIDs(ids)
+			if errorDetails != nil {
+				return nil, serviceError(ctx, log, errorDetails)
+			}
+			byID := make(map[uint]*providerResolver, len(providers))
+			for _, provider := range newProviderResolvers(providers) {
+				byID[provider.provider.ID] = provider
+			}
+			return byID, nil
+		}),
+		hotelProviders: newLoader(func(ctx context.Context, hotelIDs []uint) (map[uint][]*providerHotelResolver, error) {
+			providerHotels, errorDetails := services.ProviderHotels.GetProviderHotelsByHotelIDs(hotelIDs)
+			if errorDetails != nil {
+				return nil, serviceError(ctx, log, errorDetails)
+			}
+			byHotelID := make(map[uint][]*providerHotelResolver, len(hotelIDs))
+			for _, providerHotel := range newProviderHotelResolvers(providerHotels) {
+				hotelID := providerHotel.providerHotel.HotelID
+				byHotelID[hotelID] = append(byHotelID[hotelID], providerHotel)
+			}
+			return byHotelID, nil
+		}),
+		providerHotels: newLoader(func(ctx context.Context, providerIDs []uint) (map[uint][]*providerHotelResolver, error) {
+			providerHotels, errorDetails := services.ProviderHotels.GetProviderHotelsByProviderIDs(providerIDs)
+			if errorDetails != nil {
+				return nil, serviceError(ctx, log, errorDetails)
+			}
+			byProviderID := make(map[uint][]*providerHotelResolver, len(providerIDs))
+			for _, providerHotel := range newProviderHotelResolvers(providerHotels) {
+				providerID := providerHotel.providerHotel.ProviderID
+				byProviderID[providerID] = append(byProviderID[providerID], providerHotel)
+			}
+			return byProviderID, nil
+		}),
+		latestReviews: newLoader(func(ctx context.Context, keys []latestReviewsKey) (map[latestReviewsKey][]*reviewResolver, error) {
+			// Hotels asked for the same number of reviews are fetched together
+			var limits []int
+			hotelIDs := make(map[int][]uint)
+			for _, key := range keys {
+				if _, ok := hotelIDs[key.limit]; !ok {
+					limits = append(limits, key.limit)
+				}
+				hotelIDs[key.limit] = append(hotelIDs[key.limit], key.hotelID)
+			}
+
+			byKey := make(map[latestReviewsKey][]*reviewResolver, len(keys))
+			for _, limit := range limits {
+				reviews, errorDetails := services.Reviews.GetLatestReviewsByHotelIDs(hotelIDs[limit], limit)
+				if errorDetails != nil {
+					return nil, serviceError(ctx, log, errorDetails)
+				}
+				for _, review := range newReviewResolvers(reviews) {
+					key := latestReviewsKey{hotelID: review.review.HotelID, limit: limit}
+					byKey[key] = append(byKey[key], review)
+				}
+			}
+			return byKey, nil
+		}),
+	}
+}
+
+type loadersContextKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersContextKey{}, l)
+}
+
+// loadersFromContext returns the loaders of the request the handler attached.
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersContextKey{}).(*loaders)
+}
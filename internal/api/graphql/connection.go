@@ -0,0 +1,144 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// MaxPageSize is the most nodes a connection returns at once.
+const MaxPageSize = 100
+
+// cursorPrefix marks the offsets encoded in cursors, which are opaque to clients.
+const cursorPrefix = "offset:"
+
+// encodeCursor returns the cursor of the node at offset.
+func encodeCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset a cursor points at.
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %w", err)
+	}
+	offsetText, ok := strings.CutPrefix(string(decoded), cursorPrefix)
+	if !ok {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	offset, err := strconv.Atoi(offsetText)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	return offset, nil
+}
+
+// pageBounds returns the limit and offset of the page of first nodes after
+// the after cursor.
+func pageBounds(ctx context.Context, first int32, after *string) (limit, offset int, err error) {
+	limit = int(first)
+	if limit < 1 || limit > MaxPageSize {
+		return 0, 0, validationError(ctx, "first", fmt.Sprintf("first must be between 1 and %d", MaxPageSize))
+	}
+	if after != nil {
+		cursorOffset, err := decodeCursor(*after)
+		if err != nil {
+			return 0, 0, validationError(ctx, "after", "after must be a cursor returned by this connection")
+		}
+		offset = cursorOffset + 1
+	}
+	return limit, offset, nil
+}
+
+// parseID returns the numeric ID of an ID argument.
+func parseID(ctx context.Context, field string, id graphqlgo.ID) (uint, error) {
+	value, err := strconv.ParseUint(string(id), 10, 0)
+	if err != nil || value == 0 {
+		return 0, validationError(ctx, field, fmt.Sprintf("%s must be a positive integer", field))
+	}
+	return uint(value), nil
+}
+
+// parseOptionalID returns the numeric ID of an optional ID argument, or 0.
+func parseOptionalID(ctx context.Context, field string, id *graphqlgo.ID) (uint, error) {
+	if id == nil {
+		return 0, nil
+	}
+	return parseID(ctx, field, *id)
+}
+
+// connection is a page of nodes starting at offset, out of total.
+type connection[T any] struct {
+	nodes  []T
+	offset int
+	total  int
+}
+
+func newConnection[T any](nodes []T, offset, total int) *connection[T] {
+	return &connection[T]{nodes: nodes, offset: offset, total: total}
+}
+
+func (c *connection[T]) Edges() []*edge[T] {
+	edges := make([]*edge[T], len(c.nodes))
+	for i, node := range c.nodes {
+		edges[i] = &edge[T]{node: node, cursor: encodeCursor(c.offset + i)}
+	}
+	return edges
+}
+
+func (c *connection[T]) PageInfo() *pageInfo {
+	info := &pageInfo{
+		hasNextPage:     c.offset+len(c.nodes) < c.total,
+		hasPreviousPage: c.offset > 0,
+	}
+	if len(c.nodes) > 0 {
+		start, end := encodeCursor(c.offset), encodeCursor(c.offset+len(c.nodes)-1)
+		info.startCursor, info.endCursor = &start, &end
+	}
+	return info
+}
+
+func (c *connection[T]) TotalCount() int32 {
+	return int32(c.total)
+}
+
+type edge[T any] struct {
+	node   T
+	cursor string
+}
+
+func (e *edge[T]) Cursor() string {
+	return e.cursor
+}
+
+func (e *edge[T]) Node() T {
+	return e.node
+}
+
+type pageInfo struct {
+	hasNextPage     bool
+	hasPreviousPage bool
+	startCursor     *string
+	endCursor       *string
+}
+
+func (p *pageInfo) HasNextPage() bool {
+	return p.hasNextPage
+}
+
+func (p *pageInfo) HasPreviousPage() bool {
+	return p.hasPreviousPage
+}
+
+func (p *pageInfo) StartCursor() *string {
+	return p.startCursor
+}
+
+func (p *pageInfo) EndCursor() *string {
+	return p.endCursor
+}
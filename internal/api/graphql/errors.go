@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/logger"
+)
+
+// queryError is an error of a query field. It carries the same code, status
+// and trace ID as a problem response of the REST API, as error extensions.
+type queryError struct {
+	problem *response.Problem
+}
+
+func (e *queryError) Error() string {
+	return e.problem.Detail
+}
+
+// Extensions implements the extensions of a GraphQL error.
+func (e *queryError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{
+		"code":   e.problem.Code,
+		"status": e.problem.Status,
+	}
+	if e.problem.TraceID != "" {
+		extensions["trace_id"] = e.problem.TraceID
+	}
+	if len(e.problem.Errors) > 0 {
+		extensions["errors"] = e.problem.Errors
+	}
+	return extensions
+}
+
+// newQueryError returns an error with the generic code of statusCode.
+func newQueryError(ctx context.Context, statusCode int, detail string) error {
+	problem := response.NewProblem(statusCode, "", detail)
+	problem.TraceID = response.TraceID(ctx)
+	return &queryError{problem: problem}
+}
+
+// validationError returns the error of an argument that failed validation.
+func validationError(ctx context.Context, field, message string) error {
+	return serviceError(ctx, nil, response.ValidationErrorDetails(field, message, nil))
+}
+
+// serviceError returns the error a service returned. As in the REST API only
+// the message reaches the client, so the cause of a server error is logged
+// with the request's trace ID instead.
+func serviceError(ctx context.Context, log *logger.Logger, details *response.ErrorDetails) error {
+	problem := response.NewProblem(details.Code, details.ErrorCode, details.Message)
+	problem.TraceID = response.TraceID(ctx)
+	problem.Errors = details.Fields
+	if details.Code >= http.StatusInternalServerError && log != nil {
+		log.Error(details.Error, fmt.Sprintf("GraphQL query failed with %d (trace ID %s)", details.Code, problem.TraceID))
+	}
+	return &queryError{problem: problem}
+}
+
+// panicHandler answers a resolver panic with an internal error, rather than
+// the panic value the library would report. The library logs the panic.
+type panicHandler struct{}
+
+func (panicHandler) MakePanicError(ctx context.Context, value interface{}) *gqlerrors.QueryError {
+	err := newQueryError(ctx, http.StatusInternalServerError, "Internal server error").(*queryError)
+	return &gqlerrors.QueryError{Message: err.Error(), Extensions: err.Extensions()}
+}
@@ -0,0 +1,87 @@
+// Package graphql serves the read API over hotels, providers and reviews as
+// a GraphQL endpoint.
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/logger"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// MaxDepth bounds how deeply a query may nest, as relations can cycle.
+const MaxDepth = 10
+
+// Handler executes GraphQL queries. It runs behind the same middleware as the
+// REST API, so the caller's scopes apply to the queries as well.
+type Handler struct {
+	schema   *graphqlgo.Schema
+	services Services
+	logger   *logger.Logger
+}
+
+func NewHandler(services Services, log *logger.Logger) *Handler {
+	resolver := &queryResolver{services: services, logger: log}
+	return &Handler{
+		schema: graphqlgo.MustParseSchema(schema, resolver,
+			graphqlgo.MaxDepth(MaxDepth),
+			graphqlgo.PanicHandler(panicHandler{}),
+		),
+		services: services,
+		logger:   log,
+	}
+}
+
+// request is a GraphQL request, sent as a JSON body or, for GET, as query
+// parameters with the variables encoded as JSON.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP godoc
+// @Summary Execute a GraphQL query
+// @Description Execute a query against the GraphQL schema of hotels, providers, provider scores, reviews and audit logs. Errors of individual fields are reported in the errors of the result, with the error code and trace ID as extensions.
+// @Accept json
+// @Produce json
+// @Param request body object true "GraphQL request with query, operationName and variables"
+// @Success 200 {object} object
+// @Failure 400 {object} response.Problem
+// @Router /graphql [post]
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+	if req.Query == "" {
+		response.WriteErrorDetails(w, r, response.ValidationErrorDetails("query", "query is required", nil))
+		return
+	}
+
+	// Loaders batch and cache lookups for this request only
+	ctx := withLoaders(r.Context(), newLoaders(h.services, h.logger))
+	result := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error(err, "Failed to write GraphQL response")
+	}
+}
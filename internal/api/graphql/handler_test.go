@@ -0,0 +1,537 @@
+package graphql_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/graphql"
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service/mock"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockServices struct {
+	hotels         *mock.MockHotelService
+	providers      *mock.MockProviderService
+	providerHotels *mock.MockProviderHotelService
+	reviews        *mock.MockReviewService
+	auditLogs      *mock.MockAuditLogService
+}
+
+func newTestHandler(ctrl *gomock.Controller) (*graphql.Handler, *mockServices) {
+	mocks := &mockServices{
+		hotels:         mock.NewMockHotelService(ctrl),
+		providers:      mock.NewMockProviderService(ctrl),
+		providerHotels: mock.NewMockProviderHotelService(ctrl),
+		reviews:        mock.NewMockReviewService(ctrl),
+		auditLogs:      mock.NewMockAuditLogService(ctrl),
+	}
+	log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+	h := graphql.NewHandler(graphql.Services{
+		Hotels:         mocks.hotels,
+		Providers:      mocks.providers,
+		ProviderHotels: mocks.providerHotels,
+		Reviews:        mocks.reviews,
+		AuditLogs:      mocks.auditLogs,
+	}, log)
+	return h, mocks
+}
+
+// graphQLResult is the body of an executed query.
+type graphQLResult struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Path       []interface{}          `json:"path"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// testAPIKeys are the API keys the tests call with.
+var testAPIKeys = []middleware.APIKey{
+	{Key: "secret", Name: "client"},
+	{Key: "admin-secret", Name: "admin", Scopes: []string{middleware.ScopeAdmin}},
+	{Key: "hotel-secret", Name: "hotel", Scopes: []string{middleware.ScopeRespond}, HotelID: 1},
+}
+
+func TestMain(m *testing.M) {
+	middleware.SetAPIKeys(testAPIKeys)
+	os.Exit(m.Run())
+}
+
+// query posts a query as apiKey through the same middleware as the router.
+func query(h http.Handler, apiKey, query string, variables map[string]interface{}) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	rr := httptest.NewRecorder()
+	middleware.Trace(middleware.Auth(h)).ServeHTTP(rr, req)
+	return rr
+}
+
+func decodeResult(t *testing.T, rr *httptest.ResponseRecorder) graphQLResult {
+	t.Helper()
+	var result graphQLResult
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+	return result
+}
+
+func TestHandler_Hotels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("batches_relations", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		hotels := []*models.Hotel{{ID: 1, HotelName: "Alpha"}, {ID: 2, HotelName: "Beta"}}
+		reviewDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+		mocks.hotels.EXPECT().GetHotelsList(&dto.HotelsQueryParams{Limit: 2, Offset: 0, City: "Hanoi"}).Return(hotels, 3, nil)
+		// One call per relation, however many hotels are listed
+		mocks.providerHotels.EXPECT().GetProviderHotelsByHotelIDs([]uint{1, 2}).Return([]*models.ProviderHotel{
+			{HotelID: 1, ProviderID: 10, OverallScore: 8.5},
+			{HotelID: 2, ProviderID: 11, OverallScore: 7},
+			{HotelID: 1, ProviderID: 11, OverallScore: 9},
+		}, nil)
+		mocks.providers.EXPECT().GetProvidersByIDs([]uint{10, 11}).Return([]*models.Provider{{ID: 10, Name: "Agoda"}, {ID: 11, Name: "Booking.com"}}, nil)
+		mocks.reviews.EXPECT().GetLatestReviewsByHotelIDs([]uint{1, 2}, 2).Return([]*models.Review{
+			{ID: 100, HotelID: 1, Rating: 9, Title: "Great", ReviewDate: reviewDate, Status: models.ReviewStatusPublished},
+		}, nil)
+
+		// Act
+		rr := query(h, "secret", `query ($city: String) {
+			hotels(first: 2, filter: {city: $city}) {
+				totalCount
+				pageInfo { hasNextPage hasPreviousPage endCursor }
+				edges { node {
+					id name
+					providers { overallScore provider { name } }
+					latestReviews(first: 2) { id title }
+				} }
+			}
+		}`, map[string]interface{}{"city": "Hanoi"})
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Empty(t, result.Errors)
+		assert.JSONEq(t, `{"hotels": {
+			"totalCount": 3,
+			"pageInfo": {"hasNextPage": true, "hasPreviousPage": false, "endCursor": "b2Zmc2V0OjE="},
+			"edges": [
+				{"node": {"id": "1", "name": "Alpha",
+					"providers": [{"overallScore": 8.5, "provider": {"name": "Agoda"}}, {"overallScore": 9, "provider": {"name": "Booking.com"}}],
+					"latestReviews": [{"id": "100", "title": "Great"}]}},
+				{"node": {"id": "2", "name": "Beta",
+					"providers": [{"overallScore": 7, "provider": {"name": "Booking.com"}}],
+					"latestReviews": []}}
+			]
+		}}`, string(result.Data))
+	})
+
+	t.Run("after_cursor", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		mocks.hotels.EXPECT().GetHotelsList(&dto.HotelsQueryParams{Limit: 2, Offset: 2}).Return([]*models.Hotel{{ID: 3, HotelName: "Gamma"}}, 3, nil)
+
+		// Act
+		rr := query(h, "secret", `{ hotels(first: 2, after: "b2Zmc2V0OjE=") {
+			pageInfo { hasNextPage hasPreviousPage startCursor }
+			edges { cursor node { name } }
+		} }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Empty(t, result.Errors)
+		assert.JSONEq(t, `{"hotels": {
+			"pageInfo": {"hasNextPage": false, "hasPreviousPage": true, "startCursor": "b2Zmc2V0OjI="},
+			"edges": [{"cursor": "b2Zmc2V0OjI=", "node": {"name": "Gamma"}}]
+		}}`, string(result.Data))
+	})
+
+	t.Run("invalid_first", func(t *testing.T) {
+		// Arrange
+		h, _ := newTestHandler(ctrl)
+
+		// Act
+		rr := query(h, "secret", `{ hotels(first: 500) { totalCount } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, "first must be between 1 and 100", result.Errors[0].Message)
+		assert.Equal(t, response.CodeValidationFailed, result.Errors[0].Extensions["code"])
+		assert.Equal(t, float64(http.StatusBadRequest), result.Errors[0].Extensions["status"])
+		assert.NotEmpty(t, result.Errors[0].Extensions["trace_id"])
+	})
+
+	t.Run("include_deleted_requires_admin", func(t *testing.T) {
+		// Arrange
+		h, _ := newTestHandler(ctrl)
+
+		// Act
+		rr := query(h, "secret", `{ hotels(filter: {includeDeleted: true}) { totalCount } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, response.CodeForbidden, result.Errors[0].Extensions["code"])
+	})
+
+	t.Run("internal_error_not_leaked", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		mocks.hotels.EXPECT().GetHotelsList(gomock.Any()).Return(nil, 0, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   errors.New("pq: connection refused"),
+		})
+
+		// Act
+		rr := query(h, "secret", `{ hotels { totalCount } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, "Internal server error", result.Errors[0].Message)
+		assert.Equal(t, response.CodeInternalServerError, result.Errors[0].Extensions["code"])
+		assert.NotContains(t, rr.Body.String(), "connection refused")
+	})
+}
+
+func TestHandler_Hotel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		lat, lng := 21.03, 105.85
+		mocks.hotels.EXPECT().GetHotelByID(uint(1)).Return(&models.Hotel{
+			ID:            1,
+			HotelName:     "Alpha",
+			HotelLocation: models.HotelLocation{City: "Hanoi", CountryCode: "VN", Latitude: &lat, Longitude: &lng},
+		}, nil)
+
+		// Act
+		rr := query(h, "secret", `{ hotel(id: "1") { name location { city countryCode address latitude longitude } } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Empty(t, result.Errors)
+		assert.JSONEq(t, `{"hotel": {"name": "Alpha", "location": {"city": "Hanoi", "countryCode": "VN", "address": null, "latitude": 21.03, "longitude": 105.85}}}`, string(result.Data))
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		mocks.hotels.EXPECT().GetHotelByID(uint(9)).Return(nil, &response.ErrorDetails{Code: http.StatusNotFound, Message: "Hotel not found"})
+
+		// Act
+		rr := query(h, "secret", `{ hotel(id: "9") { name } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Empty(t, result.Errors)
+		assert.JSONEq(t, `{"hotel": null}`, string(result.Data))
+	})
+
+	t.Run("invalid_id", func(t *testing.T) {
+		// Arrange
+		h, _ := newTestHandler(ctrl)
+
+		// Act
+		rr := query(h, "secret", `{ hotel(id: "abc") { name } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, "id must be a positive integer", result.Errors[0].Message)
+	})
+}
+
+func TestHandler_Reviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("batches_relations", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		minRating := 8.0
+		reviews := []*models.Review{
+			{ID: 1, HotelID: 1, ProviderID: 10, Rating: 9, Status: models.ReviewStatusPublished,
+				Response: &models.ReviewResponse{ID: 5, ResponderName: "Manager", Body: "Thanks", Source: models.ReviewResponseSourceAPI}},
+			{ID: 2, HotelID: 2, ProviderID: 10, Rating: 8, Status: models.ReviewStatusPublished},
+			{ID: 3, HotelID: 1, ProviderID: 10, Rating: 8.5, Status: models.ReviewStatusPublished},
+		}
+
+		mocks.reviews.EXPECT().GetReviewsList(&dto.ReviewQueryParams{
+			Limit:           20,
+			HotelID:         0,
+			MinRating:       &minRating,
+			ResponseOptions: dto.ResponseOptions{Include: dto.RelationResponse},
+		}).Return(reviews, 3, nil)
+		mocks.hotels.EXPECT().GetHotelsByIDs([]uint{1, 2}).Return([]*models.Hotel{{ID: 1, HotelName: "Alpha"}, {ID: 2, HotelName: "Beta"}}, nil)
+		mocks.providers.EXPECT().GetProvidersByIDs([]uint{10}).Return([]*models.Provider{{ID: 10, Name: "Agoda"}}, nil)
+
+		// Act
+		rr := query(h, "secret", `{ reviews(filter: {minRating: 8}) { edges { node {
+			id
+			hotel { name }
+			provider { name }
+			response { responderName body }
+		} } } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Empty(t, result.Errors)
+		assert.JSONEq(t, `{"reviews": {"edges": [
+			{"node": {"id": "1", "hotel": {"name": "Alpha"}, "provider": {"name": "Agoda"}, "response": {"responderName": "Manager", "body": "Thanks"}}},
+			{"node": {"id": "2", "hotel": {"name": "Beta"}, "provider": {"name": "Agoda"}, "response": null}},
+			{"node": {"id": "3", "hotel": {"name": "Alpha"}, "provider": {"name": "Agoda"}, "response": null}}
+		]}}`, string(result.Data))
+	})
+
+	t.Run("unpublished_requires_admin", func(t *testing.T) {
+		// Arrange
+		h, _ := newTestHandler(ctrl)
+
+		// Act
+		rr := query(h, "secret", `{ reviews(filter: {status: ["pending"]}) { totalCount } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, "Only published reviews are visible without the admin scope", result.Errors[0].Message)
+		assert.Equal(t, response.CodeForbidden, result.Errors[0].Extensions["code"])
+	})
+
+	t.Run("unpublished_as_admin", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		mocks.reviews.EXPECT().GetReviewsList(&dto.ReviewQueryParams{
+			Limit:           20,
+			Status:          "pending,flagged",
+			ResponseOptions: dto.ResponseOptions{Include: dto.RelationResponse},
+		}).Return([]*models.Review{}, 0, nil)
+
+		// Act
+		rr := query(h, "admin-secret", `{ reviews(filter: {status: ["pending", "flagged"]}) { totalCount } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Empty(t, result.Errors)
+		assert.JSONEq(t, `{"reviews": {"totalCount": 0}}`, string(result.Data))
+	})
+
+	t.Run("validation_error", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		mocks.reviews.EXPECT().GetReviewsList(gomock.Any()).Return(nil, 0, response.ValidationErrorDetails("sort", "sort must be one of review_date, rating, created_at, optionally prefixed with -", nil))
+
+		// Act
+		rr := query(h, "secret", `{ reviews(filter: {sort: "title"}) { totalCount } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, response.CodeValidationFailed, result.Errors[0].Extensions["code"])
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"field":   "sort",
+			"message": "sort must be one of review_date, rating, created_at, optionally prefixed with -",
+		}}, result.Errors[0].Extensions["errors"])
+	})
+}
+
+func TestHandler_Review(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name     string
+		apiKey   string
+		status   string
+		expected string
+	}{
+		{name: "published", apiKey: "secret", status: models.ReviewStatusPublished, expected: `{"review": {"id": "1", "status": "published"}}`},
+		{name: "pending_hidden_from_client", apiKey: "secret", status: models.ReviewStatusPending, expected: `{"review": null}`},
+		{name: "pending_visible_to_admin", apiKey: "admin-secret", status: models.ReviewStatusPending, expected: `{"review": {"id": "1", "status": "pending"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			h, mocks := newTestHandler(ctrl)
+			mocks.reviews.EXPECT().GetReviewByID(uint(1), dto.RelationResponse).Return(&models.Review{ID: 1, Status: tt.status}, nil)
+
+			// Act
+			rr := query(h, tt.apiKey, `{ review(id: "1") { id status } }`, nil)
+
+			// Assert
+			result := decodeResult(t, rr)
+			assert.Empty(t, result.Errors)
+			assert.JSONEq(t, tt.expected, string(result.Data))
+		})
+	}
+}
+
+func TestHandler_Provider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("hotels", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		mocks.providers.EXPECT().GetProviderByID(uint(10)).Return(&models.Provider{ID: 10, Name: "Agoda"}, nil)
+		mocks.providerHotels.EXPECT().GetProviderHotelsByProviderIDs([]uint{10}).Return([]*models.ProviderHotel{
+			{HotelID: 1, ProviderID: 10, ReviewCount: 42},
+			{HotelID: 2, ProviderID: 10, ReviewCount: 7},
+		}, nil)
+		mocks.hotels.EXPECT().GetHotelsByIDs([]uint{1, 2}).Return([]*models.Hotel{{ID: 1, HotelName: "Alpha"}}, nil)
+
+		// Act
+		rr := query(h, "secret", `{ provider(id: "10") { name hotels { reviewCount hotel { name } } } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Empty(t, result.Errors)
+		assert.JSONEq(t, `{"provider": {"name": "Agoda", "hotels": [
+			{"reviewCount": 42, "hotel": {"name": "Alpha"}},
+			{"reviewCount": 7, "hotel": null}
+		]}}`, string(result.Data))
+	})
+}
+
+func TestHandler_AuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		mocks.auditLogs.EXPECT().GetAuditLogsList(&dto.AuditLogsQueryParams{Limit: 10, FileName: "reviews.jl"}).Return([]*models.AuditLog{
+			{ID: 1, FileName: "reviews.jl", SuccessCount: 9, FailureCount: 1, TotalCount: 10, RuleHits: map[string]int{"profanity": 2, "links": 1}},
+		}, 1, nil)
+
+		// Act
+		rr := query(h, "admin-secret", `{ auditLogs(first: 10, filter: {fileName: "reviews.jl"}) {
+			totalCount
+			edges { node { fileName successCount failureCount ruleHits { rule count } } }
+		} }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Empty(t, result.Errors)
+		assert.JSONEq(t, `{"auditLogs": {"totalCount": 1, "edges": [{"node": {
+			"fileName": "reviews.jl", "successCount": 9, "failureCount": 1,
+			"ruleHits": [{"rule": "links", "count": 1}, {"rule": "profanity", "count": 2}]
+		}}]}}`, string(result.Data))
+	})
+
+	t.Run("requires_admin", func(t *testing.T) {
+		// Arrange
+		h, _ := newTestHandler(ctrl)
+
+		// Act
+		rr := query(h, "secret", `{ auditLogs { totalCount } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, response.CodeForbidden, result.Errors[0].Extensions["code"])
+		assert.JSONEq(t, `null`, string(result.Data))
+	})
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get_request", func(t *testing.T) {
+		// Arrange
+		h, mocks := newTestHandler(ctrl)
+		mocks.providers.EXPECT().GetProviderByID(uint(10)).Return(&models.Provider{ID: 10, Name: "Agoda"}, nil)
+
+		params := url.Values{}
+		params.Set("query", `query ($id: ID!) { provider(id: $id) { name } }`)
+		params.Set("variables", `{"id": "10"}`)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/graphql?"+params.Encode(), nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rr := httptest.NewRecorder()
+
+		// Act
+		middleware.Trace(middleware.Auth(h)).ServeHTTP(rr, req)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.Empty(t, result.Errors)
+		assert.JSONEq(t, `{"provider": {"name": "Agoda"}}`, string(result.Data))
+	})
+
+	t.Run("missing_query", func(t *testing.T) {
+		// Arrange
+		h, _ := newTestHandler(ctrl)
+
+		// Act
+		rr := query(h, "secret", "", nil)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, response.ProblemContentType, rr.Header().Get("Content-Type"))
+		var problem response.Problem
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+		assert.Equal(t, response.CodeValidationFailed, problem.Code)
+	})
+
+	t.Run("malformed_body", func(t *testing.T) {
+		// Arrange
+		h, _ := newTestHandler(ctrl)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewBufferString("{"))
+		req.Header.Set("Authorization", "Bearer secret")
+		rr := httptest.NewRecorder()
+
+		// Act
+		middleware.Trace(middleware.Auth(h)).ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, response.ProblemContentType, rr.Header().Get("Content-Type"))
+	})
+
+	t.Run("requires_api_key", func(t *testing.T) {
+		// Arrange
+		h, _ := newTestHandler(ctrl)
+
+		// Act
+		rr := query(h, "wrong", `{ hotels { totalCount } }`, nil)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("depth_limited", func(t *testing.T) {
+		// Arrange
+		h, _ := newTestHandler(ctrl)
+
+		// Act
+		rr := query(h, "secret", `{ hotel(id: "1") { providers { hotel { providers { hotel { providers { hotel { providers { hotel { providers { hotel { name } } } } } } } } } } }`, nil)
+
+		// Assert
+		result := decodeResult(t, rr)
+		assert.NotEmpty(t, result.Errors)
+		assert.Empty(t, result.Data)
+	})
+}
@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// loader batches and caches the lookups of one relation during a request.
+// Keys are primed before they are loaded: a resolver primes the keys of every
+// entity listed alongside its own, so the first load fetches them all at once
+// and the others are answered from the cache, whichever resolver runs first.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	primed  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:  fetch,
+		primed: make(map[K]bool),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+// prime queues keys for the next fetch. Keys that are already queued or
+// fetched are skipped.
+func (l *loader[K, V]) prime(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if !l.primed[key] {
+			l.primed[key] = true
+			l.pending = append(l.pending, key)
+		}
+	}
+}
+
+// load returns the value of key, fetching it along with every queued key
+// unless it was fetched before. Keys the fetch does not return load as the
+// zero value.
+func (l *loader[K, V]) load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.primed[key] {
+		l.primed[key] = true
+		l.pending = append(l.pending, key)
+	}
+	if _, fetched := l.values[key]; !fetched && l.errs[key] == nil {
+		keys := l.pending
+		l.pending = nil
+		values, err := l.fetch(ctx, keys)
+		for _, k := range keys {
+			if err != nil {
+				l.errs[k] = err
+				continue
+			}
+			l.values[k] = values[k]
+		}
+	}
+	return l.values[key], l.errs[key]
+}
@@ -0,0 +1,82 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader(t *testing.T) {
+	t.Run("fetches_primed_keys_together", func(t *testing.T) {
+		// Arrange
+		var calls [][]int
+		l := newLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+			calls = append(calls, keys)
+			return map[int]string{1: "one", 2: "two"}, nil
+		})
+		l.prime(1, 2, 3, 2)
+
+		// Act
+		var wg sync.WaitGroup
+		values := make([]string, 3)
+		for i := range values {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				values[i], _ = l.load(context.Background(), i+1)
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		assert.Equal(t, [][]int{{1, 2, 3}}, calls)
+		assert.Equal(t, []string{"one", "two", ""}, values)
+	})
+
+	t.Run("caches_errors", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		l := newLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+			calls++
+			return nil, errors.New("boom")
+		})
+
+		// Act
+		_, err1 := l.load(context.Background(), 1)
+		_, err2 := l.load(context.Background(), 1)
+
+		// Assert
+		assert.EqualError(t, err1, "boom")
+		assert.EqualError(t, err2, "boom")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name        string
+		cursor      string
+		expected    int
+		expectedErr bool
+	}{
+		{name: "round_trip", cursor: encodeCursor(41), expected: 41},
+		{name: "not_base64", cursor: "!!", expectedErr: true},
+		{name: "wrong_prefix", cursor: "aWQ6MQ==", expectedErr: true},
+		{name: "negative", cursor: "b2Zmc2V0Oi0x", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := decodeCursor(tt.cursor)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, offset)
+		})
+	}
+}
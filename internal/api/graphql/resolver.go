@@ -0,0 +1,366 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// Services are the services the resolvers read through.
+type Services struct {
+	Hotels         service.HotelService
+	Providers      service.ProviderService
+	ProviderHotels service.ProviderHotelService
+	Reviews        service.ReviewService
+	AuditLogs      service.AuditLogService
+}
+
+// queryResolver resolves the fields of the Query type.
+type queryResolver struct {
+	services Services
+	logger   *logger.Logger
+}
+
+type hotelFilter struct {
+	Name           *string
+	ProviderID     *graphqlgo.ID
+	City           *string
+	Country        *string
+	Near           *string
+	RadiusKm       *float64
+	IncludeDeleted *bool
+}
+
+type providerFilter struct {
+	Name           *string
+	HotelID        *graphqlgo.ID
+	IncludeDeleted *bool
+}
+
+type providerHotelFilter struct {
+	HotelID        *graphqlgo.ID
+	ProviderID     *graphqlgo.ID
+	IncludeDeleted *bool
+}
+
+type reviewFilter struct {
+	HotelID        *graphqlgo.ID
+	ProviderID     *graphqlgo.ID
+	MinRating      *float64
+	MaxRating      *float64
+	ReviewDateFrom *graphqlgo.Time
+	ReviewDateTo   *graphqlgo.Time
+	Lang           *string
+	TravelerType   *string
+	Country        *string
+	HasComment     *bool
+	Q              *string
+	Status         *[]string
+	Sort           *string
+	IncludeDeleted *bool
+}
+
+type auditLogFilter struct {
+	FileName    *string
+	CreatedFrom *graphqlgo.Time
+	CreatedTo   *graphqlgo.Time
+}
+
+type idArgs struct {
+	ID graphqlgo.ID
+}
+
+type hotelsArgs struct {
+	First  int32
+	After  *string
+	Filter *hotelFilter
+}
+
+type providersArgs struct {
+	First  int32
+	After  *string
+	Filter *providerFilter
+}
+
+type providerHotelsArgs struct {
+	First  int32
+	After  *string
+	Filter *providerHotelFilter
+}
+
+type reviewsArgs struct {
+	First  int32
+	After  *string
+	Filter *reviewFilter
+}
+
+type auditLogsArgs struct {
+	First  int32
+	After  *string
+	Filter *auditLogFilter
+}
+
+func (r *queryResolver) Hotel(ctx context.Context, args idArgs) (*hotelResolver, error) {
+	id, err := parseID(ctx, "id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	hotel, errorDetails := r.services.Hotels.GetHotelByID(id)
+	if errorDetails != nil {
+		return nil, r.lookupError(ctx, errorDetails)
+	}
+	return newHotelResolvers([]*models.Hotel{hotel})[0], nil
+}
+
+func (r *queryResolver) Hotels(ctx context.Context, args hotelsArgs) (*connection[*hotelResolver], error) {
+	limit, offset, err := pageBounds(ctx, args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := &dto.HotelsQueryParams{Limit: limit, Offset: offset}
+	if filter := args.Filter; filter != nil {
+		queryParams.Name = value(filter.Name)
+		queryParams.City = value(filter.City)
+		queryParams.Country = value(filter.Country)
+		queryParams.RadiusKm = value(filter.RadiusKm)
+		queryParams.IncludeDeleted = value(filter.IncludeDeleted)
+		if queryParams.ProviderID, err = parseOptionalID(ctx, "filter.providerId", filter.ProviderID); err != nil {
+			return nil, err
+		}
+		if filter.Near != nil {
+			near, err := dto.ParseGeoPoint(*filter.Near)
+			if err != nil {
+				return nil, validationError(ctx, "filter.near", "near must be a lat,lng pair: "+err.Error())
+			}
+			queryParams.Near = &near
+		}
+	}
+	if err := checkIncludeDeleted(ctx, queryParams.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
+	hotels, total, errorDetails := r.services.Hotels.GetHotelsList(queryParams)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, r.logger, errorDetails)
+	}
+	return newConnection(newHotelResolvers(hotels), offset, total), nil
+}
+
+func (r *queryResolver) Provider(ctx context.Context, args idArgs) (*providerResolver, error) {
+	id, err := parseID(ctx, "id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, errorDetails := r.services.Providers.GetProviderByID(id)
+	if errorDetails != nil {
+		return nil, r.lookupError(ctx, errorDetails)
+	}
+	return newProviderResolvers([]*models.Provider{provider})[0], nil
+}
+
+func (r *queryResolver) Providers(ctx context.Context, args providersArgs) (*connection[*providerResolver], error) {
+	limit, offset, err := pageBounds(ctx, args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := &dto.ProvidersQueryParams{Limit: limit, Offset: offset}
+	if filter := args.Filter; filter != nil {
+		queryParams.Name = value(filter.Name)
+		queryParams.IncludeDeleted = value(filter.IncludeDeleted)
+		if queryParams.HotelID, err = parseOptionalID(ctx, "filter.hotelId", filter.HotelID); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkIncludeDeleted(ctx, queryParams.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
+	providers, total, errorDetails := r.services.Providers.GetProvidersList(queryParams)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, r.logger, errorDetails)
+	}
+	return newConnection(newProviderResolvers(providers), offset, total), nil
+}
+
+func (r *queryResolver) ProviderHotels(ctx context.Context, args providerHotelsArgs) (*connection[*providerHotelResolver], error) {
+	limit, offset, err := pageBounds(ctx, args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := &dto.ProviderHotelsQueryParams{Limit: limit, Offset: offset}
+	if filter := args.Filter; filter != nil {
+		queryParams.IncludeDeleted = value(filter.IncludeDeleted)
+		if queryParams.HotelID, err = parseOptionalID(ctx, "filter.hotelId", filter.HotelID); err != nil {
+			return nil, err
+		}
+		if queryParams.ProviderID, err = parseOptionalID(ctx, "filter.providerId", filter.ProviderID); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkIncludeDeleted(ctx, queryParams.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
+	providerHotels, total, errorDetails := r.services.ProviderHotels.GetProviderHotelsList(queryParams)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, r.logger, errorDetails)
+	}
+	return newConnection(newProviderHotelResolvers(providerHotels), offset, total), nil
+}
+
+func (r *queryResolver) Review(ctx context.Context, args idArgs) (*reviewResolver, error) {
+	id, err := parseID(ctx, "id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	review, errorDetails := r.services.Reviews.GetReviewByID(id, dto.RelationResponse)
+	if errorDetails != nil {
+		return nil, r.lookupError(ctx, errorDetails)
+	}
+
+	// Unpublished reviews do not exist as far as the public is concerned
+	if review.Status != models.ReviewStatusPublished && !middleware.IsAdmin(ctx) {
+		return nil, nil
+	}
+	return newReviewResolvers([]*models.Review{review})[0], nil
+}
+
+func (r *queryResolver) Reviews(ctx context.Context, args reviewsArgs) (*connection[*reviewResolver], error) {
+	limit, offset, err := pageBounds(ctx, args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	// Responses are preloaded, as a review has at most one
+	queryParams := &dto.ReviewQueryParams{
+		Limit:           limit,
+		Offset:          offset,
+		ResponseOptions: dto.ResponseOptions{Include: dto.RelationResponse},
+	}
+	if filter := args.Filter; filter != nil {
+		queryParams.MinRating = filter.MinRating
+		queryParams.MaxRating = filter.MaxRating
+		queryParams.Lang = value(filter.Lang)
+		queryParams.TravelerType = value(filter.TravelerType)
+		queryParams.Country = value(filter.Country)
+		queryParams.HasComment = filter.HasComment
+		queryParams.Q = value(filter.Q)
+		queryParams.Sort = value(filter.Sort)
+		queryParams.IncludeDeleted = value(filter.IncludeDeleted)
+		if filter.Status != nil {
+			queryParams.Status = strings.Join(*filter.Status, ",")
+		}
+		if filter.ReviewDateFrom != nil {
+			queryParams.ReviewDateFrom = filter.ReviewDateFrom.Time
+		}
+		if filter.ReviewDateTo != nil {
+			queryParams.ReviewDateTo = dto.RangeEnd{Time: filter.ReviewDateTo.Time}
+		}
+		if queryParams.HotelID, err = parseOptionalID(ctx, "filter.hotelId", filter.HotelID); err != nil {
+			return nil, err
+		}
+		if queryParams.ProviderID, err = parseOptionalID(ctx, "filter.providerId", filter.ProviderID); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkReviewStatuses(ctx, queryParams.StatusList()); err != nil {
+		return nil, err
+	}
+	if err := checkIncludeDeleted(ctx, queryParams.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
+	reviews, total, errorDetails := r.services.Reviews.GetReviewsList(queryParams)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, r.logger, errorDetails)
+	}
+	return newConnection(newReviewResolvers(reviews), offset, total), nil
+}
+
+func (r *queryResolver) AuditLogs(ctx context.Context, args auditLogsArgs) (*connection[*auditLogResolver], error) {
+	if !middleware.IsAdmin(ctx) {
+		return nil, newQueryError(ctx, http.StatusForbidden, "Audit logs require the admin scope")
+	}
+
+	limit, offset, err := pageBounds(ctx, args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := &dto.AuditLogsQueryParams{Limit: limit, Offset: offset}
+	if filter := args.Filter; filter != nil {
+		queryParams.FileName = value(filter.FileName)
+		if filter.CreatedFrom != nil {
+			queryParams.CreatedFrom = filter.CreatedFrom.Time
+		}
+		if filter.CreatedTo != nil {
+			queryParams.CreatedTo = filter.CreatedTo.Time
+		}
+	}
+
+	auditLogs, total, errorDetails := r.services.AuditLogs.GetAuditLogsList(queryParams)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, r.logger, errorDetails)
+	}
+
+	nodes := make([]*auditLogResolver, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		nodes[i] = &auditLogResolver{auditLog: auditLog}
+	}
+	return newConnection(nodes, offset, total), nil
+}
+
+// lookupError returns the error of looking an entity up by ID. An entity that
+// does not exist is null rather than an error.
+func (r *queryResolver) lookupError(ctx context.Context, errorDetails *response.ErrorDetails) error {
+	if errorDetails.Code == http.StatusNotFound {
+		return nil
+	}
+	return serviceError(ctx, r.logger, errorDetails)
+}
+
+// checkReviewStatuses refuses to list unpublished reviews to callers without
+// the admin scope, as the REST API does.
+func checkReviewStatuses(ctx context.Context, statuses []string) error {
+	if middleware.IsAdmin(ctx) {
+		return nil
+	}
+	for _, status := range statuses {
+		if status != models.ReviewStatusPublished {
+			return newQueryError(ctx, http.StatusForbidden, "Only published reviews are visible without the admin scope")
+		}
+	}
+	return nil
+}
+
+// checkIncludeDeleted refuses to list soft-deleted entities to callers without
+// the admin scope, as the REST API does.
+func checkIncludeDeleted(ctx context.Context, includeDeleted bool) error {
+	if !includeDeleted || middleware.IsAdmin(ctx) {
+		return nil
+	}
+	return newQueryError(ctx, http.StatusForbidden, "Deleted entities are only visible with the admin scope")
+}
+
+// value returns what an optional argument points at, or its zero value.
+func value[T any](arg *T) T {
+	var zero T
+	if arg == nil {
+		return zero
+	}
+	return *arg
+}
@@ -0,0 +1,418 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm"
+)
+
+// MaxLatestReviews is the most reviews Hotel.latestReviews returns.
+const MaxLatestReviews = 20
+
+// Every resolver keeps the entities fetched along with its own, its siblings,
+// and primes their keys before loading a relation. A list of hotels thus
+// loads the providers of all of them with one call, whichever hotel's
+// providers are resolved first.
+
+type hotelResolver struct {
+	hotel    *models.Hotel
+	siblings []*models.Hotel
+}
+
+func newHotelResolvers(hotels []*models.Hotel) []*hotelResolver {
+	resolvers := make([]*hotelResolver, len(hotels))
+	for i, hotel := range hotels {
+		resolvers[i] = &hotelResolver{hotel: hotel, siblings: hotels}
+	}
+	return resolvers
+}
+
+func (r *hotelResolver) ID() graphqlgo.ID {
+	return formatID(r.hotel.ID)
+}
+
+func (r *hotelResolver) Name() string {
+	return r.hotel.HotelName
+}
+
+func (r *hotelResolver) Location() *hotelLocationResolver {
+	return &hotelLocationResolver{location: &r.hotel.HotelLocation}
+}
+
+func (r *hotelResolver) Providers(ctx context.Context) ([]*providerHotelResolver, error) {
+	loaders := loadersFromContext(ctx)
+	for _, sibling := range r.siblings {
+		loaders.hotelProviders.prime(sibling.ID)
+	}
+	return loaders.hotelProviders.load(ctx, r.hotel.ID)
+}
+
+func (r *hotelResolver) LatestReviews(ctx context.Context, args struct{ First int32 }) ([]*reviewResolver, error) {
+	limit := int(args.First)
+	if limit < 1 || limit > MaxLatestReviews {
+		return nil, validationError(ctx, "first", fmt.Sprintf("first must be between 1 and %d", MaxLatestReviews))
+	}
+
+	loaders := loadersFromContext(ctx)
+	for _, sibling := range r.siblings {
+		loaders.latestReviews.prime(latestReviewsKey{hotelID: sibling.ID, limit: limit})
+	}
+	return loaders.latestReviews.load(ctx, latestReviewsKey{hotelID: r.hotel.ID, limit: limit})
+}
+
+func (r *hotelResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.hotel.CreatedAt}
+}
+
+func (r *hotelResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.hotel.UpdatedAt}
+}
+
+func (r *hotelResolver) DeletedAt() *graphqlgo.Time {
+	return deletedAt(r.hotel.DeletedAt)
+}
+
+type hotelLocationResolver struct {
+	location *models.HotelLocation
+}
+
+func (r *hotelLocationResolver) Address() *string {
+	return optionalString(r.location.Address)
+}
+
+func (r *hotelLocationResolver) City() *string {
+	return optionalString(r.location.City)
+}
+
+func (r *hotelLocationResolver) CountryCode() *string {
+	return optionalString(r.location.CountryCode)
+}
+
+func (r *hotelLocationResolver) Latitude() *float64 {
+	return r.location.Latitude
+}
+
+func (r *hotelLocationResolver) Longitude() *float64 {
+	return r.location.Longitude
+}
+
+func (r *hotelLocationResolver) Timezone() *string {
+	return optionalString(r.location.Timezone)
+}
+
+type providerResolver struct {
+	provider *models.Provider
+	siblings []*models.Provider
+}
+
+func newProviderResolvers(providers []*models.Provider) []*providerResolver {
+	resolvers := make([]*providerResolver, len(providers))
+	for i, provider := range providers {
+		resolvers[i] = &providerResolver{provider: provider, siblings: providers}
+	}
+	return resolvers
+}
+
+func (r *providerResolver) ID() graphqlgo.ID {
+	return formatID(r.provider.ID)
+}
+
+func (r *providerResolver) Name() string {
+	return r.provider.Name
+}
+
+func (r *providerResolver) Hotels(ctx context.Context) ([]*providerHotelResolver, error) {
+	loaders := loadersFromContext(ctx)
+	for _, sibling := range r.siblings {
+		loaders.providerHotels.prime(sibling.ID)
+	}
+	return loaders.providerHotels.load(ctx, r.provider.ID)
+}
+
+func (r *providerResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.provider.CreatedAt}
+}
+
+func (r *providerResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.provider.UpdatedAt}
+}
+
+func (r *providerResolver) DeletedAt() *graphqlgo.Time {
+	return deletedAt(r.provider.DeletedAt)
+}
+
+type providerHotelResolver struct {
+	providerHotel *models.ProviderHotel
+	siblings      []*models.ProviderHotel
+}
+
+func newProviderHotelResolvers(providerHotels []*models.ProviderHotel) []*providerHotelResolver {
+	resolvers := make([]*providerHotelResolver, len(providerHotels))
+	for i, providerHotel := range providerHotels {
+		resolvers[i] = &providerHotelResolver{providerHotel: providerHotel, siblings: providerHotels}
+	}
+	return resolvers
+}
+
+func (r *providerHotelResolver) Provider(ctx context.Context) (*providerResolver, error) {
+	loaders := loadersFromContext(ctx)
+	for _, sibling := range r.siblings {
+		loaders.providers.prime(sibling.ProviderID)
+	}
+	return loaders.providers.load(ctx, r.providerHotel.ProviderID)
+}
+
+func (r *providerHotelResolver) Hotel(ctx context.Context) (*hotelResolver, error) {
+	loaders := loadersFromContext(ctx)
+	for _, sibling := range r.siblings {
+		loaders.hotels.prime(sibling.HotelID)
+	}
+	return loaders.hotels.load(ctx, r.providerHotel.HotelID)
+}
+
+func (r *providerHotelResolver) OverallScore() float64 {
+	return r.providerHotel.OverallScore
+}
+
+func (r *providerHotelResolver) ReviewCount() int32 {
+	return int32(r.providerHotel.ReviewCount)
+}
+
+func (r *providerHotelResolver) Grades() *string {
+	return rawJSON(r.providerHotel.Grades)
+}
+
+func (r *providerHotelResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.providerHotel.CreatedAt}
+}
+
+func (r *providerHotelResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.providerHotel.UpdatedAt}
+}
+
+func (r *providerHotelResolver) DeletedAt() *graphqlgo.Time {
+	return deletedAt(r.providerHotel.DeletedAt)
+}
+
+type reviewResolver struct {
+	review   *models.Review
+	siblings []*models.Review
+}
+
+func newReviewResolvers(reviews []*models.Review) []*reviewResolver {
+	resolvers := make([]*reviewResolver, len(reviews))
+	for i, review := range reviews {
+		resolvers[i] = &reviewResolver{review: review, siblings: reviews}
+	}
+	return resolvers
+}
+
+func (r *reviewResolver) ID() graphqlgo.ID {
+	return formatID(r.review.ID)
+}
+
+func (r *reviewResolver) Provider(ctx context.Context) (*providerResolver, error) {
+	loaders := loadersFromContext(ctx)
+	for _, sibling := range r.siblings {
+		loaders.providers.prime(sibling.ProviderID)
+	}
+	return loaders.providers.load(ctx, r.review.ProviderID)
+}
+
+func (r *reviewResolver) Hotel(ctx context.Context) (*hotelResolver, error) {
+	loaders := loadersFromContext(ctx)
+	for _, sibling := range r.siblings {
+		loaders.hotels.prime(sibling.HotelID)
+	}
+	return loaders.hotels.load(ctx, r.review.HotelID)
+}
+
+func (r *reviewResolver) Rating() float64 {
+	return r.review.Rating
+}
+
+func (r *reviewResolver) Title() string {
+	return r.review.Title
+}
+
+func (r *reviewResolver) Comment() string {
+	return r.review.Comment
+}
+
+func (r *reviewResolver) Lang() string {
+	return r.review.Lang
+}
+
+func (r *reviewResolver) ReviewDate() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.review.ReviewDate}
+}
+
+func (r *reviewResolver) ReviewerInfo() *string {
+	return rawJSON(r.review.ReviewerInfo)
+}
+
+func (r *reviewResolver) Status() string {
+	return r.review.Status
+}
+
+func (r *reviewResolver) ModerationReason() *string {
+	return optionalString(r.review.ModerationReason)
+}
+
+func (r *reviewResolver) ModeratedAt() *graphqlgo.Time {
+	return optionalTime(r.review.ModeratedAt)
+}
+
+// Response is preloaded with the review, as a review has at most one.
+func (r *reviewResolver) Response() *reviewResponseResolver {
+	if r.review.Response == nil {
+		return nil
+	}
+	return &reviewResponseResolver{response: r.review.Response}
+}
+
+func (r *reviewResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.review.CreatedAt}
+}
+
+func (r *reviewResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.review.UpdatedAt}
+}
+
+func (r *reviewResolver) DeletedAt() *graphqlgo.Time {
+	return deletedAt(r.review.DeletedAt)
+}
+
+type reviewResponseResolver struct {
+	response *models.ReviewResponse
+}
+
+func (r *reviewResponseResolver) ID() graphqlgo.ID {
+	return formatID(r.response.ID)
+}
+
+func (r *reviewResponseResolver) ResponderName() string {
+	return r.response.ResponderName
+}
+
+func (r *reviewResponseResolver) Body() string {
+	return r.response.Body
+}
+
+func (r *reviewResponseResolver) ResponseDate() *graphqlgo.Time {
+	return optionalTime(r.response.ResponseDate)
+}
+
+func (r *reviewResponseResolver) ResponseDateText() *string {
+	return optionalString(r.response.ResponseDateText)
+}
+
+func (r *reviewResponseResolver) Source() string {
+	return r.response.Source
+}
+
+func (r *reviewResponseResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.response.CreatedAt}
+}
+
+func (r *reviewResponseResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.response.UpdatedAt}
+}
+
+type auditLogResolver struct {
+	auditLog *models.AuditLog
+}
+
+func (r *auditLogResolver) ID() graphqlgo.ID {
+	return formatID(r.auditLog.ID)
+}
+
+func (r *auditLogResolver) FileName() string {
+	return r.auditLog.FileName
+}
+
+func (r *auditLogResolver) SuccessCount() int32 {
+	return int32(r.auditLog.SuccessCount)
+}
+
+func (r *auditLogResolver) FailureCount() int32 {
+	return int32(r.auditLog.FailureCount)
+}
+
+func (r *auditLogResolver) TotalCount() int32 {
+	return int32(r.auditLog.TotalCount)
+}
+
+// RuleHits lists the rules by name, so the order is stable.
+func (r *auditLogResolver) RuleHits() []*ruleHitResolver {
+	rules := make([]string, 0, len(r.auditLog.RuleHits))
+	for rule := range r.auditLog.RuleHits {
+		rules = append(rules, rule)
+	}
+	slices.Sort(rules)
+
+	hits := make([]*ruleHitResolver, len(rules))
+	for i, rule := range rules {
+		hits[i] = &ruleHitResolver{rule: rule, count: r.auditLog.RuleHits[rule]}
+	}
+	return hits
+}
+
+func (r *auditLogResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.auditLog.CreatedAt}
+}
+
+type ruleHitResolver struct {
+	rule  string
+	count int
+}
+
+func (r *ruleHitResolver) Rule() string {
+	return r.rule
+}
+
+func (r *ruleHitResolver) Count() int32 {
+	return int32(r.count)
+}
+
+func formatID(id uint) graphqlgo.ID {
+	return graphqlgo.ID(strconv.FormatUint(uint64(id), 10))
+}
+
+// optionalString returns nil for an empty string, which is null in the schema.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func optionalTime(value *time.Time) *graphqlgo.Time {
+	if value == nil {
+		return nil
+	}
+	return &graphqlgo.Time{Time: *value}
+}
+
+func deletedAt(value gorm.DeletedAt) *graphqlgo.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &graphqlgo.Time{Time: value.Time}
+}
+
+// rawJSON returns a JSON column as a string, or nil when it is empty.
+func rawJSON(value json.RawMessage) *string {
+	if len(value) == 0 || string(value) == "null" {
+		return nil
+	}
+	text := string(value)
+	return &text
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/logger"
+)
+
+// writeServiceError writes the error a service returned as a problem. The
+// cause of a server error is kept out of the response, so it is logged with
+// the request's trace ID instead.
+func writeServiceError(w http.ResponseWriter, r *http.Request, log *logger.Logger, details *response.ErrorDetails) {
+	if details.Code >= http.StatusInternalServerError {
+		log.Error(details.Error, fmt.Sprintf("%s %s failed with %d (trace ID %s)", r.Method, r.URL.Path, details.Code, response.TraceID(r.Context())))
+	}
+	response.WriteErrorDetails(w, r, details)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/kirananto/review-system/internal/api/service"
 	"github.com/kirananto/review-system/internal/api/utils"
 	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
 )
 
 type HotelHandler struct {
@@ -24,7 +25,7 @@ func NewHotelHandler(service service.HotelService, logger *logger.Logger) *Hotel
 	return &HotelHandler{
 		service: service,
 		logger:  logger,
-		decoder: schema.NewDecoder(),
+		decoder: utils.NewQueryDecoder(),
 	}
 }
 
@@ -33,11 +34,26 @@ func NewHotelHandler(service service.HotelService, logger *logger.Logger) *Hotel
 // @Description Get a list of hotels with optional filters
 // @Produce json
 // @Param name query string false "Hotel name"
+// @Param provider_id query int false "Only hotels mapped to this provider"
+// @Param city query string false "City, case-insensitive"
+// @Param country query string false "ISO 3166-1 alpha-2 country code"
+// @Param near query string false "Only hotels within radius_km of this point, as lat,lng; sorted by distance"
+// @Param radius_km query number false "Search radius around near in km" default(10)
 // @Param limit query int false "Limit"
 // @Param offset query int false "Offset"
+// @Param fields query string false "Comma-separated hotel fields to return"
+// @Param include_deleted query bool false "Also return soft-deleted hotels; admin scope only"
 // @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.Hotel}}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
 // @Router /hotels [get]
 func (h *HotelHandler) GetHotelsList(w http.ResponseWriter, r *http.Request) {
+	h.listHotels(w, r, nil)
+}
+
+// listHotels writes a page of hotels. scope, when set, restricts the query
+// parameters after they are decoded, as the nested routes do.
+func (h *HotelHandler) listHotels(w http.ResponseWriter, r *http.Request, scope func(*dto.HotelsQueryParams)) {
 	// Initialize with default values
 	queryParams := &dto.HotelsQueryParams{
 		Limit:  20,
@@ -46,15 +62,22 @@ func (h *HotelHandler) GetHotelsList(w http.ResponseWriter, r *http.Request) {
 
 	// Parse query parameters automatically
 	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid query parameters")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+	if scope != nil {
+		scope(queryParams)
+	}
+	if !checkResponseOptions(w, r, queryParams.ResponseOptions, models.Hotel{}, nil) {
+		return
+	}
+	if !checkIncludeDeleted(w, r, queryParams.IncludeDeleted) {
 		return
 	}
 
 	hotels, total, errorDetails := h.service.GetHotelsList(queryParams)
 	if errorDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusInternalServerError, errorDetails.Error.Error())
-		response.WriteHTTPResponse(w, http.StatusInternalServerError, errResp)
+		writeServiceError(w, r, h.logger, errorDetails)
 		return
 	}
 
@@ -68,11 +91,8 @@ func (h *HotelHandler) GetHotelsList(w http.ResponseWriter, r *http.Request) {
 		Next:     nextURL,
 		Results:  hotels,
 	}
-	resp := &response.HTTPResponse{
-		Content: content,
-	}
 
-	response.WriteHTTPResponse(w, http.StatusOK, resp)
+	writeSelectedList(w, r, queryParams.ResponseOptions, content)
 }
 
 // GetHotel godoc
@@ -80,27 +100,142 @@ func (h *HotelHandler) GetHotelsList(w http.ResponseWriter, r *http.Request) {
 // @Description Get a hotel by ID
 // @Produce json
 // @Param id path int true "Hotel ID"
+// @Param fields query string false "Comma-separated hotel fields to return"
 // @Success 200 {object} response.HTTPResponse{content=models.Hotel}
+// @Failure 400 {object} response.Problem
 // @Router /hotels/{id} [get]
 func (h *HotelHandler) GetHotel(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid hotel ID")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid hotel ID")
+		return
+	}
+
+	opts := dto.NewResponseOptions(r.URL.Query())
+	if !checkResponseOptions(w, r, opts, models.Hotel{}, nil) {
 		return
 	}
 
 	hotel, errorDetails := h.service.GetHotelByID(uint(id))
 	if errorDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(errorDetails.Code, errorDetails.Message)
-		response.WriteHTTPResponse(w, errorDetails.Code, errResp)
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	writeSelectedFields(w, r, http.StatusOK, opts, hotel, hotel.UpdatedAt)
+}
+
+// GetHotelSummary godoc
+// @Summary Get a hotel's rating summary
+// @Description Get our average rating and rating histogram from stored reviews, the review counts per provider, each provider's overall score and grades, and the date of the most recent review
+// @ID get-hotel-summary
+// @Produce json
+// @Param id path int true "Hotel ID"
+// @Success 200 {object} response.HTTPResponse{content=dto.HotelSummary}
+// @Failure 404 {object} response.Problem
+// @Router /hotels/{id}/summary [get]
+func (h *HotelHandler) GetHotelSummary(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid hotel ID")
+		return
+	}
+
+	summary, errorDetails := h.service.GetHotelSummary(uint(id))
+	if errorDetails != nil {
+		writeServiceError(w, r, h.logger, errorDetails)
 		return
 	}
 
-	// Create success response
 	resp := &response.HTTPResponse{
-		Content: hotel,
+		Content: summary,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// GetHotelRatingTimeseries godoc
+// @Summary Get a hotel's rating trend
+// @Description Get the review count, average rating and percentiles per week or month of review date, from stored reviews. Weeks start on Monday and buckets are in UTC. Dates are RFC 3339 timestamps or YYYY-MM-DD dates.
+// @ID get-hotel-rating-timeseries
+// @Produce json
+// @Param id path int true "Hotel ID"
+// @Param interval query string false "Bucket size, defaults to month" Enums(week, month)
+// @Param provider_id query int false "Only reviews from this provider"
+// @Param from query string false "Earliest review date"
+// @Param to query string false "Latest review date"
+// @Success 200 {object} response.HTTPResponse{content=dto.RatingTimeseries}
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /hotels/{id}/ratings/timeseries [get]
+func (h *HotelHandler) GetHotelRatingTimeseries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid hotel ID")
+		return
+	}
+
+	// Initialize with default values
+	queryParams := &dto.RatingTimeseriesQueryParams{
+		Interval: dto.IntervalMonth,
+	}
+
+	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+
+	series, errorDetails := h.service.GetHotelRatingTimeseries(uint(id), queryParams)
+	if errorDetails != nil {
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: series,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// GetHotelResponseMetrics godoc
+// @Summary Get a hotel's response metrics
+// @Description Get the share of a hotel's stored reviews that have a response from the hotel, and the average and median hours from review to response. Provider feeds date responses by the day. Dates are RFC 3339 timestamps or YYYY-MM-DD dates.
+// @ID get-hotel-response-metrics
+// @Produce json
+// @Param id path int true "Hotel ID"
+// @Param provider_id query int false "Only reviews from this provider"
+// @Param from query string false "Earliest review date"
+// @Param to query string false "Latest review date"
+// @Success 200 {object} response.HTTPResponse{content=dto.HotelResponseMetrics}
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /hotels/{id}/responses/metrics [get]
+func (h *HotelHandler) GetHotelResponseMetrics(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid hotel ID")
+		return
+	}
+
+	queryParams := &dto.ResponseMetricsQueryParams{}
+	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+
+	metrics, errorDetails := h.service.GetHotelResponseMetrics(uint(id), queryParams)
+	if errorDetails != nil {
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: metrics,
 	}
 
 	response.WriteHTTPResponse(w, http.StatusOK, resp)
@@ -108,24 +243,23 @@ func (h *HotelHandler) GetHotel(w http.ResponseWriter, r *http.Request) {
 
 // CreateHotel godoc
 // @Summary Create a new hotel
-// @Description Create a new hotel
+// @Description Create a new hotel. Requires the admin scope.
 // @Accept json
 // @Produce json
 // @Param hotel body dto.HotelRequestBody true "Hotel object"
 // @Success 201 {object} response.HTTPResponse{content=models.Hotel}
+// @Failure 403 {object} response.Problem
 // @Router /hotels [post]
 func (h *HotelHandler) CreateHotel(w http.ResponseWriter, r *http.Request) {
 	var hotelDto dto.HotelRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&hotelDto); err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid request body")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
 		return
 	}
 
 	hotel, errDetails := h.service.CreateHotel(&hotelDto)
 	if errDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(errDetails.Code, errDetails.Message)
-		response.WriteHTTPResponse(w, errDetails.Code, errResp)
+		writeServiceError(w, r, h.logger, errDetails)
 		return
 	}
 
@@ -138,33 +272,31 @@ func (h *HotelHandler) CreateHotel(w http.ResponseWriter, r *http.Request) {
 
 // UpdateHotel godoc
 // @Summary Update a hotel
-// @Description Update a hotel
+// @Description Update a hotel. Requires the admin scope.
 // @Accept json
 // @Produce json
 // @Param id path int true "Hotel ID"
 // @Param hotel body dto.HotelRequestBody true "Hotel object"
 // @Success 200 {object} response.HTTPResponse{content=models.Hotel}
+// @Failure 403 {object} response.Problem
 // @Router /hotels/{id} [put]
 func (h *HotelHandler) UpdateHotel(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid hotel ID")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid hotel ID")
 		return
 	}
 
 	var hotelDto dto.HotelRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&hotelDto); err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid request body")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
 		return
 	}
 
 	hotel, errDetails := h.service.UpdateHotel(uint(id), &hotelDto)
 	if errDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(errDetails.Code, errDetails.Message)
-		response.WriteHTTPResponse(w, errDetails.Code, errResp)
+		writeServiceError(w, r, h.logger, errDetails)
 		return
 	}
 
@@ -177,26 +309,58 @@ func (h *HotelHandler) UpdateHotel(w http.ResponseWriter, r *http.Request) {
 
 // DeleteHotel godoc
 // @Summary Delete a hotel
-// @Description Delete a hotel
+// @Description Soft-delete a hotel along with its reviews and provider mappings. They can be restored until the purge job removes them after the retention window. Requires the admin scope.
 // @Produce json
 // @Param id path int true "Hotel ID"
 // @Success 204
+// @Failure 403 {object} response.Problem
 // @Router /hotels/{id} [delete]
 func (h *HotelHandler) DeleteHotel(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid hotel ID")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid hotel ID")
 		return
 	}
 
 	errDetails := h.service.DeleteHotel(uint(id))
 	if errDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(errDetails.Code, errDetails.Message)
-		response.WriteHTTPResponse(w, errDetails.Code, errResp)
+		writeServiceError(w, r, h.logger, errDetails)
 		return
 	}
 
-	response.WriteHTTPResponse(w, http.StatusNoContent, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreHotel godoc
+// @Summary Restore a deleted hotel
+// @Description Restore a soft-deleted hotel along with the reviews and provider mappings deleted with it. Requires the admin scope.
+// @ID restore-hotel
+// @Produce json
+// @Param id path int true "Hotel ID"
+// @Success 200 {object} response.HTTPResponse{content=models.Hotel}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /hotels/{id}/restore [post]
+func (h *HotelHandler) RestoreHotel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid hotel ID")
+		return
+	}
+
+	hotel, errDetails := h.service.RestoreHotel(uint(id))
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: hotel,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
 }
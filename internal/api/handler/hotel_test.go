@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
@@ -179,3 +180,369 @@ func TestHotelHandler_DeleteHotel(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, rr.Code)
 	})
 }
+
+func TestHotelHandler_RestoreHotel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().RestoreHotel(uint(1)).Return(&models.Hotel{ID: 1, HotelName: "Test Hotel"}, nil)
+
+		req, err := http.NewRequest("POST", "/hotels/1/restore", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		hotelHandler.RestoreHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Content models.Hotel `json:"content"`
+		}
+		err = json.Unmarshal(rr.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), resp.Content.ID)
+		assert.False(t, resp.Content.DeletedAt.Valid)
+	})
+
+	t.Run("not_deleted", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().RestoreHotel(uint(1)).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusConflict,
+			Message: "Hotel is not deleted",
+			Error:   errors.New("Hotel is not deleted"),
+		})
+
+		req, err := http.NewRequest("POST", "/hotels/1/restore", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		hotelHandler.RestoreHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestHotelHandler_GetHotelsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("include_deleted_as_admin", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().GetHotelsList(gomock.Any()).DoAndReturn(func(params *dto.HotelsQueryParams) ([]*models.Hotel, int, *response.ErrorDetails) {
+			assert.True(t, params.IncludeDeleted)
+			return []*models.Hotel{{ID: 1, HotelName: "Test Hotel"}}, 1, nil
+		})
+
+		req, err := http.NewRequest("GET", "/hotels?include_deleted=true", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("admin-secret", hotelHandler.GetHotelsList, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("include_deleted_forbidden", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		req, err := http.NewRequest("GET", "/hotels?include_deleted=true", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("secret", hotelHandler.GetHotelsList, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("near_and_filters", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().GetHotelsList(gomock.Any()).DoAndReturn(func(params *dto.HotelsQueryParams) ([]*models.Hotel, int, *response.ErrorDetails) {
+			assert.Equal(t, &dto.GeoPoint{Lat: 10.7769, Lng: 106.7009}, params.Near)
+			assert.Equal(t, 5.0, params.RadiusKm)
+			assert.Equal(t, "Ho Chi Minh City", params.City)
+			assert.Equal(t, "VN", params.Country)
+			return []*models.Hotel{{ID: 1, HotelName: "Test Hotel"}}, 1, nil
+		})
+
+		req, err := http.NewRequest("GET", "/hotels?near=10.7769,106.7009&radius_km=5&city=Ho+Chi+Minh+City&country=VN", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := httptest.NewRecorder()
+		hotelHandler.GetHotelsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("invalid_near", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		req, err := http.NewRequest("GET", "/hotels?near=95,106.7", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := httptest.NewRecorder()
+		hotelHandler.GetHotelsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("validation_error", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().GetHotelsList(gomock.Any()).Return(nil, 0, response.ValidationErrorDetails("radius_km", "radius_km requires near", nil))
+
+		req, err := http.NewRequest("GET", "/hotels?radius_km=5", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := httptest.NewRecorder()
+		hotelHandler.GetHotelsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var problem response.Problem
+		err = json.Unmarshal(rr.Body.Bytes(), &problem)
+		assert.NoError(t, err)
+		assert.Equal(t, response.CodeValidationFailed, problem.Code)
+		assert.Equal(t, []response.FieldError{{Field: "radius_km", Message: "radius_km requires near"}}, problem.Errors)
+	})
+}
+
+func TestHotelHandler_GetHotelSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		averageRating := 7.6
+		mockService.EXPECT().GetHotelSummary(uint(1)).Return(&dto.HotelSummary{
+			HotelID:       1,
+			HotelName:     "Test Hotel",
+			ReviewCount:   4,
+			AverageRating: &averageRating,
+		}, nil)
+
+		req, err := http.NewRequest("GET", "/hotels/1/summary", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		hotelHandler.GetHotelSummary(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Content dto.HotelSummary `json:"content"`
+		}
+		err = json.Unmarshal(rr.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, resp.Content.ReviewCount)
+		assert.Equal(t, 7.6, *resp.Content.AverageRating)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().GetHotelSummary(uint(1)).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Hotel not found",
+			Error:   errors.New("not found"),
+		})
+
+		req, err := http.NewRequest("GET", "/hotels/1/summary", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		hotelHandler.GetHotelSummary(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestHotelHandler_GetHotelRatingTimeseries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().GetHotelRatingTimeseries(uint(1), gomock.Any()).DoAndReturn(func(id uint, params *dto.RatingTimeseriesQueryParams) (*dto.RatingTimeseries, *response.ErrorDetails) {
+			assert.Equal(t, dto.IntervalWeek, params.Interval)
+			assert.Equal(t, uint(332), params.ProviderID)
+			assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), params.From)
+			return &dto.RatingTimeseries{HotelID: 1, Interval: params.Interval, Points: []*dto.RatingTimeseriesPoint{}}, nil
+		})
+
+		req, err := http.NewRequest("GET", "/hotels/1/ratings/timeseries?interval=week&provider_id=332&from=2025-01-01", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		hotelHandler.GetHotelRatingTimeseries(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("defaults_to_month", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().GetHotelRatingTimeseries(uint(1), &dto.RatingTimeseriesQueryParams{Interval: dto.IntervalMonth}).
+			Return(&dto.RatingTimeseries{HotelID: 1, Interval: dto.IntervalMonth, Points: []*dto.RatingTimeseriesPoint{}}, nil)
+
+		req, err := http.NewRequest("GET", "/hotels/1/ratings/timeseries", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		hotelHandler.GetHotelRatingTimeseries(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("date_only_to", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().GetHotelRatingTimeseries(uint(1), &dto.RatingTimeseriesQueryParams{
+			Interval: dto.IntervalMonth,
+			To:       dto.RangeEnd{Time: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), DateOnly: true},
+		}).Return(&dto.RatingTimeseries{HotelID: 1, Interval: dto.IntervalMonth, Points: []*dto.RatingTimeseriesPoint{}}, nil)
+
+		req, err := http.NewRequest("GET", "/hotels/1/ratings/timeseries?to=2025-06-30", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		hotelHandler.GetHotelRatingTimeseries(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestHotelHandler_GetHotelResponseMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		rate, hours := 0.5, 26.0
+		mockService.EXPECT().GetHotelResponseMetrics(uint(1), gomock.Any()).DoAndReturn(func(id uint, params *dto.ResponseMetricsQueryParams) (*dto.HotelResponseMetrics, *response.ErrorDetails) {
+			assert.Equal(t, uint(332), params.ProviderID)
+			assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), params.From)
+			assert.Equal(t, dto.RangeEnd{Time: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), DateOnly: true}, params.To)
+			return &dto.HotelResponseMetrics{HotelID: 1, ReviewCount: 4, ResponseCount: 2, ResponseRate: &rate, MedianResponseHours: &hours}, nil
+		})
+
+		req, err := http.NewRequest("GET", "/hotels/1/responses/metrics?provider_id=332&from=2025-01-01&to=2025-06-30", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		hotelHandler.GetHotelResponseMetrics(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"response_rate":0.5`)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		hotelHandler := handler.NewHotelHandler(mockService, log)
+
+		mockService.EXPECT().GetHotelResponseMetrics(uint(1), gomock.Any()).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Hotel not found",
+		})
+
+		req, err := http.NewRequest("GET", "/hotels/1/responses/metrics", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		hotelHandler.GetHotelResponseMetrics(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/response"
+)
+
+// NestedHandler serves the routes that list what belongs to a hotel or a
+// provider. It answers 404 when the parent does not exist, then hands over to
+// the flat list handler scoped to the parent, so every list parameter works.
+type NestedHandler struct {
+	hotels    *HotelHandler
+	providers *ProviderHandler
+	reviews   *ReviewHandler
+}
+
+// NewNestedHandler creates a new NestedHandler
+func NewNestedHandler(hotels *HotelHandler, providers *ProviderHandler, reviews *ReviewHandler) *NestedHandler {
+	return &NestedHandler{
+		hotels:    hotels,
+		providers: providers,
+		reviews:   reviews,
+	}
+}
+
+// GetHotelReviews godoc
+// @Summary Get the reviews of a hotel
+// @Description Get the reviews of a hotel. Accepts every parameter of the reviews list except hotel_id.
+// @ID get-hotel-reviews
+// @Produce json
+// @Param id path int true "Hotel ID"
+// @Param provider_id query int false "Provider ID"
+// @Param sort query string false "Sort order" Enums(review_date, -review_date, rating, -rating, created_at, -created_at)
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param include query string false "Comma-separated relations to embed: provider, hotel, response"
+// @Param fields query string false "Comma-separated review fields to return; embedded relations are always returned"
+// @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.Review}}
+// @Failure 404 {object} response.Problem
+// @Router /hotels/{id}/reviews [get]
+func (h *NestedHandler) GetHotelReviews(w http.ResponseWriter, r *http.Request) {
+	hotelID, ok := h.hotelID(w, r)
+	if !ok {
+		return
+	}
+
+	h.reviews.listReviews(w, r, func(queryParams *dto.ReviewQueryParams) {
+		queryParams.HotelID = hotelID
+	})
+}
+
+// GetHotelProviders godoc
+// @Summary Get the providers of a hotel
+// @Description Get the providers mapped to a hotel. Accepts every parameter of the providers list except hotel_id.
+// @ID get-hotel-providers
+// @Produce json
+// @Param id path int true "Hotel ID"
+// @Param name query string false "Provider name"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param fields query string false "Comma-separated provider fields to return"
+// @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.Provider}}
+// @Failure 404 {object} response.Problem
+// @Router /hotels/{id}/providers [get]
+func (h *NestedHandler) GetHotelProviders(w http.ResponseWriter, r *http.Request) {
+	hotelID, ok := h.hotelID(w, r)
+	if !ok {
+		return
+	}
+
+	h.providers.listProviders(w, r, func(queryParams *dto.ProvidersQueryParams) {
+		queryParams.HotelID = hotelID
+	})
+}
+
+// GetProviderHotels godoc
+// @Summary Get the hotels of a provider
+// @Description Get the hotels mapped to a provider. Accepts every parameter of the hotels list except provider_id.
+// @ID get-hotels-of-provider
+// @Produce json
+// @Param id path int true "Provider ID"
+// @Param name query string false "Hotel name"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param fields query string false "Comma-separated hotel fields to return"
+// @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.Hotel}}
+// @Failure 404 {object} response.Problem
+// @Router /providers/{id}/hotels [get]
+func (h *NestedHandler) GetProviderHotels(w http.ResponseWriter, r *http.Request) {
+	providerID, ok := h.providerID(w, r)
+	if !ok {
+		return
+	}
+
+	h.hotels.listHotels(w, r, func(queryParams *dto.HotelsQueryParams) {
+		queryParams.ProviderID = providerID
+	})
+}
+
+// GetProviderReviews godoc
+// @Summary Get the reviews of a provider
+// @Description Get the reviews from a provider. Accepts every parameter of the reviews list except provider_id.
+// @ID get-provider-reviews
+// @Produce json
+// @Param id path int true "Provider ID"
+// @Param hotel_id query int false "Hotel ID"
+// @Param sort query string false "Sort order" Enums(review_date, -review_date, rating, -rating, created_at, -created_at)
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param include query string false "Comma-separated relations to embed: provider, hotel, response"
+// @Param fields query string false "Comma-separated review fields to return; embedded relations are always returned"
+// @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.Review}}
+// @Failure 404 {object} response.Problem
+// @Router /providers/{id}/reviews [get]
+func (h *NestedHandler) GetProviderReviews(w http.ResponseWriter, r *http.Request) {
+	providerID, ok := h.providerID(w, r)
+	if !ok {
+		return
+	}
+
+	h.reviews.listReviews(w, r, func(queryParams *dto.ReviewQueryParams) {
+		queryParams.ProviderID = providerID
+	})
+}
+
+// hotelID reads the parent hotel from the path and checks it exists. It writes
+// the error response and returns false otherwise.
+func (h *NestedHandler) hotelID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid hotel ID")
+		return 0, false
+	}
+
+	if _, errorDetails := h.hotels.service.GetHotelByID(uint(id)); errorDetails != nil {
+		writeServiceError(w, r, h.hotels.logger, errorDetails)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// providerID reads the parent provider from the path and checks it exists. It
+// writes the error response and returns false otherwise.
+func (h *NestedHandler) providerID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Provider ID")
+		return 0, false
+	}
+
+	if _, errorDetails := h.providers.service.GetProviderByID(uint(id)); errorDetails != nil {
+		writeServiceError(w, r, h.providers.logger, errorDetails)
+		return 0, false
+	}
+	return uint(id), true
+}
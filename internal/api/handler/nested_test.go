@@ -0,0 +1,197 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/handler"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service/mock"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type nestedTestMocks struct {
+	hotels    *mock.MockHotelService
+	providers *mock.MockProviderService
+	reviews   *mock.MockReviewService
+}
+
+func newNestedTestHandler(ctrl *gomock.Controller) (*handler.NestedHandler, *nestedTestMocks) {
+	log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+	mocks := &nestedTestMocks{
+		hotels:    mock.NewMockHotelService(ctrl),
+		providers: mock.NewMockProviderService(ctrl),
+		reviews:   mock.NewMockReviewService(ctrl),
+	}
+	nestedHandler := handler.NewNestedHandler(
+		handler.NewHotelHandler(mocks.hotels, log),
+		handler.NewProviderHandler(mocks.providers, log),
+		handler.NewReviewHandler(mocks.reviews, log),
+	)
+	return nestedHandler, mocks
+}
+
+func TestNestedHandler_GetHotelReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		nestedHandler, mocks := newNestedTestHandler(ctrl)
+
+		mocks.hotels.EXPECT().GetHotelByID(uint(1)).Return(&models.Hotel{ID: 1}, nil)
+		mocks.reviews.EXPECT().GetReviewsList(gomock.Any()).DoAndReturn(func(params *dto.ReviewQueryParams) ([]*models.Review, int, *response.ErrorDetails) {
+			// The path wins over a hotel_id in the query string
+			assert.Equal(t, uint(1), params.HotelID)
+			assert.Equal(t, uint(332), params.ProviderID)
+			assert.Equal(t, "-rating", params.Sort)
+			return []*models.Review{{ID: 10, HotelID: 1}}, 1, nil
+		})
+
+		req, err := http.NewRequest("GET", "/hotels/1/reviews?hotel_id=2&provider_id=332&sort=-rating", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		nestedHandler.GetHotelReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("hotel_not_found", func(t *testing.T) {
+		// Arrange
+		nestedHandler, mocks := newNestedTestHandler(ctrl)
+
+		mocks.hotels.EXPECT().GetHotelByID(uint(1)).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Hotel not found",
+			Error:   errors.New("not found"),
+		})
+		mocks.reviews.EXPECT().GetReviewsList(gomock.Any()).Times(0)
+
+		req, err := http.NewRequest("GET", "/hotels/1/reviews", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		nestedHandler.GetHotelReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestNestedHandler_GetHotelProviders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		nestedHandler, mocks := newNestedTestHandler(ctrl)
+
+		mocks.hotels.EXPECT().GetHotelByID(uint(1)).Return(&models.Hotel{ID: 1}, nil)
+		mocks.providers.EXPECT().GetProvidersList(&dto.ProvidersQueryParams{Limit: 5, HotelID: 1}).
+			Return([]*models.Provider{{ID: 332, Name: "Agoda"}}, 1, nil)
+
+		req, err := http.NewRequest("GET", "/hotels/1/providers?limit=5", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		nestedHandler.GetHotelProviders(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestNestedHandler_GetProviderHotels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		nestedHandler, mocks := newNestedTestHandler(ctrl)
+
+		mocks.providers.EXPECT().GetProviderByID(uint(332)).Return(&models.Provider{ID: 332}, nil)
+		mocks.hotels.EXPECT().GetHotelsList(&dto.HotelsQueryParams{Limit: 20, Name: "saigon", ProviderID: 332}).
+			Return([]*models.Hotel{{ID: 1}}, 1, nil)
+
+		req, err := http.NewRequest("GET", "/providers/332/hotels?name=saigon", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "332"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		nestedHandler.GetProviderHotels(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("provider_not_found", func(t *testing.T) {
+		// Arrange
+		nestedHandler, mocks := newNestedTestHandler(ctrl)
+
+		mocks.providers.EXPECT().GetProviderByID(uint(332)).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Provider not found",
+			Error:   errors.New("not found"),
+		})
+
+		req, err := http.NewRequest("GET", "/providers/332/hotels", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "332"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		nestedHandler.GetProviderHotels(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestNestedHandler_GetProviderReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("cursor_pagination", func(t *testing.T) {
+		// Arrange
+		nestedHandler, mocks := newNestedTestHandler(ctrl)
+
+		mocks.providers.EXPECT().GetProviderByID(uint(332)).Return(&models.Provider{ID: 332}, nil)
+		mocks.reviews.EXPECT().GetReviewsPage(gomock.Any()).DoAndReturn(func(params *dto.ReviewQueryParams) (*dto.ReviewPage, *response.ErrorDetails) {
+			assert.Equal(t, uint(332), params.ProviderID)
+			return &dto.ReviewPage{Reviews: []*models.Review{}}, nil
+		})
+
+		req, err := http.NewRequest("GET", "/providers/332/reviews?pagination=cursor", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "332"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		nestedHandler.GetProviderReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/kirananto/review-system/internal/api/service"
 	"github.com/kirananto/review-system/internal/api/utils"
 	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
 )
 
 type ProviderHandler struct {
@@ -33,11 +35,21 @@ func NewProviderHandler(service service.ProviderService, logger *logger.Logger)
 // @ID get-providers-list
 // @Produce json
 // @Param name query string false "Provider name"
+// @Param hotel_id query int false "Only providers mapped to this hotel"
 // @Param limit query int false "Limit"
 // @Param offset query int false "Offset"
+// @Param fields query string false "Comma-separated provider fields to return"
+// @Param include_deleted query bool false "Also return soft-deleted providers; admin scope only"
 // @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.Provider}}
+// @Failure 403 {object} response.Problem
 // @Router /providers [get]
 func (h *ProviderHandler) GetProvidersList(w http.ResponseWriter, r *http.Request) {
+	h.listProviders(w, r, nil)
+}
+
+// listProviders writes a page of providers. scope, when set, restricts the
+// query parameters after they are decoded, as the nested routes do.
+func (h *ProviderHandler) listProviders(w http.ResponseWriter, r *http.Request, scope func(*dto.ProvidersQueryParams)) {
 	// Initialize with default values
 	queryParams := &dto.ProvidersQueryParams{
 		Limit:  20,
@@ -46,15 +58,22 @@ func (h *ProviderHandler) GetProvidersList(w http.ResponseWriter, r *http.Reques
 
 	// Parse query parameters automatically
 	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid query parameters")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+	if scope != nil {
+		scope(queryParams)
+	}
+	if !checkResponseOptions(w, r, queryParams.ResponseOptions, models.Provider{}, nil) {
+		return
+	}
+	if !checkIncludeDeleted(w, r, queryParams.IncludeDeleted) {
 		return
 	}
 
 	providers, total, errorDetails := h.service.GetProvidersList(queryParams)
 	if errorDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusInternalServerError, errorDetails.Error.Error())
-		response.WriteHTTPResponse(w, http.StatusInternalServerError, errResp)
+		writeServiceError(w, r, h.logger, errorDetails)
 		return
 	}
 
@@ -68,11 +87,8 @@ func (h *ProviderHandler) GetProvidersList(w http.ResponseWriter, r *http.Reques
 		Next:     nextURL,
 		Results:  providers,
 	}
-	resp := &response.HTTPResponse{
-		Content: content,
-	}
 
-	response.WriteHTTPResponse(w, http.StatusOK, resp)
+	writeSelectedList(w, r, queryParams.ResponseOptions, content)
 }
 
 // GetProvider godoc
@@ -81,25 +97,158 @@ func (h *ProviderHandler) GetProvidersList(w http.ResponseWriter, r *http.Reques
 // @ID get-provider-by-id
 // @Produce json
 // @Param id path int true "Provider ID"
+// @Param fields query string false "Comma-separated provider fields to return"
 // @Success 200 {object} response.HTTPResponse{content=models.Provider}
+// @Failure 400 {object} response.Problem
 // @Router /providers/{id} [get]
 func (h *ProviderHandler) GetProvider(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid Provider ID")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Provider ID")
+		return
+	}
+
+	opts := dto.NewResponseOptions(r.URL.Query())
+	if !checkResponseOptions(w, r, opts, models.Provider{}, nil) {
 		return
 	}
 
 	provider, errorDetails := h.service.GetProviderByID(uint(id))
 	if errorDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(errorDetails.Code, errorDetails.Message)
-		response.WriteHTTPResponse(w, errorDetails.Code, errResp)
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	writeSelectedFields(w, r, http.StatusOK, opts, provider, provider.UpdatedAt)
+}
+
+// CreateProvider godoc
+// @Summary Create a new provider
+// @Description Create a new provider. Provider names are unique. Requires the admin scope.
+// @ID create-provider
+// @Accept json
+// @Produce json
+// @Param provider body dto.ProviderRequestBody true "Provider object"
+// @Success 201 {object} response.HTTPResponse{content=models.Provider}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /providers [post]
+func (h *ProviderHandler) CreateProvider(w http.ResponseWriter, r *http.Request) {
+	var providerDto dto.ProviderRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&providerDto); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+
+	provider, errDetails := h.service.CreateProvider(&providerDto)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: provider,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusCreated, resp)
+}
+
+// UpdateProvider godoc
+// @Summary Update a provider
+// @Description Update a provider. Requires the admin scope.
+// @ID update-provider
+// @Accept json
+// @Produce json
+// @Param id path int true "Provider ID"
+// @Param provider body dto.ProviderRequestBody true "Provider object"
+// @Success 200 {object} response.HTTPResponse{content=models.Provider}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /providers/{id} [put]
+func (h *ProviderHandler) UpdateProvider(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Provider ID")
+		return
+	}
+
+	var providerDto dto.ProviderRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&providerDto); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+
+	provider, errDetails := h.service.UpdateProvider(uint(id), &providerDto)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: provider,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// DeleteProvider godoc
+// @Summary Delete a provider
+// @Description Soft-delete a provider along with its reviews and hotel mappings. They can be restored until the purge job removes them after the retention window. Requires the admin scope.
+// @ID delete-provider
+// @Produce json
+// @Param id path int true "Provider ID"
+// @Success 204
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /providers/{id} [delete]
+func (h *ProviderHandler) DeleteProvider(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Provider ID")
+		return
+	}
+
+	errDetails := h.service.DeleteProvider(uint(id))
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreProvider godoc
+// @Summary Restore a deleted provider
+// @Description Restore a soft-deleted provider along with the reviews and hotel mappings deleted with it. Requires the admin scope.
+// @ID restore-provider
+// @Produce json
+// @Param id path int true "Provider ID"
+// @Success 200 {object} response.HTTPResponse{content=models.Provider}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /providers/{id}/restore [post]
+func (h *ProviderHandler) RestoreProvider(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Provider ID")
+		return
+	}
+
+	provider, errDetails := h.service.RestoreProvider(uint(id))
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
 		return
 	}
 
-	// Create success response
 	resp := &response.HTTPResponse{
 		Content: provider,
 	}
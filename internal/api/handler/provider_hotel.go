@@ -1,14 +1,18 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"github.com/gorilla/mux"
 	"github.com/gorilla/schema"
 	"github.com/kirananto/review-system/internal/api/dto"
 	"github.com/kirananto/review-system/internal/api/response"
 	"github.com/kirananto/review-system/internal/api/service"
 	"github.com/kirananto/review-system/internal/api/utils"
 	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
 )
 
 type ProviderHotelHandler struct {
@@ -33,7 +37,11 @@ func NewProviderHotelHandler(service service.ProviderHotelService, logger *logge
 // @Param hotel_id query int false "Hotel ID"
 // @Param limit query int false "Limit"
 // @Param offset query int false "Offset"
+// @Param include query string false "Comma-separated relations to embed: provider, hotel"
+// @Param fields query string false "Comma-separated provider hotel fields to return; embedded relations are always returned"
+// @Param include_deleted query bool false "Also return soft-deleted provider hotels; admin scope only"
 // @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.ProviderHotel}}
+// @Failure 403 {object} response.Problem
 // @Router /provider-hotels [get]
 func (h *ProviderHotelHandler) GetProviderHotelsList(w http.ResponseWriter, r *http.Request) {
 	// Initialize with default values
@@ -44,15 +52,19 @@ func (h *ProviderHotelHandler) GetProviderHotelsList(w http.ResponseWriter, r *h
 
 	// Parse query parameters automatically
 	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid query parameters")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+	if !checkResponseOptions(w, r, queryParams.ResponseOptions, models.ProviderHotel{}, dto.ProviderHotelRelations) {
+		return
+	}
+	if !checkIncludeDeleted(w, r, queryParams.IncludeDeleted) {
 		return
 	}
 
 	providerHotels, total, errorDetails := h.service.GetProviderHotelsList(queryParams)
 	if errorDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusInternalServerError, errorDetails.Error.Error())
-		response.WriteHTTPResponse(w, http.StatusInternalServerError, errResp)
+		writeServiceError(w, r, h.logger, errorDetails)
 		return
 	}
 
@@ -66,9 +78,185 @@ func (h *ProviderHotelHandler) GetProviderHotelsList(w http.ResponseWriter, r *h
 		Next:     nextURL,
 		Results:  providerHotels,
 	}
+
+	writeSelectedList(w, r, queryParams.ResponseOptions, content)
+}
+
+// GetProviderHotel godoc
+// @Summary Get a provider hotel
+// @Description Get the mapping between a provider and a hotel
+// @ID get-provider-hotel
+// @Produce json
+// @Param provider_id path int true "Provider ID"
+// @Param hotel_id path int true "Hotel ID"
+// @Param include query string false "Comma-separated relations to embed: provider, hotel"
+// @Param fields query string false "Comma-separated provider hotel fields to return; embedded relations are always returned"
+// @Success 200 {object} response.HTTPResponse{content=models.ProviderHotel}
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /provider-hotels/{provider_id}/{hotel_id} [get]
+func (h *ProviderHotelHandler) GetProviderHotel(w http.ResponseWriter, r *http.Request) {
+	providerID, hotelID, ok := parseProviderHotelIDs(w, r)
+	if !ok {
+		return
+	}
+
+	opts := dto.NewResponseOptions(r.URL.Query())
+	if !checkResponseOptions(w, r, opts, models.ProviderHotel{}, dto.ProviderHotelRelations) {
+		return
+	}
+
+	providerHotel, errDetails := h.service.GetProviderHotel(providerID, hotelID, opts.IncludeList()...)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	writeSelectedFields(w, r, http.StatusOK, opts, providerHotel, providerHotel.UpdatedAt)
+}
+
+// CreateProviderHotel godoc
+// @Summary Create a provider hotel
+// @Description Map a hotel to a provider along with the provider's stats for it. Requires the admin scope.
+// @ID create-provider-hotel
+// @Accept json
+// @Produce json
+// @Param providerHotel body dto.ProviderHotelRequestBody true "Provider hotel object"
+// @Success 201 {object} response.HTTPResponse{content=models.ProviderHotel}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /provider-hotels [post]
+func (h *ProviderHotelHandler) CreateProviderHotel(w http.ResponseWriter, r *http.Request) {
+	var providerHotelDto dto.ProviderHotelRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&providerHotelDto); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+
+	providerHotel, errDetails := h.service.CreateProviderHotel(&providerHotelDto)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
 	resp := &response.HTTPResponse{
-		Content: content,
+		Content: providerHotel,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusCreated, resp)
+}
+
+// UpdateProviderHotel godoc
+// @Summary Update a provider hotel
+// @Description Update the overall score, review count and grades of a provider hotel. Requires the admin scope.
+// @ID update-provider-hotel
+// @Accept json
+// @Produce json
+// @Param provider_id path int true "Provider ID"
+// @Param hotel_id path int true "Hotel ID"
+// @Param stats body dto.ProviderHotelStatsBody true "Provider hotel stats"
+// @Success 200 {object} response.HTTPResponse{content=models.ProviderHotel}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /provider-hotels/{provider_id}/{hotel_id} [put]
+func (h *ProviderHotelHandler) UpdateProviderHotel(w http.ResponseWriter, r *http.Request) {
+	providerID, hotelID, ok := parseProviderHotelIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var stats dto.ProviderHotelStatsBody
+	if err := json.NewDecoder(r.Body).Decode(&stats); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+
+	providerHotel, errDetails := h.service.UpdateProviderHotel(providerID, hotelID, &stats)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: providerHotel,
 	}
 
 	response.WriteHTTPResponse(w, http.StatusOK, resp)
 }
+
+// DeleteProviderHotel godoc
+// @Summary Delete a provider hotel
+// @Description Soft-delete the mapping between a provider and a hotel. It can be restored until the purge job removes it after the retention window. Requires the admin scope.
+// @ID delete-provider-hotel
+// @Produce json
+// @Param provider_id path int true "Provider ID"
+// @Param hotel_id path int true "Hotel ID"
+// @Success 204
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /provider-hotels/{provider_id}/{hotel_id} [delete]
+func (h *ProviderHotelHandler) DeleteProviderHotel(w http.ResponseWriter, r *http.Request) {
+	providerID, hotelID, ok := parseProviderHotelIDs(w, r)
+	if !ok {
+		return
+	}
+
+	errDetails := h.service.DeleteProviderHotel(providerID, hotelID)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreProviderHotel godoc
+// @Summary Restore a deleted provider hotel
+// @Description Restore a soft-deleted mapping between a provider and a hotel. The provider and hotel have to be restored first. Requires the admin scope.
+// @ID restore-provider-hotel
+// @Produce json
+// @Param provider_id path int true "Provider ID"
+// @Param hotel_id path int true "Hotel ID"
+// @Success 200 {object} response.HTTPResponse{content=models.ProviderHotel}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /provider-hotels/{provider_id}/{hotel_id}/restore [post]
+func (h *ProviderHotelHandler) RestoreProviderHotel(w http.ResponseWriter, r *http.Request) {
+	providerID, hotelID, ok := parseProviderHotelIDs(w, r)
+	if !ok {
+		return
+	}
+
+	providerHotel, errDetails := h.service.RestoreProviderHotel(providerID, hotelID)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: providerHotel,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// parseProviderHotelIDs reads the composite key from the path. It writes a
+// 400 response and returns false when either ID is invalid.
+func parseProviderHotelIDs(w http.ResponseWriter, r *http.Request) (uint, uint, bool) {
+	vars := mux.Vars(r)
+	providerID, err := strconv.Atoi(vars["provider_id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Provider ID")
+		return 0, 0, false
+	}
+	hotelID, err := strconv.Atoi(vars["hotel_id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid hotel ID")
+		return 0, 0, false
+	}
+	return uint(providerID), uint(hotelID), true
+}
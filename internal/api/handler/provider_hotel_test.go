@@ -0,0 +1,263 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/handler"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service/mock"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderHotelHandler_GetProviderHotel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHotelHandler := handler.NewProviderHotelHandler(mockService, log)
+
+		expected := &models.ProviderHotel{ProviderID: 1, HotelID: 2, OverallScore: 8.2, ReviewCount: 10}
+		mockService.EXPECT().GetProviderHotel(uint(1), uint(2)).Return(expected, nil)
+
+		req, err := http.NewRequest("GET", "/provider-hotels/1/2", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"provider_id": "1", "hotel_id": "2"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHotelHandler.GetProviderHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp response.HTTPResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+
+		var actual models.ProviderHotel
+		actualBytes, _ := json.Marshal(resp.Content)
+		err = json.Unmarshal(actualBytes, &actual)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected.ProviderID, actual.ProviderID)
+		assert.Equal(t, expected.HotelID, actual.HotelID)
+		assert.Equal(t, expected.ReviewCount, actual.ReviewCount)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHotelHandler := handler.NewProviderHotelHandler(mockService, log)
+
+		mockService.EXPECT().GetProviderHotel(uint(1), uint(2)).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Provider hotel not found",
+			Error:   errors.New("not found"),
+		})
+
+		req, err := http.NewRequest("GET", "/provider-hotels/1/2", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"provider_id": "1", "hotel_id": "2"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHotelHandler.GetProviderHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid_hotel_id", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHotelHandler := handler.NewProviderHotelHandler(mockService, log)
+
+		req, err := http.NewRequest("GET", "/provider-hotels/1/abc", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"provider_id": "1", "hotel_id": "abc"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHotelHandler.GetProviderHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestProviderHotelHandler_CreateProviderHotel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHotelHandler := handler.NewProviderHotelHandler(mockService, log)
+
+		providerHotelDto := &dto.ProviderHotelRequestBody{ProviderID: 1, HotelID: 2, OverallScore: 8.2, ReviewCount: 10}
+		mockService.EXPECT().CreateProviderHotel(gomock.Any()).Return(&models.ProviderHotel{ProviderID: 1, HotelID: 2}, nil)
+
+		body, _ := json.Marshal(providerHotelDto)
+		req, err := http.NewRequest("POST", "/provider-hotels", bytes.NewBuffer(body))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHotelHandler.CreateProviderHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHotelHandler := handler.NewProviderHotelHandler(mockService, log)
+
+		mockService.EXPECT().CreateProviderHotel(gomock.Any()).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusConflict,
+			Message: "Provider hotel already exists",
+			Error:   errors.New("duplicate"),
+		})
+
+		req, err := http.NewRequest("POST", "/provider-hotels", bytes.NewBufferString(`{"provider_id":1,"hotel_id":2}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHotelHandler.CreateProviderHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestProviderHotelHandler_UpdateProviderHotel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHotelHandler := handler.NewProviderHotelHandler(mockService, log)
+
+		mockService.EXPECT().UpdateProviderHotel(uint(1), uint(2), gomock.Any()).Return(&models.ProviderHotel{ProviderID: 1, HotelID: 2, OverallScore: 9}, nil)
+
+		req, err := http.NewRequest("PUT", "/provider-hotels/1/2", bytes.NewBufferString(`{"overall_score":9,"review_count":12}`))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"provider_id": "1", "hotel_id": "2"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHotelHandler.UpdateProviderHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestProviderHotelHandler_DeleteProviderHotel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHotelHandler := handler.NewProviderHotelHandler(mockService, log)
+
+		mockService.EXPECT().DeleteProviderHotel(uint(1), uint(2)).Return(nil)
+
+		req, err := http.NewRequest("DELETE", "/provider-hotels/1/2", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"provider_id": "1", "hotel_id": "2"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHotelHandler.DeleteProviderHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+}
+
+func TestProviderHotelHandler_RestoreProviderHotel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHotelHandler := handler.NewProviderHotelHandler(mockService, log)
+
+		mockService.EXPECT().RestoreProviderHotel(uint(1), uint(2)).Return(&models.ProviderHotel{ProviderID: 1, HotelID: 2}, nil)
+
+		req, err := http.NewRequest("POST", "/provider-hotels/1/2/restore", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"provider_id": "1", "hotel_id": "2"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHotelHandler.RestoreProviderHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("parent_deleted", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderHotelService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHotelHandler := handler.NewProviderHotelHandler(mockService, log)
+
+		mockService.EXPECT().RestoreProviderHotel(uint(1), uint(2)).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusConflict,
+			Message: "The hotel is deleted, restore it first",
+			Error:   errors.New("record not found"),
+		})
+
+		req, err := http.NewRequest("POST", "/provider-hotels/1/2/restore", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"provider_id": "1", "hotel_id": "2"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHotelHandler.RestoreProviderHotel(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		var problem response.Problem
+		err = json.Unmarshal(rr.Body.Bytes(), &problem)
+		assert.NoError(t, err)
+		assert.Equal(t, "The hotel is deleted, restore it first", problem.Detail)
+	})
+}
@@ -0,0 +1,307 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/handler"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service/mock"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderHandler_CreateProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		providerDto := &dto.ProviderRequestBody{Name: "Agoda"}
+		mockService.EXPECT().CreateProvider(providerDto).Return(&models.Provider{ID: 1, Name: "Agoda"}, nil)
+
+		body, _ := json.Marshal(providerDto)
+		req, err := http.NewRequest("POST", "/providers", bytes.NewBuffer(body))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.CreateProvider(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		providerDto := &dto.ProviderRequestBody{Name: "Agoda"}
+		mockService.EXPECT().CreateProvider(providerDto).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusConflict,
+			Message: "A provider with this name already exists",
+			Error:   errors.New("duplicate"),
+		})
+
+		body, _ := json.Marshal(providerDto)
+		req, err := http.NewRequest("POST", "/providers", bytes.NewBuffer(body))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.CreateProvider(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("invalid_body", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		req, err := http.NewRequest("POST", "/providers", bytes.NewBufferString("{"))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.CreateProvider(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+	t.Run("wrong_field_type", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		req, err := http.NewRequest("POST", "/providers", bytes.NewBufferString(`{"name": 42}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.CreateProvider(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		var problem response.Problem
+		err = json.Unmarshal(rr.Body.Bytes(), &problem)
+		assert.NoError(t, err)
+		assert.Equal(t, response.CodeMalformedRequest, problem.Code)
+		assert.Equal(t, []response.FieldError{{Field: "name", Message: "must be a string"}}, problem.Errors)
+	})
+}
+
+func TestProviderHandler_GetProvidersList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("internal_error_not_leaked", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		mockService.EXPECT().GetProvidersList(gomock.Any()).Return(nil, 0, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   errors.New(`pq: password authentication failed for user "review"`),
+		})
+
+		req, err := http.NewRequest("GET", "/providers", nil)
+		assert.NoError(t, err)
+		req.Header.Set(response.TraceHeader, "trace-42")
+
+		// Act
+		rr := serveAs("secret", providerHandler.GetProvidersList, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, response.ProblemContentType, rr.Header().Get("Content-Type"))
+		assert.NotContains(t, rr.Body.String(), "password")
+
+		var problem response.Problem
+		err = json.Unmarshal(rr.Body.Bytes(), &problem)
+		assert.NoError(t, err)
+		assert.Equal(t, response.CodeInternalServerError, problem.Code)
+		assert.Equal(t, "trace-42", problem.TraceID)
+	})
+
+	t.Run("invalid_query_fields", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		req, err := http.NewRequest("GET", "/providers?limit=ten&offset=-&hotel_id=x", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.GetProvidersList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		var problem response.Problem
+		err = json.Unmarshal(rr.Body.Bytes(), &problem)
+		assert.NoError(t, err)
+		assert.Equal(t, []response.FieldError{
+			{Field: "hotel_id", Message: "invalid value"},
+			{Field: "limit", Message: "invalid value"},
+			{Field: "offset", Message: "invalid value"},
+		}, problem.Errors)
+	})
+}
+
+func TestProviderHandler_UpdateProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		providerDto := &dto.ProviderRequestBody{Name: "Booking.com"}
+		mockService.EXPECT().UpdateProvider(uint(1), providerDto).Return(&models.Provider{ID: 1, Name: "Booking.com"}, nil)
+
+		body, _ := json.Marshal(providerDto)
+		req, err := http.NewRequest("PUT", "/providers/1", bytes.NewBuffer(body))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.UpdateProvider(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestProviderHandler_DeleteProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		mockService.EXPECT().DeleteProvider(uint(1)).Return(nil)
+
+		req, err := http.NewRequest("DELETE", "/providers/1", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.DeleteProvider(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		mockService.EXPECT().DeleteProvider(uint(1)).Return(&response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Provider not found",
+			Error:   errors.New("not found"),
+		})
+
+		req, err := http.NewRequest("DELETE", "/providers/1", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.DeleteProvider(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestProviderHandler_RestoreProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		mockService.EXPECT().RestoreProvider(uint(1)).Return(&models.Provider{ID: 1, Name: "Agoda"}, nil)
+
+		req, err := http.NewRequest("POST", "/providers/1/restore", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.RestoreProvider(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockProviderService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		providerHandler := handler.NewProviderHandler(mockService, log)
+
+		mockService.EXPECT().RestoreProvider(uint(1)).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Provider not found",
+			Error:   errors.New("not found"),
+		})
+
+		req, err := http.NewRequest("POST", "/providers/1/restore", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		providerHandler.RestoreProvider(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/validator"
+)
+
+// checkResponseOptions validates the include and fields query parameters
+// against model, the type of the response body or of each list result, and the
+// relations the resource can embed. On failure it writes a 400 response and
+// returns false.
+func checkResponseOptions(w http.ResponseWriter, r *http.Request, opts dto.ResponseOptions, model interface{}, relations []string) bool {
+	err := validator.ValidateResponseOptions(opts, model, relations)
+	if err == nil {
+		return true
+	}
+
+	var validationErr *validator.ValidationError
+	if !errors.As(err, &validationErr) {
+		response.WriteError(w, r, http.StatusInternalServerError, "Internal server error")
+		return false
+	}
+
+	response.WriteErrorDetails(w, r, response.ValidationErrorDetails(validationErr.Field, validationErr.Message, err))
+	return false
+}
+
+// writeSelectedFields writes content, a single resource or a list of them,
+// trimmed to the fields the request asked for. updatedAt becomes the
+// Last-Modified header unless relations are embedded, as those change on their
+// own.
+func writeSelectedFields(w http.ResponseWriter, r *http.Request, statusCode int, opts dto.ResponseOptions, content interface{}, updatedAt time.Time) {
+	selected, err := response.SelectFields(content, opts.FieldList())
+	if err != nil {
+		response.WriteError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if len(opts.IncludeList()) == 0 {
+		response.SetLastModified(w, updatedAt)
+	}
+
+	resp := &response.HTTPResponse{
+		Content: selected,
+	}
+
+	response.WriteHTTPResponse(w, statusCode, resp)
+}
+
+// writeSelectedList writes a page of results, each trimmed to the fields the
+// request asked for.
+func writeSelectedList(w http.ResponseWriter, r *http.Request, opts dto.ResponseOptions, content *response.HTTPResponseContent) {
+	results, err := response.SelectFields(content.Results, opts.FieldList())
+	if err != nil {
+		response.WriteError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	content.Results = results
+
+	resp := &response.HTTPResponse{
+		Content: content,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
@@ -1,16 +1,19 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/schema"
 	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/middleware"
 	"github.com/kirananto/review-system/internal/api/response"
 	"github.com/kirananto/review-system/internal/api/service"
 	"github.com/kirananto/review-system/internal/api/utils"
 	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
 )
 
 // ReviewHandler handles API requests for reviews
@@ -25,21 +28,46 @@ func NewReviewHandler(service service.ReviewService, logger *logger.Logger) *Rev
 	return &ReviewHandler{
 		service: service,
 		logger:  logger,
-		decoder: schema.NewDecoder(),
+		decoder: utils.NewQueryDecoder(),
 	}
 }
 
 // GetReviewsList godoc
 // @Summary Get a list of reviews
-// @Description Get a list of reviews with optional filters
+// @Description Get a list of reviews with optional filters. Dates are RFC 3339 timestamps or YYYY-MM-DD dates.
 // @ID get-reviews-list
 // @Produce json
 // @Param hotel_id query int false "Hotel ID"
+// @Param provider_id query int false "Provider ID"
+// @Param min_rating query number false "Minimum rating"
+// @Param max_rating query number false "Maximum rating"
+// @Param review_date_from query string false "Earliest review date"
+// @Param review_date_to query string false "Latest review date"
+// @Param lang query string false "Language code"
+// @Param traveler_type query string false "Traveler type from reviewer_info, e.g. Solo traveler"
+// @Param country query string false "Reviewer country from reviewer_info"
+// @Param has_comment query bool false "Only reviews with (true) or without (false) a comment"
+// @Param q query string false "Full-text search over title and comment; results are ranked by relevance unless sort is set"
+// @Param sort query string false "Sort order" Enums(review_date, -review_date, rating, -rating, created_at, -created_at)
+// @Param pagination query string false "Pagination mode, defaults to offset" Enums(offset, cursor)
+// @Param cursor query string false "Opaque cursor from next_cursor or prev_cursor; implies cursor pagination"
 // @Param limit query int false "Limit"
-// @Param offset query int false "Offset"
+// @Param offset query int false "Offset, only in offset pagination"
+// @Param include query string false "Comma-separated relations to embed: provider, hotel, response"
+// @Param fields query string false "Comma-separated review fields to return; embedded relations are always returned"
+// @Param include_deleted query bool false "Also return soft-deleted reviews; admin scope only"
 // @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.Review}}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
 // @Router /reviews [get]
 func (h *ReviewHandler) GetReviewsList(w http.ResponseWriter, r *http.Request) {
+	h.listReviews(w, r, nil)
+}
+
+// listReviews writes a page of reviews in offset or cursor mode. scope, when
+// set, restricts the query parameters after they are decoded, as the nested
+// routes do.
+func (h *ReviewHandler) listReviews(w http.ResponseWriter, r *http.Request, scope func(*dto.ReviewQueryParams)) {
 	// Initialize with default values
 	queryParams := &dto.ReviewQueryParams{
 		Limit:  20,
@@ -48,15 +76,30 @@ func (h *ReviewHandler) GetReviewsList(w http.ResponseWriter, r *http.Request) {
 
 	// Parse query parameters automatically
 	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid query parameters")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+	if scope != nil {
+		scope(queryParams)
+	}
+	if !checkResponseOptions(w, r, queryParams.ResponseOptions, models.Review{}, dto.ReviewRelations) {
+		return
+	}
+	if !checkReviewStatuses(w, r, queryParams.StatusList()) {
+		return
+	}
+	if !checkIncludeDeleted(w, r, queryParams.IncludeDeleted) {
+		return
+	}
+
+	if queryParams.CursorMode() {
+		h.getReviewsPage(w, r, queryParams)
 		return
 	}
 
 	reviews, total, errorDetails := h.service.GetReviewsList(queryParams)
 	if errorDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusInternalServerError, errorDetails.Error.Error())
-		response.WriteHTTPResponse(w, http.StatusInternalServerError, errResp)
+		writeServiceError(w, r, h.logger, errorDetails)
 		return
 	}
 
@@ -70,11 +113,144 @@ func (h *ReviewHandler) GetReviewsList(w http.ResponseWriter, r *http.Request) {
 		Next:     nextURL,
 		Results:  reviews,
 	}
-	resp := &response.HTTPResponse{
-		Content: content,
+
+	writeSelectedList(w, r, queryParams.ResponseOptions, content)
+}
+
+// getReviewsPage writes a page of the reviews list in cursor mode.
+func (h *ReviewHandler) getReviewsPage(w http.ResponseWriter, r *http.Request, queryParams *dto.ReviewQueryParams) {
+	page, errorDetails := h.service.GetReviewsPage(queryParams)
+	if errorDetails != nil {
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
 	}
 
-	response.WriteHTTPResponse(w, http.StatusOK, resp)
+	prevURL, nextURL := utils.GetCursorLinks(r, page.Prev, page.Next)
+
+	content := &response.HTTPResponseContent{
+		Count:      page.Total,
+		Previous:   prevURL,
+		Next:       nextURL,
+		PrevCursor: page.Prev,
+		NextCursor: page.Next,
+		Results:    page.Reviews,
+	}
+
+	writeSelectedList(w, r, queryParams.ResponseOptions, content)
+}
+
+// SearchReviews godoc
+// @Summary Search reviews
+// @Description Full-text search over review titles and comments, ranked by relevance. Accepts the same filters as the reviews list. Matches are highlighted with <mark> in the snippet.
+// @ID search-reviews
+// @Produce json
+// @Param q query string true "Search query in web search syntax, e.g. noisy breakfast or -pool"
+// @Param hotel_id query int false "Hotel ID"
+// @Param provider_id query int false "Provider ID"
+// @Param sort query string false "Sort order, defaults to relevance" Enums(review_date, -review_date, rating, -rating, created_at, -created_at)
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param include query string false "Comma-separated relations to embed: provider, hotel, response"
+// @Param fields query string false "Comma-separated search result fields to return; embedded relations are always returned"
+// @Param include_deleted query bool false "Also return soft-deleted reviews; admin scope only"
+// @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]dto.ReviewSearchResult}}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Router /search/reviews [get]
+func (h *ReviewHandler) SearchReviews(w http.ResponseWriter, r *http.Request) {
+	// Initialize with default values
+	queryParams := &dto.ReviewQueryParams{
+		Limit:  20,
+		Offset: 0,
+	}
+
+	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+	if !checkResponseOptions(w, r, queryParams.ResponseOptions, dto.ReviewSearchResult{}, dto.ReviewRelations) {
+		return
+	}
+	if !checkReviewStatuses(w, r, queryParams.StatusList()) {
+		return
+	}
+	if !checkIncludeDeleted(w, r, queryParams.IncludeDeleted) {
+		return
+	}
+
+	results, total, errorDetails := h.service.SearchReviews(queryParams)
+	if errorDetails != nil {
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	prevURL, nextURL := utils.GetPaginationLinks(r, queryParams.Offset, queryParams.Limit, total)
+
+	content := &response.HTTPResponseContent{
+		Count:    total,
+		Previous: prevURL,
+		Next:     nextURL,
+		Results:  results,
+	}
+
+	writeSelectedList(w, r, queryParams.ResponseOptions, content)
+}
+
+// ExportReviews godoc
+// @Summary Export reviews
+// @Description Stream every review matching the filters as NDJSON, one review per line, or as CSV with a header row. The format parameter takes precedence over the Accept header; NDJSON is the default. Reviews are in the order of the reviews list.
+// @ID export-reviews
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Param format query string false "Export format" Enums(ndjson, csv)
+// @Param hotel_id query int false "Hotel ID"
+// @Param provider_id query int false "Provider ID"
+// @Param min_rating query number false "Minimum rating"
+// @Param max_rating query number false "Maximum rating"
+// @Param review_date_from query string false "Earliest review date"
+// @Param review_date_to query string false "Latest review date"
+// @Param lang query string false "Language code"
+// @Param traveler_type query string false "Traveler type from reviewer_info, e.g. Solo traveler"
+// @Param country query string false "Reviewer country from reviewer_info"
+// @Param has_comment query bool false "Only reviews with (true) or without (false) a comment"
+// @Param q query string false "Full-text search over title and comment"
+// @Param sort query string false "Sort order" Enums(review_date, -review_date, rating, -rating, created_at, -created_at)
+// @Param include_deleted query bool false "Also return soft-deleted reviews; admin scope only"
+// @Success 200 {array} models.Review
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Router /exports/reviews [get]
+func (h *ReviewHandler) ExportReviews(w http.ResponseWriter, r *http.Request) {
+	queryParams := &dto.ReviewExportQueryParams{}
+	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+	if queryParams.Format == "" {
+		queryParams.Format = negotiateExportFormat(r.Header.Get("Accept"))
+	}
+	if !checkReviewStatuses(w, r, queryParams.StatusList()) {
+		return
+	}
+	if !checkIncludeDeleted(w, r, queryParams.IncludeDeleted) {
+		return
+	}
+
+	export := newReviewExportWriter(w, queryParams.Format)
+	errorDetails := h.service.ExportReviews(r.Context(), queryParams, export.Write)
+	if errorDetails != nil {
+		// Once streaming has begun the status is sent and the export is cut short
+		if export.Started() {
+			h.logger.Error(errorDetails.Error, "review export failed after streaming began")
+			return
+		}
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	if err := export.Finish(); err != nil {
+		h.logger.Error(err, "review export failed to flush")
+	}
 }
 
 // GetReview godoc
@@ -83,25 +259,205 @@ func (h *ReviewHandler) GetReviewsList(w http.ResponseWriter, r *http.Request) {
 // @ID get-review-by-id
 // @Produce json
 // @Param id path int true "Review ID"
+// @Param include query string false "Comma-separated relations to embed: provider, hotel, response"
+// @Param fields query string false "Comma-separated review fields to return; embedded relations are always returned"
 // @Success 200 {object} response.HTTPResponse{content=models.Review}
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
 // @Router /reviews/{id} [get]
 func (h *ReviewHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		errResp := response.GetErrorHTTPResponseBody(http.StatusBadRequest, "Invalid Review ID")
-		response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Review ID")
+		return
+	}
+
+	opts := dto.NewResponseOptions(r.URL.Query())
+	if !checkResponseOptions(w, r, opts, models.Review{}, dto.ReviewRelations) {
 		return
 	}
 
-	review, errorDetails := h.service.GetReviewByID(uint(id))
+	review, errorDetails := h.service.GetReviewByID(uint(id), opts.IncludeList()...)
 	if errorDetails != nil {
-		errResp := response.GetErrorHTTPResponseBody(errorDetails.Code, errorDetails.Message)
-		response.WriteHTTPResponse(w, errorDetails.Code, errResp)
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	// Unpublished reviews do not exist as far as the public is concerned
+	if review.Status != models.ReviewStatusPublished && !middleware.IsAdmin(r.Context()) {
+		response.WriteError(w, r, http.StatusNotFound, "Review not found")
+		return
+	}
+
+	writeSelectedFields(w, r, http.StatusOK, opts, review, review.UpdatedAt)
+}
+
+// CreateReview godoc
+// @Summary Create a new review
+// @Description Create a new review. The ID is assigned by the provider and is required. Requires the admin scope.
+// @ID create-review
+// @Accept json
+// @Produce json
+// @Param review body dto.ReviewRequestBody true "Review object"
+// @Success 201 {object} response.HTTPResponse{content=models.Review}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /reviews [post]
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	var reviewDto dto.ReviewRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&reviewDto); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+
+	review, errDetails := h.service.CreateReview(&reviewDto)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: review,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusCreated, resp)
+}
+
+// UpdateReview godoc
+// @Summary Replace a review
+// @Description Replace all writable fields of a review. Requires the admin scope.
+// @ID update-review
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param review body dto.ReviewRequestBody true "Review object"
+// @Success 200 {object} response.HTTPResponse{content=models.Review}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /reviews/{id} [put]
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Review ID")
+		return
+	}
+
+	var reviewDto dto.ReviewRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&reviewDto); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+
+	review, errDetails := h.service.UpdateReview(uint(id), &reviewDto)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: review,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// PatchReview godoc
+// @Summary Partially update a review
+// @Description Update only the fields present in the request body. Requires the admin scope.
+// @ID patch-review
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param review body dto.ReviewPatchBody true "Fields to update"
+// @Success 200 {object} response.HTTPResponse{content=models.Review}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /reviews/{id} [patch]
+func (h *ReviewHandler) PatchReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Review ID")
+		return
+	}
+
+	var patch dto.ReviewPatchBody
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+
+	review, errDetails := h.service.PatchReview(uint(id), &patch)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: review,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// DeleteReview godoc
+// @Summary Delete a review
+// @Description Soft-delete a review. It can be restored until the purge job removes it after the retention window. Requires the admin scope.
+// @ID delete-review
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 204
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /reviews/{id} [delete]
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Review ID")
+		return
+	}
+
+	errDetails := h.service.DeleteReview(uint(id))
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreReview godoc
+// @Summary Restore a deleted review
+// @Description Restore a soft-deleted review. Its provider and hotel have to be restored first. Requires the admin scope.
+// @ID restore-review
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 200 {object} response.HTTPResponse{content=models.Review}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /reviews/{id}/restore [post]
+func (h *ReviewHandler) RestoreReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Review ID")
+		return
+	}
+
+	review, errDetails := h.service.RestoreReview(uint(id))
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
 		return
 	}
 
-	// Create success response
 	resp := &response.HTTPResponse{
 		Content: review,
 	}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// exportFlushInterval is the number of reviews written between flushes, so
+// clients of the local server receive the export as it is produced.
+const exportFlushInterval = 500
+
+// exportMediaTypes maps each export format to its Content-Type.
+var exportMediaTypes = map[string]string{
+	dto.ExportFormatNDJSON: "application/x-ndjson",
+	dto.ExportFormatCSV:    "text/csv; charset=utf-8",
+}
+
+// reviewCSVHeader names the columns of a CSV export.
+var reviewCSVHeader = []string{
+	"id", "provider_id", "hotel_id", "rating", "title", "comment", "lang",
+	"review_date", "reviewer_info", "updated_at", "created_at",
+}
+
+// negotiateExportFormat picks the export format from the Accept header, taking
+// the first supported media type listed. Anything else gets NDJSON.
+func negotiateExportFormat(accept string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "application/x-ndjson", "application/jsonl":
+			return dto.ExportFormatNDJSON
+		case "text/csv":
+			return dto.ExportFormatCSV
+		}
+	}
+	return dto.ExportFormatNDJSON
+}
+
+// reviewExportWriter writes reviews to the response as they arrive. The
+// response headers are only sent with the first review, or by finish when there
+// are none, so errors raised before that can still be answered with a status.
+type reviewExportWriter struct {
+	w       http.ResponseWriter
+	format  string
+	started bool
+	count   int
+	json    *json.Encoder
+	csv     *csv.Writer
+}
+
+func newReviewExportWriter(w http.ResponseWriter, format string) *reviewExportWriter {
+	return &reviewExportWriter{w: w, format: format}
+}
+
+// Started reports whether any part of the response has been sent.
+func (e *reviewExportWriter) Started() bool {
+	return e.started
+}
+
+// Write writes one review.
+func (e *reviewExportWriter) Write(review *models.Review) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if e.format == dto.ExportFormatCSV {
+		if err := e.csv.Write(reviewCSVRecord(review)); err != nil {
+			return err
+		}
+	} else if err := e.json.Encode(review); err != nil {
+		return err
+	}
+
+	e.count++
+	if e.count%exportFlushInterval == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// Finish sends whatever is still buffered.
+func (e *reviewExportWriter) Finish() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.flush()
+}
+
+func (e *reviewExportWriter) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	e.w.Header().Set("Content-Type", exportMediaTypes[e.format])
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reviews.%s"`, e.format))
+	e.w.WriteHeader(http.StatusOK)
+
+	if e.format == dto.ExportFormatCSV {
+		e.csv = csv.NewWriter(e.w)
+		return e.csv.Write(reviewCSVHeader)
+	}
+	e.json = json.NewEncoder(e.w)
+	return nil
+}
+
+func (e *reviewExportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	// The Lambda adapter buffers the whole response and can not flush
+	if flusher, ok := e.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+func reviewCSVRecord(review *models.Review) []string {
+	return []string{
+		strconv.FormatUint(uint64(review.ID), 10),
+		strconv.FormatUint(uint64(review.ProviderID), 10),
+		strconv.FormatUint(uint64(review.HotelID), 10),
+		strconv.FormatFloat(review.Rating, 'f', -1, 64),
+		csvText(review.Title),
+		csvText(review.Comment),
+		csvText(review.Lang),
+		review.ReviewDate.UTC().Format(time.RFC3339),
+		csvText(string(review.ReviewerInfo)),
+		review.UpdatedAt.UTC().Format(time.RFC3339),
+		review.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// csvText guards a text cell from reviewers against formula injection.
+// Spreadsheets run cells starting with =, +, -, @, a tab or a carriage return
+// as formulas, so those are prefixed with a quote to be read as text.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// moderationQueueStatuses are the statuses the moderation queue lists unless
+// the status parameter asks for others.
+const moderationQueueStatuses = models.ReviewStatusPending + "," + models.ReviewStatusFlagged
+
+// GetModerationQueue godoc
+// @Summary Get the moderation queue
+// @Description List reviews awaiting moderation, pending and flagged ones by default, oldest first. Accepts the same filters as the reviews list. Requires the admin scope.
+// @ID get-moderation-queue
+// @Produce json
+// @Param status query string false "Comma-separated statuses, defaults to pending,flagged"
+// @Param hotel_id query int false "Hotel ID"
+// @Param provider_id query int false "Provider ID"
+// @Param sort query string false "Sort order, defaults to created_at" Enums(review_date, -review_date, rating, -rating, created_at, -created_at)
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.Review}}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Router /moderation/reviews [get]
+func (h *ReviewHandler) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	h.listReviews(w, r, func(params *dto.ReviewQueryParams) {
+		if params.Status == "" {
+			params.Status = moderationQueueStatuses
+		}
+		if params.Sort == "" {
+			params.Sort = "created_at"
+		}
+	})
+}
+
+// ModerateReview godoc
+// @Summary Moderate a review
+// @Description Approve (publish), reject or flag a review. Rejecting or flagging takes a published review down. A reason is required to reject or flag. Requires the admin scope.
+// @ID moderate-review
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param action path string true "Moderation action" Enums(approve, reject, flag)
+// @Param moderation body dto.ModerationRequestBody true "Moderation decision"
+// @Success 200 {object} response.HTTPResponse{content=models.Review}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /reviews/{id}/{action} [post]
+func (h *ReviewHandler) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Review ID")
+		return
+	}
+
+	var moderationDto dto.ModerationRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&moderationDto); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+	moderationDto.Moderator = moderator(r)
+
+	review, errDetails := h.service.ModerateReview(uint(id), vars["action"], &moderationDto)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: review,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// ModerateReviews godoc
+// @Summary Moderate a batch of reviews
+// @Description Apply the same moderation action to up to 500 reviews. Reviews that do not exist or whose status does not allow the action are reported as skipped. Requires the admin scope.
+// @ID moderate-reviews
+// @Accept json
+// @Produce json
+// @Param action path string true "Moderation action" Enums(approve, reject, flag)
+// @Param moderation body dto.BatchModerationRequestBody true "Review IDs and moderation decision"
+// @Success 200 {object} response.HTTPResponse{content=dto.BatchModerationResult}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Router /moderation/reviews/{action} [post]
+func (h *ReviewHandler) ModerateReviews(w http.ResponseWriter, r *http.Request) {
+	var moderationDto dto.BatchModerationRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&moderationDto); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+	moderationDto.Moderator = moderator(r)
+
+	result, errDetails := h.service.ModerateReviews(mux.Vars(r)["action"], &moderationDto)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: result,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// checkReviewStatuses answers 403 when a caller without the admin scope asks
+// for reviews that are not published, and returns false.
+func checkReviewStatuses(w http.ResponseWriter, r *http.Request, statuses []string) bool {
+	if middleware.IsAdmin(r.Context()) {
+		return true
+	}
+	for _, status := range statuses {
+		if status != models.ReviewStatusPublished {
+			response.WriteError(w, r, http.StatusForbidden, "Only published reviews are visible without the admin scope")
+			return false
+		}
+	}
+	return true
+}
+
+// moderator returns the name of the principal moderating a request, which
+// RequireAdmin ensures was authenticated.
+func moderator(r *http.Request) string {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		return ""
+	}
+	return principal.Name
+}
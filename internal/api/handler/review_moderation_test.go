@@ -0,0 +1,255 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/handler"
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service/mock"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAPIKeys are the API keys the tests call with.
+var testAPIKeys = []middleware.APIKey{
+	{Key: "secret", Name: "client"},
+	{Key: "admin-secret", Name: "admin", Scopes: []string{middleware.ScopeAdmin}},
+	{Key: "hotel-secret", Name: "hotel", Scopes: []string{middleware.ScopeRespond}, HotelID: 1},
+}
+
+func TestMain(m *testing.M) {
+	middleware.SetAPIKeys(testAPIKeys)
+	os.Exit(m.Run())
+}
+
+// serveAs runs a handler behind Trace and Auth with the given API key, as the
+// router does.
+func serveAs(apiKey string, handlerFunc http.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	rr := httptest.NewRecorder()
+	middleware.Trace(middleware.Auth(handlerFunc)).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestReviewHandler_ModerateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		moderated := &models.Review{ID: 1, Status: models.ReviewStatusRejected, ModerationReason: "abusive", ModeratedBy: "admin"}
+		mockService.EXPECT().ModerateReview(uint(1), dto.ModerationReject, &dto.ModerationRequestBody{Reason: "abusive", Moderator: "admin"}).Return(moderated, nil)
+
+		body, _ := json.Marshal(dto.ModerationRequestBody{Reason: "abusive"})
+		req, err := http.NewRequest("POST", "/reviews/1/reject", bytes.NewBuffer(body))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1", "action": "reject"})
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(reviewHandler.ModerateReview), req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"status":"rejected"`)
+		assert.Contains(t, rr.Body.String(), `"moderated_by":"admin"`)
+	})
+
+	t.Run("moderator is the caller", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().ModerateReview(uint(1), dto.ModerationApprove, &dto.ModerationRequestBody{Moderator: "admin"}).Return(&models.Review{ID: 1}, nil)
+
+		req, err := http.NewRequest("POST", "/reviews/1/approve", bytes.NewBufferString(`{"moderator":"someone-else"}`))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1", "action": "approve"})
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(reviewHandler.ModerateReview), req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().ModerateReview(uint(1), dto.ModerationFlag, gomock.Any()).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusConflict,
+			Message: "Can not flag a rejected review",
+			Error:   errors.New("review 1 is rejected"),
+		})
+
+		req, err := http.NewRequest("POST", "/reviews/1/flag", bytes.NewBufferString(`{"reason":"spam"}`))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1", "action": "flag"})
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(reviewHandler.ModerateReview), req)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Can not flag a rejected review")
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("POST", "/reviews/1/approve", bytes.NewBufferString(`{}`))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1", "action": "approve"})
+
+		// Act
+		rr := serveAs("secret", middleware.RequireAdmin(reviewHandler.ModerateReview), req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestReviewHandler_ModerateReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().ModerateReviews(dto.ModerationApprove, gomock.Any()).DoAndReturn(
+			func(action string, body *dto.BatchModerationRequestBody) (*dto.BatchModerationResult, *response.ErrorDetails) {
+				assert.Equal(t, []uint{1, 2, 3}, body.IDs)
+				assert.Equal(t, "admin", body.Moderator)
+				return &dto.BatchModerationResult{Status: models.ReviewStatusPublished, Moderated: []uint{1, 3}, Skipped: []uint{2}}, nil
+			})
+
+		req, err := http.NewRequest("POST", "/moderation/reviews/approve", bytes.NewBufferString(`{"ids":[1,2,3]}`))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"action": "approve"})
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(reviewHandler.ModerateReviews), req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Content dto.BatchModerationResult `json:"content"`
+		}
+		err = json.Unmarshal(rr.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1, 3}, resp.Content.Moderated)
+		assert.Equal(t, []uint{2}, resp.Content.Skipped)
+	})
+}
+
+func TestReviewHandler_GetModerationQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("defaults", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().GetReviewsList(gomock.Any()).DoAndReturn(func(params *dto.ReviewQueryParams) ([]*models.Review, int, *response.ErrorDetails) {
+			assert.Equal(t, []string{models.ReviewStatusPending, models.ReviewStatusFlagged}, params.StatusList())
+			assert.Equal(t, "created_at", params.Sort)
+			return []*models.Review{{ID: 1, Status: models.ReviewStatusPending}}, 1, nil
+		})
+
+		req, err := http.NewRequest("GET", "/moderation/reviews", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(reviewHandler.GetModerationQueue), req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestReviewHandler_Visibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("public_list_of_pending_reviews", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("GET", "/reviews?status=pending", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("secret", reviewHandler.GetReviewsList, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("admin_list_of_pending_reviews", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().GetReviewsList(gomock.Any()).Return([]*models.Review{}, 0, nil)
+
+		req, err := http.NewRequest("GET", "/reviews?status=pending", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("admin-secret", reviewHandler.GetReviewsList, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("unpublished_review", func(t *testing.T) {
+		for apiKey, expectedStatus := range map[string]int{"secret": http.StatusNotFound, "admin-secret": http.StatusOK} {
+			// Arrange
+			mockService := mock.NewMockReviewService(ctrl)
+			log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+			reviewHandler := handler.NewReviewHandler(mockService, log)
+
+			mockService.EXPECT().GetReviewByID(uint(1)).Return(&models.Review{ID: 1, Status: models.ReviewStatusRejected}, nil)
+
+			req, err := http.NewRequest("GET", "/reviews/1", nil)
+			assert.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			// Act
+			rr := serveAs(apiKey, reviewHandler.GetReview, req)
+
+			// Assert
+			assert.Equal(t, expectedStatus, rr.Code, apiKey)
+		}
+	})
+}
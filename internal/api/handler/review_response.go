@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// GetReviewResponse godoc
+// @Summary Get the hotel's response to a review
+// @Description Get the hotel management's response to a published review
+// @ID get-review-response
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 200 {object} response.HTTPResponse{content=models.ReviewResponse}
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /reviews/{id}/response [get]
+func (h *ReviewHandler) GetReviewResponse(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Review ID")
+		return
+	}
+
+	review, errorDetails := h.service.GetReviewByID(uint(id), dto.RelationResponse)
+	if errorDetails != nil {
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	if review.Status != models.ReviewStatusPublished && !middleware.IsAdmin(r.Context()) {
+		response.WriteError(w, r, http.StatusNotFound, "Review not found")
+		return
+	}
+	if review.Response == nil {
+		response.WriteError(w, r, http.StatusNotFound, "Review response not found")
+		return
+	}
+
+	response.SetLastModified(w, review.Response.UpdatedAt)
+
+	resp := &response.HTTPResponse{
+		Content: review.Response,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// CreateReviewResponse godoc
+// @Summary Respond to a review
+// @Description Post the hotel's public response to one of its published reviews. A review has at most one response. Requires a hotel key, which can only respond to its own hotel's reviews, or the admin scope.
+// @ID create-review-response
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param response body dto.ReviewResponseRequestBody true "Response"
+// @Success 201 {object} response.HTTPResponse{content=models.ReviewResponse}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /reviews/{id}/response [post]
+func (h *ReviewHandler) CreateReviewResponse(w http.ResponseWriter, r *http.Request) {
+	reviewID, hotelID, body, ok := decodeReviewResponseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	reviewResponse, errDetails := h.service.CreateReviewResponse(reviewID, hotelID, body)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: reviewResponse,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusCreated, resp)
+}
+
+// UpdateReviewResponse godoc
+// @Summary Edit a response to a review
+// @Description Edit the response a hotel posted through the API. Responses imported from a provider can only be changed on the provider. Requires a hotel key for the review's hotel or the admin scope.
+// @ID update-review-response
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param response body dto.ReviewResponseRequestBody true "Response"
+// @Success 200 {object} response.HTTPResponse{content=models.ReviewResponse}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /reviews/{id}/response [put]
+func (h *ReviewHandler) UpdateReviewResponse(w http.ResponseWriter, r *http.Request) {
+	reviewID, hotelID, body, ok := decodeReviewResponseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	reviewResponse, errDetails := h.service.UpdateReviewResponse(reviewID, hotelID, body)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: reviewResponse,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// DeleteReviewResponse godoc
+// @Summary Delete a response to a review
+// @Description Delete the response a hotel posted through the API. Requires a hotel key for the review's hotel or the admin scope.
+// @ID delete-review-response
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 204
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /reviews/{id}/response [delete]
+func (h *ReviewHandler) DeleteReviewResponse(w http.ResponseWriter, r *http.Request) {
+	reviewID, hotelID, ok := reviewResponseTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if errDetails := h.service.DeleteReviewResponse(reviewID, hotelID); errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reviewResponseTarget reads the review a response request is for and the
+// hotel the caller responds as. Callers that can not respond get a 403. On
+// failure it writes the error response and returns false.
+func reviewResponseTarget(w http.ResponseWriter, r *http.Request) (reviewID, hotelID uint, ok bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Review ID")
+		return 0, 0, false
+	}
+
+	hotelID, ok = middleware.RespondingHotel(r.Context())
+	if !ok {
+		response.WriteError(w, r, http.StatusForbidden, "Responding to reviews requires a hotel key or the admin scope")
+		return 0, 0, false
+	}
+
+	return uint(id), hotelID, true
+}
+
+// decodeReviewResponseRequest is reviewResponseTarget for requests that also
+// carry a response body.
+func decodeReviewResponseRequest(w http.ResponseWriter, r *http.Request) (reviewID, hotelID uint, body *dto.ReviewResponseRequestBody, ok bool) {
+	reviewID, hotelID, ok = reviewResponseTarget(w, r)
+	if !ok {
+		return 0, 0, nil, false
+	}
+
+	body = &dto.ReviewResponseRequestBody{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return 0, 0, nil, false
+	}
+
+	return reviewID, hotelID, body, true
+}
@@ -0,0 +1,251 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/handler"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service/mock"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewHandler_GetReviewResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name           string
+		review         *models.Review
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			review: &models.Review{ID: 1, Status: models.ReviewStatusPublished, Response: &models.ReviewResponse{
+				ID: 3, ReviewID: 1, ResponderName: "Front Office", Body: "Thank you", UpdatedAt: time.Date(2025, 4, 12, 0, 0, 0, 0, time.UTC),
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "no_response",
+			review:         &models.Review{ID: 1, Status: models.ReviewStatusPublished},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unpublished_review",
+			review:         &models.Review{ID: 1, Status: models.ReviewStatusPending, Response: &models.ReviewResponse{ID: 3}},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := mock.NewMockReviewService(ctrl)
+			log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+			reviewHandler := handler.NewReviewHandler(mockService, log)
+
+			mockService.EXPECT().GetReviewByID(uint(1), dto.RelationResponse).Return(tt.review, nil)
+
+			req, err := http.NewRequest("GET", "/reviews/1/response", nil)
+			assert.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			// Act
+			rr := serveAs("secret", reviewHandler.GetReviewResponse, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, rr.Body.String(), `"responder_name":"Front Office"`)
+				assert.Equal(t, "Sat, 12 Apr 2025 00:00:00 GMT", rr.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
+
+func TestReviewHandler_CreateReviewResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := `{"responder_name":"Front Office","body":"Thank you for staying with us."}`
+
+	t.Run("as_hotel", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		expectedBody := &dto.ReviewResponseRequestBody{ResponderName: "Front Office", Body: "Thank you for staying with us."}
+		mockService.EXPECT().CreateReviewResponse(uint(1), uint(1), expectedBody).
+			Return(&models.ReviewResponse{ID: 3, ReviewID: 1, HotelID: 1, Source: models.ReviewResponseSourceAPI}, nil)
+
+		req, err := http.NewRequest("POST", "/reviews/1/response", bytes.NewBufferString(body))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("hotel-secret", reviewHandler.CreateReviewResponse, req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"source":"api"`)
+	})
+
+	t.Run("as_admin", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().CreateReviewResponse(uint(1), uint(0), gomock.Any()).Return(&models.ReviewResponse{ID: 3}, nil)
+
+		req, err := http.NewRequest("POST", "/reviews/1/response", bytes.NewBufferString(body))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("admin-secret", reviewHandler.CreateReviewResponse, req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("POST", "/reviews/1/response", bytes.NewBufferString(body))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("secret", reviewHandler.CreateReviewResponse, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().CreateReviewResponse(uint(1), uint(1), gomock.Any()).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusConflict,
+			Message: "This review already has a response",
+			Error:   errors.New("review 1 already has a response"),
+		})
+
+		req, err := http.NewRequest("POST", "/reviews/1/response", bytes.NewBufferString(body))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("hotel-secret", reviewHandler.CreateReviewResponse, req)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("invalid_body", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("POST", "/reviews/1/response", bytes.NewBufferString("{"))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("hotel-secret", reviewHandler.CreateReviewResponse, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestReviewHandler_UpdateReviewResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().UpdateReviewResponse(uint(1), uint(1), &dto.ReviewResponseRequestBody{ResponderName: "GM", Body: "Sorry"}).
+			Return(&models.ReviewResponse{ID: 3, ResponderName: "GM", Body: "Sorry"}, nil)
+
+		req, err := http.NewRequest("PUT", "/reviews/1/response", bytes.NewBufferString(`{"responder_name":"GM","body":"Sorry"}`))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("hotel-secret", reviewHandler.UpdateReviewResponse, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"body":"Sorry"`)
+	})
+}
+
+func TestReviewHandler_DeleteReviewResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().DeleteReviewResponse(uint(1), uint(1)).Return(nil)
+
+		req, err := http.NewRequest("DELETE", "/reviews/1/response", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("hotel-secret", reviewHandler.DeleteReviewResponse, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("imported_response", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().DeleteReviewResponse(uint(1), uint(1)).Return(&response.ErrorDetails{
+			Code:    http.StatusConflict,
+			Message: "Responses imported from a provider can only be changed on the provider",
+		})
+
+		req, err := http.NewRequest("DELETE", "/reviews/1/response", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("hotel-secret", reviewHandler.DeleteReviewResponse, req)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
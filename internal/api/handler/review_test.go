@@ -1,15 +1,20 @@
 package handler_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
 	"github.com/kirananto/review-system/internal/api/handler"
 	"github.com/kirananto/review-system/internal/api/response"
 	"github.com/kirananto/review-system/internal/api/service/mock"
@@ -33,6 +38,7 @@ func TestReviewHandler_GetReview(t *testing.T) {
 			Comment:    "Great hotel!",
 			Rating:     5,
 			ReviewDate: time.Now(),
+			Status:     models.ReviewStatusPublished,
 		}
 
 		mockService.EXPECT().GetReviewByID(uint(1)).Return(expectedReview, nil)
@@ -91,4 +97,772 @@ func TestReviewHandler_GetReview(t *testing.T) {
 		// Assert
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
+
+	t.Run("include_and_fields", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		expectedReview := &models.Review{
+			ID:         1,
+			ProviderID: 2,
+			Comment:    "Great hotel!",
+			Rating:     9,
+			Status:     models.ReviewStatusPublished,
+			Provider:   &models.Provider{ID: 2, Name: "Agoda"},
+		}
+
+		mockService.EXPECT().GetReviewByID(uint(1), "provider").Return(expectedReview, nil)
+
+		req, err := http.NewRequest("GET", "/reviews/1?include=provider&fields=id,rating", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.GetReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Content map[string]json.RawMessage `json:"content"`
+		}
+		err = json.Unmarshal(rr.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Len(t, resp.Content, 3)
+		assert.JSONEq(t, "1", string(resp.Content["id"]))
+		assert.JSONEq(t, "9", string(resp.Content["rating"]))
+		assert.Contains(t, string(resp.Content["provider"]), `"name":"Agoda"`)
+	})
+
+	t.Run("invalid_include", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("GET", "/reviews/1?include=author", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.GetReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "include must be one of provider, hotel")
+	})
+}
+
+func TestReviewHandler_CreateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		newReview := &dto.ReviewRequestBody{
+			ID:         1,
+			ProviderID: 1,
+			HotelID:    1,
+			Rating:     8,
+			ReviewDate: time.Now(),
+		}
+
+		mockService.EXPECT().CreateReview(gomock.Any()).Return(&models.Review{ID: 1, Rating: 8}, nil)
+
+		body, err := json.Marshal(newReview)
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest("POST", "/reviews", bytes.NewReader(body))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.CreateReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("invalid_body", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("POST", "/reviews", bytes.NewReader([]byte("not json")))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.CreateReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().CreateReview(gomock.Any()).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusConflict,
+			Message: "Review already exists",
+			Error:   errors.New("exists"),
+		})
+
+		req, err := http.NewRequest("POST", "/reviews", bytes.NewReader([]byte(`{"id":1}`)))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.CreateReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestReviewHandler_UpdateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().UpdateReview(uint(1), gomock.Any()).Return(&models.Review{ID: 1, Rating: 9}, nil)
+
+		req, err := http.NewRequest("PUT", "/reviews/1", bytes.NewReader([]byte(`{"provider_id":1,"hotel_id":1,"rating":9}`)))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.UpdateReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestReviewHandler_PatchReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().PatchReview(uint(1), gomock.Any()).DoAndReturn(
+			func(id uint, patch *dto.ReviewPatchBody) (*models.Review, *response.ErrorDetails) {
+				assert.NotNil(t, patch.Comment)
+				assert.Nil(t, patch.Rating)
+				return &models.Review{ID: id, Comment: *patch.Comment}, nil
+			})
+
+		req, err := http.NewRequest("PATCH", "/reviews/1", bytes.NewReader([]byte(`{"comment":"Corrected"}`)))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.PatchReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("validation_error", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().PatchReview(uint(1), gomock.Any()).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusBadRequest,
+			Message: "rating must be between 0 and 10",
+			Error:   errors.New("invalid"),
+		})
+
+		req, err := http.NewRequest("PATCH", "/reviews/1", bytes.NewReader([]byte(`{"rating":11}`)))
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.PatchReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestReviewHandler_DeleteReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().DeleteReview(uint(1)).Return(nil)
+
+		req, err := http.NewRequest("DELETE", "/reviews/1", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.DeleteReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+}
+
+func TestReviewHandler_RestoreReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().RestoreReview(uint(1)).Return(&models.Review{ID: 1, Rating: 8}, nil)
+
+		req, err := http.NewRequest("POST", "/reviews/1/restore", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.RestoreReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("invalid_id", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("POST", "/reviews/abc/restore", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "abc"})
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.RestoreReview(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestReviewHandler_GetReviewsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("filters_and_sort", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().GetReviewsList(gomock.Any()).DoAndReturn(func(params *dto.ReviewQueryParams) ([]*models.Review, int, *response.ErrorDetails) {
+			assert.Equal(t, 7.5, *params.MinRating)
+			assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), params.ReviewDateFrom)
+			assert.Equal(t, "Solo traveler", params.TravelerType)
+			assert.True(t, *params.HasComment)
+			assert.Equal(t, "-rating", params.Sort)
+			return []*models.Review{{ID: 1}}, 30, nil
+		})
+
+		req, err := http.NewRequest("GET", "/reviews?min_rating=7.5&review_date_from=2025-04-01&traveler_type=Solo+traveler&has_comment=true&sort=-rating&limit=10", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.GetReviewsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Content response.HTTPResponseContent `json:"content"`
+		}
+		err = json.Unmarshal(rr.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.NotNil(t, resp.Content.Next)
+		assert.Contains(t, *resp.Content.Next, "sort=-rating")
+		assert.Contains(t, *resp.Content.Next, "min_rating=7.5")
+		assert.Contains(t, *resp.Content.Next, "offset=10")
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().GetReviewsList(gomock.Any()).DoAndReturn(func(params *dto.ReviewQueryParams) ([]*models.Review, int, *response.ErrorDetails) {
+			assert.Equal(t, []string{"hotel"}, params.IncludeList())
+			return []*models.Review{
+				{ID: 1, HotelID: 3, Rating: 8, Hotel: &models.Hotel{ID: 3, HotelName: "Grand"}},
+				{ID: 2, HotelID: 3, Rating: 6, Hotel: &models.Hotel{ID: 3, HotelName: "Grand"}},
+			}, 2, nil
+		})
+
+		req, err := http.NewRequest("GET", "/reviews?include=hotel&fields=id,+rating", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.GetReviewsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Content struct {
+				Count   int                          `json:"count"`
+				Results []map[string]json.RawMessage `json:"results"`
+			} `json:"content"`
+		}
+		err = json.Unmarshal(rr.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Content.Count)
+		assert.Len(t, resp.Content.Results, 2)
+		for _, result := range resp.Content.Results {
+			assert.Len(t, result, 3)
+			assert.Contains(t, result, "id")
+			assert.Contains(t, result, "rating")
+			assert.Contains(t, string(result["hotel"]), `"name":"Grand"`)
+		}
+	})
+
+	t.Run("unknown_field", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("GET", "/reviews?fields=id,search_vector", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.GetReviewsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `unknown field \"search_vector\"`)
+	})
+
+	t.Run("invalid_date", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("GET", "/reviews?review_date_to=yesterday", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.GetReviewsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("validation_error", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().GetReviewsList(gomock.Any()).Return(nil, 0, &response.ErrorDetails{
+			Code:    http.StatusBadRequest,
+			Message: "sort must be one of review_date, rating, created_at, optionally prefixed with -",
+			Error:   errors.New("invalid sort"),
+		})
+
+		req, err := http.NewRequest("GET", "/reviews?sort=comment", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.GetReviewsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("include_deleted_forbidden", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		req, err := http.NewRequest("GET", "/reviews?include_deleted=true", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("secret", reviewHandler.GetReviewsList, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestReviewHandler_SearchReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().GetReviewsList(gomock.Any()).Times(0)
+		mockService.EXPECT().SearchReviews(gomock.Any()).DoAndReturn(func(params *dto.ReviewQueryParams) ([]*dto.ReviewSearchResult, int, *response.ErrorDetails) {
+			assert.Equal(t, "noisy breakfast", params.Q)
+			assert.Equal(t, uint(10984), params.HotelID)
+			return []*dto.ReviewSearchResult{
+				{
+					Review:  models.Review{ID: 1, HotelID: 10984},
+					Rank:    0.6,
+					Snippet: "the room was <mark>noisy</mark>",
+				},
+			}, 1, nil
+		})
+
+		req, err := http.NewRequest("GET", "/search/reviews?q=noisy+breakfast&hotel_id=10984", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.SearchReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Content struct {
+				Count   int                       `json:"count"`
+				Results []*dto.ReviewSearchResult `json:"results"`
+			} `json:"content"`
+		}
+		err = json.Unmarshal(rr.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.Content.Count)
+		assert.Equal(t, uint(1), resp.Content.Results[0].ID)
+		assert.Equal(t, "the room was <mark>noisy</mark>", resp.Content.Results[0].Snippet)
+	})
+
+	t.Run("missing_query", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().SearchReviews(gomock.Any()).Return(nil, 0, &response.ErrorDetails{
+			Code:    http.StatusBadRequest,
+			Message: "q is required",
+			Error:   errors.New("q is required"),
+		})
+
+		req, err := http.NewRequest("GET", "/search/reviews", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.SearchReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestReviewHandler_GetReviewsList_CursorPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		next := "next-cursor"
+		prev := "prev-cursor"
+		mockService.EXPECT().GetReviewsList(gomock.Any()).Times(0)
+		mockService.EXPECT().GetReviewsPage(gomock.Any()).DoAndReturn(func(params *dto.ReviewQueryParams) (*dto.ReviewPage, *response.ErrorDetails) {
+			assert.Equal(t, "current-cursor", params.Cursor)
+			assert.Equal(t, "created_at", params.Sort)
+			return &dto.ReviewPage{
+				Reviews: []*models.Review{{ID: 1}, {ID: 2}},
+				Total:   50,
+				Next:    &next,
+				Prev:    &prev,
+			}, nil
+		})
+
+		req, err := http.NewRequest("GET", "/reviews?cursor=current-cursor&sort=created_at&limit=2", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.GetReviewsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Content response.HTTPResponseContent `json:"content"`
+		}
+		err = json.Unmarshal(rr.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, 50, resp.Content.Count)
+		assert.Equal(t, next, *resp.Content.NextCursor)
+		assert.Equal(t, prev, *resp.Content.PrevCursor)
+		assert.Contains(t, *resp.Content.Next, "cursor=next-cursor")
+		assert.Contains(t, *resp.Content.Next, "sort=created_at")
+		assert.NotContains(t, *resp.Content.Next, "offset=")
+	})
+
+	t.Run("invalid_cursor", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().GetReviewsPage(gomock.Any()).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid cursor",
+			Error:   dto.ErrInvalidCursor,
+		})
+
+		req, err := http.NewRequest("GET", "/reviews?pagination=cursor&cursor=garbage", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.GetReviewsList(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestReviewHandler_ExportReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviews := []*models.Review{
+		{ID: 1, ProviderID: 2, HotelID: 3, Rating: 8.5, Comment: "Quiet, \"spotless\" rooms", Lang: "en", ReviewDate: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, ProviderID: 2, HotelID: 3, Rating: 6, Lang: "en", ReviewDate: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	streamReviews := func(ctx context.Context, params *dto.ReviewExportQueryParams, write func(*models.Review) error) *response.ErrorDetails {
+		for _, review := range reviews {
+			if err := write(review); err != nil {
+				return &response.ErrorDetails{Code: http.StatusInternalServerError, Message: "Internal server error", Error: err}
+			}
+		}
+		return nil
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().ExportReviews(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, params *dto.ReviewExportQueryParams, write func(*models.Review) error) *response.ErrorDetails {
+				assert.Equal(t, dto.ExportFormatNDJSON, params.Format)
+				assert.Equal(t, uint(3), params.HotelID)
+				return streamReviews(ctx, params, write)
+			})
+
+		req, err := http.NewRequest("GET", "/exports/reviews?hotel_id=3", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.ExportReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		assert.Len(t, lines, 2)
+		var first models.Review
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.Equal(t, uint(1), first.ID)
+		assert.Equal(t, 8.5, first.Rating)
+	})
+
+	t.Run("csv_from_accept_header", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().ExportReviews(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, params *dto.ReviewExportQueryParams, write func(*models.Review) error) *response.ErrorDetails {
+				assert.Equal(t, dto.ExportFormatCSV, params.Format)
+				return streamReviews(ctx, params, write)
+			})
+
+		req, err := http.NewRequest("GET", "/exports/reviews", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Accept", "application/xml;q=0.9, text/csv")
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.ExportReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), `filename="reviews.csv"`)
+
+		records, err := csv.NewReader(rr.Body).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, records, 3)
+		assert.Equal(t, "id", records[0][0])
+		assert.Equal(t, []string{"1", "2", "3", "8.5"}, records[1][:4])
+		assert.Equal(t, `Quiet, "spotless" rooms`, records[1][5])
+		assert.Equal(t, "2025-04-02T00:00:00Z", records[2][7])
+	})
+
+	t.Run("csv_formulas", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().ExportReviews(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, params *dto.ReviewExportQueryParams, write func(*models.Review) error) *response.ErrorDetails {
+				write(&models.Review{ID: 1, Title: `=HYPERLINK("http://evil.example","click")`, Comment: "-2+3", Lang: "en"})
+				write(&models.Review{ID: 2, Title: "@SUM(A1)", Comment: "\tcmd", ReviewerInfo: json.RawMessage(`{"countryName":"Vietnam"}`)})
+				write(&models.Review{ID: 3, Title: "Great value", Comment: "Staff were friendly - would return"})
+				return nil
+			})
+
+		req, err := http.NewRequest("GET", "/exports/reviews?format=csv", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.ExportReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		records, err := csv.NewReader(rr.Body).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, records, 4)
+		assert.Equal(t, `'=HYPERLINK("http://evil.example","click")`, records[1][4])
+		assert.Equal(t, "'-2+3", records[1][5])
+		assert.Equal(t, "'@SUM(A1)", records[2][4])
+		assert.Equal(t, "'\tcmd", records[2][5])
+		assert.Equal(t, `{"countryName":"Vietnam"}`, records[2][8])
+		assert.Equal(t, "Great value", records[3][4])
+		assert.Equal(t, "Staff were friendly - would return", records[3][5])
+	})
+
+	t.Run("empty_csv", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().ExportReviews(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		req, err := http.NewRequest("GET", "/exports/reviews?format=csv", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.ExportReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "id,provider_id,hotel_id,rating,title,comment,lang,review_date,reviewer_info,updated_at,created_at\n", rr.Body.String())
+	})
+
+	t.Run("validation_error", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockReviewService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		reviewHandler := handler.NewReviewHandler(mockService, log)
+
+		mockService.EXPECT().ExportReviews(gomock.Any(), gomock.Any(), gomock.Any()).Return(&response.ErrorDetails{
+			Code:    http.StatusBadRequest,
+			Message: "format must be ndjson or csv",
+			Error:   errors.New("format must be ndjson or csv"),
+		})
+
+		req, err := http.NewRequest("GET", "/exports/reviews?format=xlsx", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		// Act
+		reviewHandler.ExportReviews(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, response.ProblemContentType, rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "format must be ndjson or csv")
+	})
 }
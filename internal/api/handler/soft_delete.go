@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+)
+
+// checkIncludeDeleted answers 403 when a caller without the admin scope asks
+// for soft-deleted entities, and returns false.
+func checkIncludeDeleted(w http.ResponseWriter, r *http.Request, includeDeleted bool) bool {
+	if !includeDeleted || middleware.IsAdmin(r.Context()) {
+		return true
+	}
+	response.WriteError(w, r, http.StatusForbidden, "Deleted entities are only visible with the admin scope")
+	return false
+}
@@ -0,0 +1,323 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service"
+	"github.com/kirananto/review-system/internal/api/utils"
+	"github.com/kirananto/review-system/internal/logger"
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+	logger  *logger.Logger
+	decoder *schema.Decoder
+}
+
+func NewWebhookHandler(service service.WebhookService, logger *logger.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+		logger:  logger,
+		decoder: schema.NewDecoder(),
+	}
+}
+
+// GetWebhookSubscriptionsList godoc
+// @Summary Get a list of webhook subscriptions
+// @Description Get a list of webhook subscriptions with optional filters. Requires the admin scope.
+// @ID get-webhook-subscriptions-list
+// @Produce json
+// @Param event_type query string false "Only subscriptions to this event type"
+// @Param hotel_id query int false "Only subscriptions restricted to this hotel"
+// @Param provider_id query int false "Only subscriptions restricted to this provider"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.WebhookSubscription}}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetWebhookSubscriptionsList(w http.ResponseWriter, r *http.Request) {
+	// Initialize with default values
+	queryParams := &dto.WebhookSubscriptionsQueryParams{
+		Limit:  20,
+		Offset: 0,
+	}
+
+	// Parse query parameters automatically
+	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+
+	subscriptions, total, errorDetails := h.service.GetWebhookSubscriptionsList(queryParams)
+	if errorDetails != nil {
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	// Get pagination links
+	prevURL, nextURL := utils.GetPaginationLinks(r, queryParams.Offset, queryParams.Limit, total)
+
+	// Create success response with pagination
+	resp := &response.HTTPResponse{
+		Content: &response.HTTPResponseContent{
+			Count:    total,
+			Previous: prevURL,
+			Next:     nextURL,
+			Results:  subscriptions,
+		},
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// GetWebhookSubscription godoc
+// @Summary Get a webhook subscription by ID
+// @Description Get a webhook subscription by ID. Its secret is not returned. Requires the admin scope.
+// @ID get-webhook-subscription-by-id
+// @Produce json
+// @Param id path int true "Webhook subscription ID"
+// @Success 200 {object} response.HTTPResponse{content=models.WebhookSubscription}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Webhook Subscription ID")
+		return
+	}
+
+	subscription, errDetails := h.service.GetWebhookSubscriptionByID(uint(id))
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: subscription,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// CreateWebhookSubscription godoc
+// @Summary Create a webhook subscription
+// @Description Subscribe an endpoint to event types, optionally restricted to one hotel or provider. A signing secret is generated unless one is given; it is only returned by this request. Requires the admin scope.
+// @ID create-webhook-subscription
+// @Accept json
+// @Produce json
+// @Param subscription body dto.WebhookSubscriptionRequestBody true "Webhook subscription object"
+// @Success 201 {object} response.HTTPResponse{content=dto.WebhookSubscriptionWithSecret}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	var body dto.WebhookSubscriptionRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+
+	subscription, errDetails := h.service.CreateWebhookSubscription(&body)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: subscription,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusCreated, resp)
+}
+
+// UpdateWebhookSubscription godoc
+// @Summary Update a webhook subscription
+// @Description Replace a webhook subscription. Its secret is kept unless a new one is given. Requires the admin scope.
+// @ID update-webhook-subscription
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook subscription ID"
+// @Param subscription body dto.WebhookSubscriptionRequestBody true "Webhook subscription object"
+// @Success 200 {object} response.HTTPResponse{content=dto.WebhookSubscriptionWithSecret}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Webhook Subscription ID")
+		return
+	}
+
+	var body dto.WebhookSubscriptionRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid request body", err))
+		return
+	}
+
+	subscription, errDetails := h.service.UpdateWebhookSubscription(uint(id), &body)
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: subscription,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// DeleteWebhookSubscription godoc
+// @Summary Delete a webhook subscription
+// @Description Delete a webhook subscription along with its delivery log. Requires the admin scope.
+// @ID delete-webhook-subscription
+// @Produce json
+// @Param id path int true "Webhook subscription ID"
+// @Success 204
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Webhook Subscription ID")
+		return
+	}
+
+	errDetails := h.service.DeleteWebhookSubscription(uint(id))
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetWebhookDeliveriesList godoc
+// @Summary Get the webhook delivery log
+// @Description Get webhook deliveries, newest first, with optional filters. Requires the admin scope.
+// @ID get-webhook-deliveries-list
+// @Produce json
+// @Param subscription_id query int false "Only deliveries to this subscription"
+// @Param event_type query string false "Only deliveries of this event type"
+// @Param event_id query string false "Only deliveries of this event"
+// @Param status query string false "Only deliveries with this status" Enums(pending, succeeded, dead)
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.HTTPResponse{content=response.HTTPResponseContent{results=[]models.WebhookDelivery}}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Router /webhooks/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveriesList(w http.ResponseWriter, r *http.Request) {
+	// Initialize with default values
+	queryParams := &dto.WebhookDeliveriesQueryParams{
+		Limit:  20,
+		Offset: 0,
+	}
+
+	// Parse query parameters automatically
+	if err := h.decoder.Decode(queryParams, r.URL.Query()); err != nil {
+		response.WriteErrorDetails(w, r, response.DecodeErrorDetails("Invalid query parameters", err))
+		return
+	}
+
+	deliveries, total, errorDetails := h.service.GetWebhookDeliveriesList(queryParams)
+	if errorDetails != nil {
+		writeServiceError(w, r, h.logger, errorDetails)
+		return
+	}
+
+	// Get pagination links
+	prevURL, nextURL := utils.GetPaginationLinks(r, queryParams.Offset, queryParams.Limit, total)
+
+	// Create success response with pagination
+	resp := &response.HTTPResponse{
+		Content: &response.HTTPResponseContent{
+			Count:    total,
+			Previous: prevURL,
+			Next:     nextURL,
+			Results:  deliveries,
+		},
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// GetWebhookDelivery godoc
+// @Summary Get a webhook delivery by ID
+// @Description Get a webhook delivery by ID, along with its payload and the outcome of its last attempt. Requires the admin scope.
+// @ID get-webhook-delivery-by-id
+// @Produce json
+// @Param id path int true "Webhook delivery ID"
+// @Success 200 {object} response.HTTPResponse{content=models.WebhookDelivery}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Router /webhooks/deliveries/{id} [get]
+func (h *WebhookHandler) GetWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Webhook Delivery ID")
+		return
+	}
+
+	delivery, errDetails := h.service.GetWebhookDeliveryByID(uint(id))
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: delivery,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusOK, resp)
+}
+
+// ReplayWebhookDelivery godoc
+// @Summary Replay a webhook delivery
+// @Description Send the event of a delivery to its subscription again, as a new delivery that is attempted right away and retried if that attempt fails. Requires the admin scope.
+// @ID replay-webhook-delivery
+// @Produce json
+// @Param id path int true "Webhook delivery ID"
+// @Success 201 {object} response.HTTPResponse{content=models.WebhookDelivery}
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Router /webhooks/deliveries/{id}/replay [post]
+func (h *WebhookHandler) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.WriteError(w, r, http.StatusBadRequest, "Invalid Webhook Delivery ID")
+		return
+	}
+
+	delivery, errDetails := h.service.ReplayWebhookDelivery(r.Context(), uint(id))
+	if errDetails != nil {
+		writeServiceError(w, r, h.logger, errDetails)
+		return
+	}
+
+	resp := &response.HTTPResponse{
+		Content: delivery,
+	}
+
+	response.WriteHTTPResponse(w, http.StatusCreated, resp)
+}
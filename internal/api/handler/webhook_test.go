@@ -0,0 +1,221 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/handler"
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service/mock"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookHandler_CreateWebhookSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockWebhookService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		webhookHandler := handler.NewWebhookHandler(mockService, log)
+
+		mockService.EXPECT().CreateWebhookSubscription(gomock.Any()).DoAndReturn(
+			func(body *dto.WebhookSubscriptionRequestBody) (*dto.WebhookSubscriptionWithSecret, *response.ErrorDetails) {
+				assert.Equal(t, "https://example.com/hooks", body.URL)
+				assert.Equal(t, []string{"review.created"}, body.EventTypes)
+				assert.Equal(t, uint(7), body.HotelID)
+				subscription := &models.WebhookSubscription{ID: 1, URL: body.URL, EventTypes: body.EventTypes, HotelID: body.HotelID, Secret: "whsec_abc"}
+				return &dto.WebhookSubscriptionWithSecret{WebhookSubscription: subscription, Secret: subscription.Secret}, nil
+			})
+
+		req, err := http.NewRequest("POST", "/webhooks", bytes.NewBufferString(`{"url":"https://example.com/hooks","event_types":["review.created"],"hotel_id":7}`))
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(webhookHandler.CreateWebhookSubscription), req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"secret":"whsec_abc"`)
+		assert.Contains(t, rr.Body.String(), `"hotel_id":7`)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockWebhookService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		webhookHandler := handler.NewWebhookHandler(mockService, log)
+
+		mockService.EXPECT().CreateWebhookSubscription(gomock.Any()).Return(nil,
+			response.ValidationErrorDetails("event_types", "event_types must not be empty", errors.New("event_types must not be empty")))
+
+		req, err := http.NewRequest("POST", "/webhooks", bytes.NewBufferString(`{"url":"https://example.com/hooks"}`))
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(webhookHandler.CreateWebhookSubscription), req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "event_types must not be empty")
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockWebhookService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		webhookHandler := handler.NewWebhookHandler(mockService, log)
+
+		req, err := http.NewRequest("POST", "/webhooks", bytes.NewBufferString(`{"url":"https://example.com/hooks","event_types":["review.created"]}`))
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("secret", middleware.RequireAdmin(webhookHandler.CreateWebhookSubscription), req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestWebhookHandler_GetWebhookSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("secret is not returned", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockWebhookService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		webhookHandler := handler.NewWebhookHandler(mockService, log)
+
+		subscription := &models.WebhookSubscription{ID: 1, URL: "https://example.com/hooks", EventTypes: []string{"review.created"}, Secret: "whsec_abc"}
+		mockService.EXPECT().GetWebhookSubscriptionByID(uint(1)).Return(subscription, nil)
+
+		req, err := http.NewRequest("GET", "/webhooks/1", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(webhookHandler.GetWebhookSubscription), req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"url":"https://example.com/hooks"`)
+		assert.NotContains(t, rr.Body.String(), "whsec_abc")
+	})
+}
+
+func TestWebhookHandler_DeleteWebhookSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockWebhookService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		webhookHandler := handler.NewWebhookHandler(mockService, log)
+
+		mockService.EXPECT().DeleteWebhookSubscription(uint(1)).Return(nil)
+
+		req, err := http.NewRequest("DELETE", "/webhooks/1", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(webhookHandler.DeleteWebhookSubscription), req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+}
+
+func TestWebhookHandler_GetWebhookDeliveriesList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockWebhookService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		webhookHandler := handler.NewWebhookHandler(mockService, log)
+
+		deliveries := []*models.WebhookDelivery{{ID: 3, SubscriptionID: 1, EventType: "review.created", Status: models.WebhookDeliveryDead, Attempts: 8}}
+		mockService.EXPECT().GetWebhookDeliveriesList(&dto.WebhookDeliveriesQueryParams{Limit: 20, SubscriptionID: 1, Status: "dead"}).Return(deliveries, 1, nil)
+
+		req, err := http.NewRequest("GET", "/webhooks/deliveries?subscription_id=1&status=dead", nil)
+		assert.NoError(t, err)
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(webhookHandler.GetWebhookDeliveriesList), req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"count":1`)
+		assert.Contains(t, rr.Body.String(), `"status":"dead"`)
+	})
+}
+
+func TestWebhookHandler_ReplayWebhookDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockWebhookService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		webhookHandler := handler.NewWebhookHandler(mockService, log)
+
+		replayOf := uint(3)
+		replayed := &models.WebhookDelivery{ID: 4, SubscriptionID: 1, Status: models.WebhookDeliverySucceeded, Attempts: 1, ResponseStatus: 200, ReplayOf: &replayOf}
+		mockService.EXPECT().ReplayWebhookDelivery(gomock.Any(), uint(3)).DoAndReturn(
+			func(ctx context.Context, id uint) (*models.WebhookDelivery, *response.ErrorDetails) {
+				return replayed, nil
+			})
+
+		req, err := http.NewRequest("POST", "/webhooks/deliveries/3/replay", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "3"})
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(webhookHandler.ReplayWebhookDelivery), req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"replay_of":3`)
+		assert.Contains(t, rr.Body.String(), `"status":"succeeded"`)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// Arrange
+		mockService := mock.NewMockWebhookService(ctrl)
+		log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+		webhookHandler := handler.NewWebhookHandler(mockService, log)
+
+		mockService.EXPECT().ReplayWebhookDelivery(gomock.Any(), uint(9)).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Webhook delivery not found",
+			Error:   errors.New("record not found"),
+		})
+
+		req, err := http.NewRequest("POST", "/webhooks/deliveries/9/replay", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "9"})
+
+		// Act
+		rr := serveAs("admin-secret", middleware.RequireAdmin(webhookHandler.ReplayWebhookDelivery), req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Webhook delivery not found")
+	})
+}
@@ -1,37 +1,186 @@
 package middleware
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
+	"sync/atomic"
 
 	"github.com/kirananto/review-system/internal/api/response"
 )
 
+// Scopes an API key can grant.
+const (
+	// ScopeAdmin allows writing the catalogue and its reviews, moderating
+	// reviews and seeing unpublished ones.
+	ScopeAdmin = "admin"
+	// ScopeRespond allows responding to the reviews of the principal's hotel.
+	// Otherwise its keys only read, like keys without scopes.
+	ScopeRespond = "reviews:respond"
+)
+
+// Principal is the caller an API key identifies.
+type Principal struct {
+	Name   string
+	Scopes []string
+	// HotelID is the hotel a hotel's key acts for, or 0.
+	HotelID uint
+}
+
+// HasScope reports whether the principal was granted scope.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// APIKey is an API key and the principal it identifies, as configured in the
+// API_KEYS environment variable.
+type APIKey struct {
+	Key    string   `json:"key"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// HotelID is the hotel the key acts for, if it is a hotel's key.
+	HotelID uint `json:"hotel_id"`
+}
+
+// scopes lists the scopes an API key can be granted.
+var scopes = []string{ScopeAdmin, ScopeRespond}
+
+// ParseAPIKeys parses the API keys configuration, a JSON array of keys. Empty
+// configuration has no keys.
+func ParseAPIKeys(data string) ([]APIKey, error) {
+	if strings.TrimSpace(data) == "" {
+		return nil, nil
+	}
+
+	var keys []APIKey
+	if err := json.Unmarshal([]byte(data), &keys); err != nil {
+		return nil, fmt.Errorf("invalid API keys: %w", err)
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		if key.Key == "" || key.Name == "" {
+			return nil, fmt.Errorf("API key %d: key and name are required", i)
+		}
+		if seen[key.Key] {
+			return nil, fmt.Errorf("API key %q: the key is configured more than once", key.Name)
+		}
+		seen[key.Key] = true
+		for _, scope := range key.Scopes {
+			if !slices.Contains(scopes, scope) {
+				return nil, fmt.Errorf("API key %q: unknown scope %q", key.Name, scope)
+			}
+		}
+		// Admins respond for any hotel, everyone else for their own
+		if slices.Contains(key.Scopes, ScopeRespond) && !slices.Contains(key.Scopes, ScopeAdmin) && key.HotelID == 0 {
+			return nil, fmt.Errorf("API key %q: the %s scope needs a hotel_id", key.Name, ScopeRespond)
+		}
+	}
+	return keys, nil
+}
+
+// apiKeys maps each configured API key to the principal it identifies.
+var apiKeys atomic.Pointer[map[string]*Principal]
+
+// SetAPIKeys replaces the API keys Auth accepts. No key is accepted until it
+// is called.
+func SetAPIKeys(keys []APIKey) {
+	principals := make(map[string]*Principal, len(keys))
+	for _, key := range keys {
+		principals[key.Key] = &Principal{Name: key.Name, Scopes: key.Scopes, HotelID: key.HotelID}
+	}
+	apiKeys.Store(&principals)
+}
+
+type principalContextKey struct{}
+
+// Authenticate returns the principal an API key identifies.
+func Authenticate(apiKey string) (*Principal, bool) {
+	principals := apiKeys.Load()
+	if principals == nil {
+		return nil, false
+	}
+	principal, ok := (*principals)[apiKey]
+	return principal, ok
+}
+
+// WithPrincipal returns a copy of ctx carrying principal, as Auth attaches it
+// to requests.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal Auth attached to a request.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok
+}
+
+// IsAdmin reports whether the request was made with the admin scope.
+func IsAdmin(ctx context.Context) bool {
+	principal, ok := PrincipalFromContext(ctx)
+	return ok && principal.HasScope(ScopeAdmin)
+}
+
+// RespondingHotel returns the hotel whose reviews the request may respond to.
+// It is 0 for admins, who may respond on behalf of any hotel, and ok is false
+// when the caller may not respond at all.
+func RespondingHotel(ctx context.Context) (hotelID uint, ok bool) {
+	principal, found := PrincipalFromContext(ctx)
+	switch {
+	case !found:
+		return 0, false
+	case principal.HasScope(ScopeAdmin):
+		return 0, true
+	case principal.HasScope(ScopeRespond) && principal.HotelID != 0:
+		return principal.HotelID, true
+	}
+	return 0, false
+}
+
 // Auth is a middleware that checks for a valid API key.
 func Auth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			errResp := response.GetErrorHTTPResponseBody(http.StatusUnauthorized, "Authorization header is required hint: use `Bearer secret`")
-			response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+			unauthorized(w, r, "Authorization header is required")
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			errResp := response.GetErrorHTTPResponseBody(http.StatusUnauthorized, "Invalid Authorization header format hint: use `Bearer secret`")
-			response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+			unauthorized(w, r, "Invalid Authorization header format")
 			return
 		}
 
 		token := parts[1]
-		if token != "secret" {
-
-			errResp := response.GetErrorHTTPResponseBody(http.StatusUnauthorized, "IInvalid API key hint: use `Bearer secret` ")
-			response.WriteHTTPResponse(w, http.StatusBadRequest, errResp)
+		principal, ok := Authenticate(token)
+		if !ok {
+			unauthorized(w, r, "Invalid API key")
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
 	})
 }
+
+// unauthorized answers 401, telling the client which scheme to authenticate with.
+func unauthorized(w http.ResponseWriter, r *http.Request, detail string) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	response.WriteError(w, r, http.StatusUnauthorized, detail)
+}
+
+// RequireAdmin answers 403 to requests without the admin scope. It must run
+// after Auth.
+func RequireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !IsAdmin(r.Context()) {
+			response.WriteError(w, r, http.StatusForbidden, "This endpoint requires the admin scope")
+			return
+		}
+		next(w, r)
+	}
+}
@@ -1,13 +1,29 @@
 package middleware
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
+	"github.com/kirananto/review-system/internal/api/response"
 	"github.com/stretchr/testify/assert"
 )
 
+// testAPIKeys are the API keys the tests call with.
+var testAPIKeys = []APIKey{
+	{Key: "secret", Name: "client"},
+	{Key: "admin-secret", Name: "admin", Scopes: []string{ScopeAdmin}},
+	{Key: "hotel-secret", Name: "hotel", Scopes: []string{ScopeRespond}, HotelID: 1},
+}
+
+func TestMain(m *testing.M) {
+	SetAPIKeys(testAPIKeys)
+	os.Exit(m.Run())
+}
+
 func TestAuthMiddleware(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -26,17 +42,17 @@ func TestAuthMiddleware(t *testing.T) {
 		{
 			name:           "missing auth header",
 			authHeader:     "",
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnauthorized,
 		},
 		{
 			name:           "invalid auth header format",
 			authHeader:     "invalid-format",
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnauthorized,
 		},
 		{
 			name:           "incorrect token",
 			authHeader:     "Bearer wrong-secret",
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnauthorized,
 		},
 	}
 
@@ -51,6 +67,159 @@ func TestAuthMiddleware(t *testing.T) {
 			Auth(handler).ServeHTTP(rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, response.ProblemContentType, rr.Header().Get("Content-Type"))
+				assert.Equal(t, "Bearer", rr.Header().Get("WWW-Authenticate"))
+
+				var problem response.Problem
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+				assert.Equal(t, http.StatusUnauthorized, problem.Status)
+				assert.Equal(t, response.CodeUnauthorized, problem.Code)
+				assert.NotContains(t, problem.Detail, "secret")
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_Principal(t *testing.T) {
+	tests := []struct {
+		authHeader    string
+		expectedName  string
+		expectedAdmin bool
+	}{
+		{authHeader: "Bearer secret", expectedName: "client", expectedAdmin: false},
+		{authHeader: "Bearer admin-secret", expectedName: "admin", expectedAdmin: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expectedName, func(t *testing.T) {
+			var principal *Principal
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, _ = PrincipalFromContext(r.Context())
+				assert.Equal(t, tt.expectedAdmin, IsAdmin(r.Context()))
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Authorization", tt.authHeader)
+			Auth(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.NotNil(t, principal)
+			assert.Equal(t, tt.expectedName, principal.Name)
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	handler := Auth(RequireAdmin(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for authHeader, expectedStatus := range map[string]int{
+		"Bearer secret":       http.StatusForbidden,
+		"Bearer admin-secret": http.StatusOK,
+	} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", authHeader)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, expectedStatus, rr.Code, authHeader)
+	}
+}
+
+func TestRespondingHotel(t *testing.T) {
+	tests := []struct {
+		authHeader      string
+		expectedHotelID uint
+		expectedOK      bool
+	}{
+		{authHeader: "Bearer secret", expectedHotelID: 0, expectedOK: false},
+		{authHeader: "Bearer hotel-secret", expectedHotelID: 1, expectedOK: true},
+		{authHeader: "Bearer admin-secret", expectedHotelID: 0, expectedOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.authHeader, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hotelID, ok := RespondingHotel(r.Context())
+				assert.Equal(t, tt.expectedHotelID, hotelID)
+				assert.Equal(t, tt.expectedOK, ok)
+			})
+
+			req := httptest.NewRequest("POST", "/", nil)
+			req.Header.Set("Authorization", tt.authHeader)
+			Auth(handler).ServeHTTP(httptest.NewRecorder(), req)
+		})
+	}
+
+	t.Run("without principal", func(t *testing.T) {
+		_, ok := RespondingHotel(context.Background())
+		assert.False(t, ok)
+	})
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expected    []APIKey
+		expectedErr string
+	}{
+		{
+			name:     "empty",
+			data:     "",
+			expected: nil,
+		},
+		{
+			name: "valid",
+			data: `[{"key": "k1", "name": "client"}, {"key": "k2", "name": "ops", "scopes": ["admin"]}]`,
+			expected: []APIKey{
+				{Key: "k1", Name: "client"},
+				{Key: "k2", Name: "ops", Scopes: []string{ScopeAdmin}},
+			},
+		},
+		{
+			name:     "hotel key",
+			data:     `[{"key": "k1", "name": "hotel-7", "scopes": ["reviews:respond"], "hotel_id": 7}]`,
+			expected: []APIKey{{Key: "k1", Name: "hotel-7", Scopes: []string{ScopeRespond}, HotelID: 7}},
+		},
+		{
+			name:        "hotel key without hotel",
+			data:        `[{"key": "k1", "name": "hotel", "scopes": ["reviews:respond"]}]`,
+			expectedErr: `API key "hotel": the reviews:respond scope needs a hotel_id`,
+		},
+		{
+			name:        "invalid json",
+			data:        `{"key": "k1"}`,
+			expectedErr: "invalid API keys",
+		},
+		{
+			name:        "missing key",
+			data:        `[{"name": "client"}]`,
+			expectedErr: "API key 0: key and name are required",
+		},
+		{
+			name:        "duplicate key",
+			data:        `[{"key": "k1", "name": "a"}, {"key": "k1", "name": "b"}]`,
+			expectedErr: `API key "b": the key is configured more than once`,
+		},
+		{
+			name:        "unknown scope",
+			data:        `[{"key": "k1", "name": "client", "scopes": ["root"]}]`,
+			expectedErr: `API key "client": unknown scope "root"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseAPIKeys(tt.data)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, keys)
 		})
 	}
 }
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{acceptEncoding: "", expected: ""},
+		{acceptEncoding: "identity", expected: ""},
+		{acceptEncoding: "gzip", expected: "gzip"},
+		{acceptEncoding: "gzip, deflate, br", expected: "br"},
+		{acceptEncoding: "br;q=0.5, gzip;q=0.8", expected: "gzip"},
+		{acceptEncoding: "br;q=0, gzip;q=0", expected: ""},
+		{acceptEncoding: "*", expected: "br"},
+		{acceptEncoding: "br;q=0, *", expected: "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			assert.Equal(t, tt.expected, negotiateEncoding(tt.acceptEncoding))
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	body := strings.Repeat(`{"rating":9,"comment":"Lovely stay"}`, 50)
+	handler := Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, body)
+	}))
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		decode         func(io.Reader) (io.Reader, error)
+	}{
+		{
+			name:           "gzip",
+			acceptEncoding: "gzip",
+			decode: func(r io.Reader) (io.Reader, error) {
+				return gzip.NewReader(r)
+			},
+		},
+		{
+			name:           "br",
+			acceptEncoding: "gzip, br",
+			decode: func(r io.Reader) (io.Reader, error) {
+				return brotli.NewReader(r), nil
+			},
+		},
+		{
+			name:           "identity",
+			acceptEncoding: "",
+			decode: func(r io.Reader) (io.Reader, error) {
+				return r, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/reviews", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+			if tt.acceptEncoding != "" {
+				assert.Equal(t, tt.name, rr.Header().Get("Content-Encoding"))
+				assert.Less(t, rr.Body.Len(), len(body))
+			}
+
+			reader, err := tt.decode(rr.Body)
+			assert.NoError(t, err)
+			decoded, err := io.ReadAll(reader)
+			assert.NoError(t, err)
+			assert.Equal(t, body, string(decoded))
+		})
+	}
+
+	t.Run("no body", func(t *testing.T) {
+		handler := Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		req := httptest.NewRequest(http.MethodDelete, "/reviews/1", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Header().Get("Content-Encoding"))
+		assert.Zero(t, rr.Body.Len())
+	})
+}
+
+func TestConditionalGet_Compressed(t *testing.T) {
+	handler := ConditionalGet(Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"content":{"id":1}}`)
+	})))
+
+	serve := func(acceptEncoding, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/hotels/1", nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	gzipped := serve("gzip", "")
+	plain := serve("", "")
+
+	// Each encoding is a different representation with its own strong ETag
+	assert.NotEqual(t, gzipped.Header().Get("ETag"), plain.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, serve("gzip", gzipped.Header().Get("ETag")).Code)
+	assert.Equal(t, http.StatusOK, serve("", gzipped.Header().Get("ETag")).Code)
+}
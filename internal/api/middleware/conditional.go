@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ConditionalGet gives successful JSON responses to GET and HEAD requests a
+// strong ETag, a hash of the body, and answers requests whose If-None-Match or
+// If-Modified-Since show the client already has that body with 304 Not
+// Modified. Handlers opt into If-Modified-Since by setting Last-Modified.
+// Other responses, such as streamed exports, pass through unbuffered.
+func ConditionalGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &conditionalWriter{ResponseWriter: w}
+		next.ServeHTTP(cw, r)
+		cw.finish(r)
+	})
+}
+
+// conditionalWriter holds back successful JSON responses until the handler
+// returns, so the ETag can be computed over the whole body.
+type conditionalWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	buffering   bool
+	body        bytes.Buffer
+}
+
+func (w *conditionalWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if statusCode == http.StatusOK && isJSONResponse(w.Header()) {
+		w.buffering = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *conditionalWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffering {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush passes flushes through for responses that are not buffered.
+func (w *conditionalWriter) Flush() {
+	if w.buffering {
+		return
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *conditionalWriter) finish(r *http.Request) {
+	if !w.buffering {
+		return
+	}
+
+	header := w.Header()
+	if header.Get("ETag") == "" {
+		sum := sha256.Sum256(w.body.Bytes())
+		header.Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	}
+
+	if notModified(r, header) {
+		header.Del("Content-Type")
+		header.Del("Content-Encoding")
+		header.Del("Content-Length")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(http.StatusOK)
+	w.ResponseWriter.Write(w.body.Bytes())
+}
+
+// notModified evaluates the request's preconditions against the response
+// headers. If-Modified-Since is only considered without If-None-Match.
+func notModified(r *http.Request, header http.Header) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, header.Get("ETag"))
+	}
+
+	ifModifiedSince := r.Header.Get("If-Modified-Since")
+	lastModified := header.Get("Last-Modified")
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// etagMatches reports whether an If-None-Match list contains etag, using the
+// weak comparison RFC 9110 prescribes for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func isJSONResponse(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionalGet(t *testing.T) {
+	updatedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	handler := ConditionalGet(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Last-Modified", updatedAt.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"content":{"id":1}}`))
+	}))
+
+	serve := func(method string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/hotels/1", nil)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	first := serve(http.MethodGet, nil)
+	etag := first.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, `{"content":{"id":1}}`, first.Body.String())
+
+	tests := []struct {
+		name           string
+		header         http.Header
+		expectedStatus int
+	}{
+		{
+			name:           "matching etag",
+			header:         http.Header{"If-None-Match": {`"other", ` + etag}},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "weak form of etag",
+			header:         http.Header{"If-None-Match": {"W/" + etag}},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "stale etag",
+			header:         http.Header{"If-None-Match": {`"other"`}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "not modified since",
+			header:         http.Header{"If-Modified-Since": {updatedAt.Format(http.TimeFormat)}},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "modified since",
+			header:         http.Header{"If-Modified-Since": {updatedAt.Add(-time.Second).Format(http.TimeFormat)}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "etag takes precedence",
+			header: http.Header{
+				"If-None-Match":     {`"other"`},
+				"If-Modified-Since": {updatedAt.Format(http.TimeFormat)},
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serve(http.MethodGet, tt.header)
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, etag, rr.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, rr.Body.String())
+			}
+		})
+	}
+
+	t.Run("other methods", func(t *testing.T) {
+		rr := serve(http.MethodPut, http.Header{"If-None-Match": {etag}})
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("ETag"))
+	})
+}
+
+func TestConditionalGet_Passthrough(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+	}{
+		{name: "error", status: http.StatusNotFound, contentType: "application/json"},
+		{name: "stream", status: http.StatusOK, contentType: "application/x-ndjson"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := ConditionalGet(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				w.Write([]byte("{}\n"))
+				w.(http.Flusher).Flush()
+			}))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Empty(t, rr.Header().Get("ETag"))
+			assert.True(t, rr.Flushed)
+		})
+	}
+}
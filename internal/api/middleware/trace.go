@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+
+	"github.com/kirananto/review-system/internal/api/response"
+)
+
+// traceIDPattern matches the trace IDs accepted from callers. Anything else is
+// replaced, so a trace ID is always safe to log and echo.
+var traceIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// Trace gives every request a trace ID. It keeps the X-Request-Id a caller or
+// API Gateway sent, or generates one, attaches it to the request context and
+// echoes it in the response, so error responses and logs can be matched up.
+func Trace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID := ResolveTraceID(r.Header.Get(response.TraceHeader))
+		w.Header().Set(response.TraceHeader, traceID)
+		next.ServeHTTP(w, r.WithContext(response.WithTraceID(r.Context(), traceID)))
+	})
+}
+
+// ResolveTraceID returns the trace ID a caller sent if it is acceptable, or a
+// newly generated one.
+func ResolveTraceID(traceID string) string {
+	if traceIDPattern.MatchString(traceID) {
+		return traceID
+	}
+	return newTraceID()
+}
+
+func newTraceID() string {
+	b := make([]byte, 16)
+	// crypto/rand never fails on supported platforms
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrace(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		keep      bool
+	}{
+		{name: "generated", requestID: "", keep: false},
+		{name: "kept", requestID: "c0ffee-1234", keep: true},
+		{name: "unsafe replaced", requestID: "abc\r\ninjected: yes", keep: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var traceID string
+			handler := Trace(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				traceID = response.TraceID(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.requestID != "" {
+				req.Header.Set(response.TraceHeader, tt.requestID)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.NotEmpty(t, traceID)
+			assert.Equal(t, traceID, rr.Header().Get(response.TraceHeader))
+			if tt.keep {
+				assert.Equal(t, tt.requestID, traceID)
+			} else {
+				assert.Len(t, traceID, 32)
+			}
+		})
+	}
+}
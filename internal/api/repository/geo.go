@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"math"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// earthRadiusKm is the mean radius of the Earth.
+const earthRadiusKm = 6371.0
+
+// distanceSQL is the great-circle distance in km from a point, bound as lat,
+// lat, lng, to a hotel, by the haversine formula. LEAST keeps rounding from
+// pushing the ASIN argument past 1 for antipodal points.
+const distanceSQL = "2 * 6371.0 * ASIN(LEAST(1, SQRT(" +
+	"POWER(SIN(RADIANS(latitude - ?) / 2), 2) + " +
+	"COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2))))"
+
+// boundingBox is a latitude and longitude range, in degrees, that contains
+// every point within a radius of a center.
+type boundingBox struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+}
+
+// newBoundingBox returns the smallest box around the circle of radiusKm
+// around center. When the circle reaches a pole or crosses the antimeridian
+// the box spans every longitude.
+func newBoundingBox(center dto.GeoPoint, radiusKm float64) boundingBox {
+	angular := radiusKm / earthRadiusKm
+	lat := center.Lat * math.Pi / 180
+	lng := center.Lng * math.Pi / 180
+
+	box := boundingBox{
+		MinLat: lat - angular,
+		MaxLat: lat + angular,
+		MinLng: -math.Pi,
+		MaxLng: math.Pi,
+	}
+	if box.MinLat > -math.Pi/2 && box.MaxLat < math.Pi/2 {
+		deltaLng := math.Asin(math.Sin(angular) / math.Cos(lat))
+		if lng-deltaLng >= -math.Pi && lng+deltaLng <= math.Pi {
+			box.MinLng = lng - deltaLng
+			box.MaxLng = lng + deltaLng
+		}
+	}
+	box.MinLat = math.Max(box.MinLat, -math.Pi/2)
+	box.MaxLat = math.Min(box.MaxLat, math.Pi/2)
+
+	return boundingBox{
+		MinLat: box.MinLat * 180 / math.Pi,
+		MaxLat: box.MaxLat * 180 / math.Pi,
+		MinLng: box.MinLng * 180 / math.Pi,
+		MaxLng: box.MaxLng * 180 / math.Pi,
+	}
+}
+
+// withinRadius restricts a hotels query to hotels within radiusKm of center.
+// The bounding box narrows the rows through the coordinates index before the
+// exact distance is computed.
+func withinRadius(dbQuery *gorm.DB, center dto.GeoPoint, radiusKm float64) *gorm.DB {
+	box := newBoundingBox(center, radiusKm)
+	return dbQuery.
+		Where("latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat).
+		Where("longitude BETWEEN ? AND ?", box.MinLng, box.MaxLng).
+		Where(distanceSQL+" <= ?", center.Lat, center.Lat, center.Lng, radiusKm)
+}
+
+// distanceOrder orders hotels nearest to center first.
+func distanceOrder(center dto.GeoPoint) clause.OrderBy {
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                distanceSQL + ", id",
+		Vars:               []interface{}{center.Lat, center.Lat, center.Lng},
+		WithoutParentheses: true,
+	}}
+}
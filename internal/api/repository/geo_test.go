@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBoundingBox(t *testing.T) {
+	tests := []struct {
+		name     string
+		center   dto.GeoPoint
+		radiusKm float64
+		expected boundingBox
+	}{
+		{
+			name:     "equator",
+			center:   dto.GeoPoint{Lat: 0, Lng: 0},
+			radiusKm: 111.19,
+			expected: boundingBox{MinLat: -1, MaxLat: 1, MinLng: -1, MaxLng: 1},
+		},
+		{
+			name:     "widens with latitude",
+			center:   dto.GeoPoint{Lat: 60, Lng: 10},
+			radiusKm: 111.19,
+			expected: boundingBox{MinLat: 59, MaxLat: 61, MinLng: 7.9999, MaxLng: 12.0001},
+		},
+		{
+			name:     "crosses antimeridian",
+			center:   dto.GeoPoint{Lat: -17.7, Lng: 179.9},
+			radiusKm: 50,
+			expected: boundingBox{MinLat: -18.1497, MaxLat: -17.2503, MinLng: -180, MaxLng: 180},
+		},
+		{
+			name:     "reaches pole",
+			center:   dto.GeoPoint{Lat: 89.9, Lng: 0},
+			radiusKm: 50,
+			expected: boundingBox{MinLat: 89.4503, MaxLat: 90, MinLng: -180, MaxLng: 180},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := newBoundingBox(tt.center, tt.radiusKm)
+			assert.InDelta(t, tt.expected.MinLat, box.MinLat, 0.001)
+			assert.InDelta(t, tt.expected.MaxLat, box.MaxLat, 0.001)
+			assert.InDelta(t, tt.expected.MinLng, box.MinLng, 0.001)
+			assert.InDelta(t, tt.expected.MaxLng, box.MaxLng, 0.001)
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/kirananto/review-system/internal/api/dto"
 	models "github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm"
 )
 
 // GetHotelsList retrieves hotels with pagination and filters
@@ -17,21 +21,42 @@ func (r *reviewRepository) GetHotelsList(queryParams *dto.HotelsQueryParams) ([]
 	if queryParams.Name != "" {
 		dbQuery = dbQuery.Where("hotel_name ILIKE ?", "%"+queryParams.Name+"%")
 	}
+	if queryParams.ProviderID != 0 {
+		dbQuery = dbQuery.Where("id IN (?)", r.db.Model(&models.ProviderHotel{}).Select("hotel_id").Where("provider_id = ?", queryParams.ProviderID))
+	}
+	if queryParams.City != "" {
+		dbQuery = dbQuery.Where("LOWER(city) = LOWER(?)", queryParams.City)
+	}
+	if queryParams.Country != "" {
+		dbQuery = dbQuery.Where("country_code = ?", strings.ToUpper(queryParams.Country))
+	}
+	if queryParams.Near != nil {
+		dbQuery = withinRadius(dbQuery, *queryParams.Near, queryParams.Radius())
+	}
+	if queryParams.IncludeDeleted {
+		dbQuery = dbQuery.Unscoped()
+	}
+
+	// Get total count using the same conditions
+	if err := dbQuery.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Geo searches return the nearest hotels first
+	order := interface{}("updated_at desc")
+	if queryParams.Near != nil {
+		order = distanceOrder(*queryParams.Near)
+	}
 
 	// Get paginated results
 	if err := dbQuery.
-		Order("updated_at desc").
+		Order(order).
 		Offset(queryParams.Offset).
 		Limit(queryParams.Limit).
 		Find(&hotels).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Get total count using the same conditions
-	if err := dbQuery.Count(&totalCount).Error; err != nil {
-		return nil, 0, err
-	}
-
 	return hotels, int(totalCount), nil
 }
 
@@ -44,13 +69,24 @@ func (r *reviewRepository) GetHotelByID(id uint) (*models.Hotel, error) {
 	return &hotel, nil
 }
 
-// GetHotelByName retrieves a hotel by its name.
-func (r *reviewRepository) GetHotelByName(name string) (*models.Hotel, error) {
-	var hotel models.Hotel
-	if err := r.db.Model(&models.Hotel{}).Select("id").Where("hotel_name = ?", name).First(&hotel).Error; err != nil {
+// GetHotelsByIDs retrieves the hotels with the given IDs. Unknown IDs are
+// left out.
+func (r *reviewRepository) GetHotelsByIDs(ids []uint) ([]*models.Hotel, error) {
+	var hotels []*models.Hotel
+	if err := r.db.Where("id IN ?", ids).Find(&hotels).Error; err != nil {
 		return nil, err
 	}
-	return &hotel, nil
+	return hotels, nil
+}
+
+// GetHotelsByName retrieves the hotels with a name, those that aren't deleted
+// first and then the oldest first. Unscoped, deleted hotels are included.
+func (r *reviewRepository) GetHotelsByName(name string) ([]*models.Hotel, error) {
+	var hotels []*models.Hotel
+	if err := r.db.Where("hotel_name = ?", name).Order("deleted_at IS NOT NULL, id").Find(&hotels).Error; err != nil {
+		return nil, err
+	}
+	return hotels, nil
 }
 
 // CreateHotel creates a new hotel.
@@ -63,7 +99,86 @@ func (r *reviewRepository) UpdateHotel(hotel *models.Hotel) error {
 	return r.db.Save(hotel).Error
 }
 
-// DeleteHotel deletes a hotel by its ID.
+// DeleteHotel soft-deletes a hotel by its ID, along with its reviews and
+// provider mappings.
 func (r *reviewRepository) DeleteHotel(id uint) error {
-	return r.db.Delete(&models.Hotel{}, id).Error
+	return r.softDeleteWithChildren(&models.Hotel{}, id, "hotel_id")
+}
+
+// RestoreHotel restores a soft-deleted hotel, along with the reviews and
+// provider mappings deleted with it.
+func (r *reviewRepository) RestoreHotel(id uint) error {
+	return r.restoreWithChildren(&models.Hotel{}, id, "hotel_id")
+}
+
+// GetHotelReviewStats aggregates the published reviews of a hotel per provider.
+func (r *reviewRepository) GetHotelReviewStats(hotelID uint) ([]*dto.ProviderReviewStats, error) {
+	var stats []*dto.ProviderReviewStats
+	if err := r.db.Model(&models.Review{}).
+		Select("reviews.provider_id, providers.name AS provider_name, COUNT(*) AS review_count, AVG(reviews.rating) AS average_rating, MAX(reviews.review_date) AS latest_review_date").
+		Joins("JOIN providers ON providers.id = reviews.provider_id").
+		Where("reviews.hotel_id = ? AND reviews.status = ?", hotelID, models.ReviewStatusPublished).
+		Group("reviews.provider_id, providers.name").
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetHotelRatingHistogram counts the published reviews of a hotel in one-point
+// rating buckets.
+func (r *reviewRepository) GetHotelRatingHistogram(hotelID uint) ([]*dto.RatingBucketCount, error) {
+	var buckets []*dto.RatingBucketCount
+	bucket := fmt.Sprintf("LEAST(FLOOR(rating), %d)", dto.RatingHistogramBuckets-1)
+	if err := r.db.Model(&models.Review{}).
+		Select(fmt.Sprintf("CAST(%s AS INTEGER) AS bucket, COUNT(*) AS count", bucket)).
+		Where("hotel_id = ? AND status = ?", hotelID, models.ReviewStatusPublished).
+		Group("bucket").
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// GetHotelProviderScores retrieves the overall scores providers report for a hotel.
+func (r *reviewRepository) GetHotelProviderScores(hotelID uint) ([]*dto.ProviderHotelScore, error) {
+	var scores []*dto.ProviderHotelScore
+	if err := r.db.Model(&models.ProviderHotel{}).
+		Select("provider_hotels.*, providers.name AS provider_name").
+		Joins("JOIN providers ON providers.id = provider_hotels.provider_id").
+		Where("provider_hotels.hotel_id = ?", hotelID).
+		Scan(&scores).Error; err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// GetHotelRatingTimeseries aggregates the published reviews of a hotel into
+// week or month buckets of the review date, in UTC. Only buckets with reviews
+// are returned.
+func (r *reviewRepository) GetHotelRatingTimeseries(hotelID uint, params *dto.RatingTimeseriesQueryParams) ([]*dto.RatingTimeseriesPoint, error) {
+	var points []*dto.RatingTimeseriesPoint
+
+	dbQuery := r.db.Model(&models.Review{}).
+		Select(`date_trunc(?, review_date, 'UTC') AS start,
+			COUNT(*) AS review_count,
+			AVG(rating) AS average_rating,
+			percentile_cont(0.25) WITHIN GROUP (ORDER BY rating) AS p25,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY rating) AS median,
+			percentile_cont(0.75) WITHIN GROUP (ORDER BY rating) AS p75,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY rating) AS p90`, params.Interval).
+		Where("hotel_id = ? AND status = ?", hotelID, models.ReviewStatusPublished)
+
+	if params.ProviderID != 0 {
+		dbQuery = dbQuery.Where("provider_id = ?", params.ProviderID)
+	}
+	if !params.From.IsZero() {
+		dbQuery = dbQuery.Where("review_date >= ?", params.From)
+	}
+	dbQuery = whereUntil(dbQuery, "review_date", params.To)
+
+	if err := dbQuery.Group("start").Order("start").Scan(&points).Error; err != nil {
+		return nil, err
+	}
+	return points, nil
 }
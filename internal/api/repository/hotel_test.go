@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	models "github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestHotelAggregates_OnlyPublished(t *testing.T) {
+	tests := []struct {
+		name      string
+		aggregate func(repo *reviewRepository)
+		where     string
+	}{
+		{
+			name:      "review stats",
+			aggregate: func(repo *reviewRepository) { repo.GetHotelReviewStats(1) },
+			where:     `WHERE (reviews.hotel_id = $1 AND reviews.status = $2)`,
+		},
+		{
+			name:      "rating histogram",
+			aggregate: func(repo *reviewRepository) { repo.GetHotelRatingHistogram(1) },
+			where:     `WHERE (hotel_id = $1 AND status = $2)`,
+		},
+		{
+			name: "rating timeseries",
+			aggregate: func(repo *reviewRepository) {
+				repo.GetHotelRatingTimeseries(1, &dto.RatingTimeseriesQueryParams{Interval: dto.IntervalMonth})
+			},
+			where: `WHERE (hotel_id = $2 AND status = $3)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &reviewRepository{db: newDryRunDB(t)}
+			query := captureQuerySQL(repo.db)
+
+			tt.aggregate(repo)
+
+			assert.Contains(t, query.SQL, tt.where)
+			assert.Contains(t, query.Vars, models.ReviewStatusPublished)
+		})
+	}
+}
+
+func TestHotelAggregates_SkipRejectedReviews(t *testing.T) {
+	db := newTestDB(t)
+	repo := &reviewRepository{db: db}
+
+	provider := &models.Provider{Name: "Aggregates Test Provider"}
+	require.NoError(t, db.Create(provider).Error)
+	hotel := &models.Hotel{HotelName: "Aggregates Test Hotel"}
+	require.NoError(t, db.Create(hotel).Error)
+
+	reviewDate := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	reviews := []*models.Review{
+		{ID: 900000001, ProviderID: provider.ID, HotelID: hotel.ID, Rating: 8, ReviewDate: reviewDate, Status: models.ReviewStatusPublished},
+		{ID: 900000002, ProviderID: provider.ID, HotelID: hotel.ID, Rating: 1, ReviewDate: reviewDate, Status: models.ReviewStatusRejected},
+	}
+	require.NoError(t, db.Create(reviews).Error)
+
+	stats, err := repo.GetHotelReviewStats(hotel.ID)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, 1, stats[0].ReviewCount)
+	assert.Equal(t, 8.0, stats[0].AverageRating)
+
+	buckets, err := repo.GetHotelRatingHistogram(hotel.ID)
+	require.NoError(t, err)
+	require.Len(t, buckets, 1)
+	assert.Equal(t, 8, buckets[0].Bucket)
+
+	points, err := repo.GetHotelRatingTimeseries(hotel.ID, &dto.RatingTimeseriesQueryParams{Interval: dto.IntervalMonth})
+	require.NoError(t, err)
+	require.Len(t, points, 1)
+	assert.Equal(t, 1, points[0].ReviewCount)
+}
+
+func TestHotelNames_UniquePerLocation(t *testing.T) {
+	db := newTestDB(t)
+	repo := &reviewRepository{db: db}
+
+	saigon := &models.Hotel{HotelName: "Location Test Hotel", HotelLocation: models.HotelLocation{City: "Ho Chi Minh City", CountryCode: "VN"}}
+	require.NoError(t, repo.CreateHotel(saigon))
+	hanoi := &models.Hotel{HotelName: "Location Test Hotel", HotelLocation: models.HotelLocation{City: "Hanoi", CountryCode: "VN"}}
+	require.NoError(t, repo.CreateHotel(hanoi))
+
+	duplicate := &models.Hotel{HotelName: "Location Test Hotel", HotelLocation: models.HotelLocation{City: "Hanoi", CountryCode: "VN"}}
+	assert.ErrorIs(t, repo.CreateHotel(duplicate), gorm.ErrDuplicatedKey)
+
+	hotels, err := repo.GetHotelsByName("Location Test Hotel")
+	require.NoError(t, err)
+	require.Len(t, hotels, 2)
+	assert.Equal(t, saigon.ID, hotels[0].ID)
+	assert.Equal(t, hanoi.ID, hotels[1].ID)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"github.com/kirananto/review-system/internal/api/dto"
+	models "github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+// ModerateReviews records a moderation decision on the reviews among ids whose
+// status is one of from, in a single statement, and returns their IDs. Reviews
+// in any other status are left untouched, so concurrent moderators can not
+// apply a transition the lifecycle does not allow.
+func (r *reviewRepository) ModerateReviews(ids []uint, from []string, moderation *dto.ReviewModeration) ([]uint, error) {
+	var moderated []models.Review
+	if err := r.db.Model(&moderated).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("id IN ? AND status IN ?", ids, from).
+		Updates(map[string]interface{}{
+			"status":            moderation.Status,
+			"moderation_reason": moderation.Reason,
+			"moderated_by":      moderation.Moderator,
+			"moderated_at":      moderation.At,
+		}).Error; err != nil {
+		return nil, err
+	}
+
+	moderatedIDs := make([]uint, 0, len(moderated))
+	for _, review := range moderated {
+		moderatedIDs = append(moderatedIDs, review.ID)
+	}
+	return moderatedIDs, nil
+}
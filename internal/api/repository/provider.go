@@ -17,6 +17,12 @@ func (r *reviewRepository) GetProvidersList(queryParams *dto.ProvidersQueryParam
 	if queryParams.Name != "" {
 		dbQuery = dbQuery.Where("name ILIKE ?", "%"+queryParams.Name+"%")
 	}
+	if queryParams.HotelID != 0 {
+		dbQuery = dbQuery.Where("id IN (?)", r.db.Model(&models.ProviderHotel{}).Select("provider_id").Where("hotel_id = ?", queryParams.HotelID))
+	}
+	if queryParams.IncludeDeleted {
+		dbQuery = dbQuery.Unscoped()
+	}
 
 	// Get paginated results
 	if err := dbQuery.
@@ -44,10 +50,21 @@ func (r *reviewRepository) GetProviderByID(id uint) (*models.Provider, error) {
 	return &provider, nil
 }
 
-// GetProviderByName retrieves a provider by its name.
+// GetProvidersByIDs retrieves the providers with the given IDs. Unknown IDs
+// are left out.
+func (r *reviewRepository) GetProvidersByIDs(ids []uint) ([]*models.Provider, error) {
+	var providers []*models.Provider
+	if err := r.db.Where("id IN ?", ids).Find(&providers).Error; err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// GetProviderByName retrieves a provider by its name. Unscoped, a provider
+// that isn't deleted is preferred over deleted ones of the same name.
 func (r *reviewRepository) GetProviderByName(name string) (*models.Provider, error) {
 	var provider models.Provider
-	if err := r.db.Model(&models.Provider{}).Select("id").Where("name = ?", name).First(&provider).Error; err != nil {
+	if err := r.db.Model(&models.Provider{}).Select("id", "deleted_at").Where("name = ?", name).Order("deleted_at IS NOT NULL").First(&provider).Error; err != nil {
 		return nil, err
 	}
 	return &provider, nil
@@ -57,3 +74,20 @@ func (r *reviewRepository) GetProviderByName(name string) (*models.Provider, err
 func (r *reviewRepository) CreateProvider(provider *models.Provider) error {
 	return r.db.Create(provider).Error
 }
+
+// UpdateProvider updates an existing provider.
+func (r *reviewRepository) UpdateProvider(provider *models.Provider) error {
+	return r.db.Save(provider).Error
+}
+
+// DeleteProvider soft-deletes a provider by its ID, along with its reviews and
+// hotel mappings.
+func (r *reviewRepository) DeleteProvider(id uint) error {
+	return r.softDeleteWithChildren(&models.Provider{}, id, "provider_id")
+}
+
+// RestoreProvider restores a soft-deleted provider, along with the reviews and
+// hotel mappings deleted with it.
+func (r *reviewRepository) RestoreProvider(id uint) error {
+	return r.restoreWithChildren(&models.Provider{}, id, "provider_id")
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"github.com/kirananto/review-system/internal/api/dto"
 	models "github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm"
 )
 
 // GetProviderHotelsList retrieves all provider hotels.
@@ -26,9 +27,17 @@ func (r *reviewRepository) GetProviderHotelsList(queryParams *dto.ProviderHotels
 	if len(conditions) > 0 {
 		dbQuery = dbQuery.Where(conditions)
 	}
+	if queryParams.IncludeDeleted {
+		dbQuery = dbQuery.Unscoped()
+	}
+
+	// Get total count using the same conditions
+	if err := dbQuery.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
 
 	// Get paginated results
-	if err := dbQuery.
+	if err := preloadRelations(dbQuery, queryParams.IncludeList()).
 		Order("updated_at desc").
 		Offset(queryParams.Offset).
 		Limit(queryParams.Limit).
@@ -36,23 +45,39 @@ func (r *reviewRepository) GetProviderHotelsList(queryParams *dto.ProviderHotels
 		return nil, 0, err
 	}
 
-	// Get total count using the same conditions
-	if err := dbQuery.Count(&totalCount).Error; err != nil {
-		return nil, 0, err
-	}
-
 	return providerHotels, int(totalCount), nil
 }
 
-// GetProviderHotel retrieves a provider-specific hotel mapping.
-func (r *reviewRepository) GetProviderHotel(providerID uint, hotelID uint) (*models.ProviderHotel, error) {
+// GetProviderHotel retrieves a provider-specific hotel mapping, with the
+// included relations preloaded.
+func (r *reviewRepository) GetProviderHotel(providerID uint, hotelID uint, include ...string) (*models.ProviderHotel, error) {
 	var providerHotel models.ProviderHotel
-	if err := r.db.Where("provider_id = ? AND hotel_id = ?", providerID, hotelID).First(&providerHotel).Error; err != nil {
+	if err := preloadRelations(r.db, include).Where("provider_id = ? AND hotel_id = ?", providerID, hotelID).First(&providerHotel).Error; err != nil {
 		return nil, err
 	}
 	return &providerHotel, nil
 }
 
+// GetProviderHotelsByHotelIDs retrieves the provider mappings of the given
+// hotels, most recently updated first.
+func (r *reviewRepository) GetProviderHotelsByHotelIDs(hotelIDs []uint) ([]*models.ProviderHotel, error) {
+	var providerHotels []*models.ProviderHotel
+	if err := r.db.Where("hotel_id IN ?", hotelIDs).Order("updated_at desc").Find(&providerHotels).Error; err != nil {
+		return nil, err
+	}
+	return providerHotels, nil
+}
+
+// GetProviderHotelsByProviderIDs retrieves the hotel mappings of the given
+// providers, most recently updated first.
+func (r *reviewRepository) GetProviderHotelsByProviderIDs(providerIDs []uint) ([]*models.ProviderHotel, error) {
+	var providerHotels []*models.ProviderHotel
+	if err := r.db.Where("provider_id IN ?", providerIDs).Order("updated_at desc").Find(&providerHotels).Error; err != nil {
+		return nil, err
+	}
+	return providerHotels, nil
+}
+
 // CreateProviderHotel creates a new provider-specific hotel mapping.
 func (r *reviewRepository) CreateProviderHotel(providerHotel *models.ProviderHotel) error {
 	return r.db.Create(providerHotel).Error
@@ -62,3 +87,15 @@ func (r *reviewRepository) CreateProviderHotel(providerHotel *models.ProviderHot
 func (r *reviewRepository) UpdateProviderHotel(providerHotel *models.ProviderHotel) error {
 	return r.db.Save(providerHotel).Error
 }
+
+// DeleteProviderHotel soft-deletes a provider-specific hotel mapping.
+func (r *reviewRepository) DeleteProviderHotel(providerID uint, hotelID uint) error {
+	return r.db.Where("provider_id = ? AND hotel_id = ?", providerID, hotelID).Delete(&models.ProviderHotel{}).Error
+}
+
+// RestoreProviderHotel restores a soft-deleted provider-specific hotel mapping.
+func (r *reviewRepository) RestoreProviderHotel(providerID uint, hotelID uint) error {
+	return r.db.Unscoped().Model(&models.ProviderHotel{}).
+		Where("provider_id = ? AND hotel_id = ?", providerID, hotelID).
+		Update("deleted_at", nil).Error
+}
@@ -1,6 +1,11 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/kirananto/review-system/internal/api/dto"
 	"github.com/kirananto/review-system/internal/db"
 	models "github.com/kirananto/review-system/internal/models"
@@ -12,31 +17,80 @@ type ReviewRepository interface {
 	// Provider methods
 	GetProvidersList(queryParams *dto.ProvidersQueryParams) ([]*models.Provider, int, error)
 	GetProviderByID(id uint) (*models.Provider, error)
+	GetProvidersByIDs(ids []uint) ([]*models.Provider, error)
 	GetProviderByName(name string) (*models.Provider, error)
 	CreateProvider(provider *models.Provider) error
+	UpdateProvider(provider *models.Provider) error
+	DeleteProvider(id uint) error
+	RestoreProvider(id uint) error
 
 	// Hotel methods
 	GetHotelsList(queryParams *dto.HotelsQueryParams) ([]*models.Hotel, int, error)
 	GetHotelByID(id uint) (*models.Hotel, error)
-	GetHotelByName(name string) (*models.Hotel, error)
+	GetHotelsByIDs(ids []uint) ([]*models.Hotel, error)
+	GetHotelsByName(name string) ([]*models.Hotel, error)
 	CreateHotel(hotel *models.Hotel) error
 	UpdateHotel(hotel *models.Hotel) error
 	DeleteHotel(id uint) error
+	RestoreHotel(id uint) error
+	GetHotelReviewStats(hotelID uint) ([]*dto.ProviderReviewStats, error)
+	GetHotelRatingHistogram(hotelID uint) ([]*dto.RatingBucketCount, error)
+	GetHotelProviderScores(hotelID uint) ([]*dto.ProviderHotelScore, error)
+	GetHotelRatingTimeseries(hotelID uint, params *dto.RatingTimeseriesQueryParams) ([]*dto.RatingTimeseriesPoint, error)
+	GetHotelResponseStats(hotelID uint, params *dto.ResponseMetricsQueryParams) (*dto.ReviewResponseStats, error)
 
 	// ProviderHotel methods
 	GetProviderHotelsList(queryParams *dto.ProviderHotelsQueryParams) ([]*models.ProviderHotel, int, error)
-	GetProviderHotel(providerID uint, hotelID uint) (*models.ProviderHotel, error)
+	GetProviderHotel(providerID uint, hotelID uint, include ...string) (*models.ProviderHotel, error)
+	GetProviderHotelsByHotelIDs(hotelIDs []uint) ([]*models.ProviderHotel, error)
+	GetProviderHotelsByProviderIDs(providerIDs []uint) ([]*models.ProviderHotel, error)
 	CreateProviderHotel(providerHotel *models.ProviderHotel) error
 	UpdateProviderHotel(providerHotel *models.ProviderHotel) error
+	DeleteProviderHotel(providerID uint, hotelID uint) error
+	RestoreProviderHotel(providerID uint, hotelID uint) error
 
 	// Review methods
 	GetReviewsList(queryParams *dto.ReviewQueryParams) ([]*models.Review, int, error)
-	GetReviewByID(id uint) (*models.Review, error)
+	GetReviewsPage(queryParams *dto.ReviewQueryParams, cursor *dto.ReviewCursor) ([]*models.Review, bool, error)
+	CountReviews(queryParams *dto.ReviewQueryParams) (int, error)
+	StreamReviews(ctx context.Context, queryParams *dto.ReviewQueryParams, fn func(*models.Review) error) error
+	SearchReviews(queryParams *dto.ReviewQueryParams) ([]*dto.ReviewSearchResult, int, error)
+	GetReviewByID(id uint, include ...string) (*models.Review, error)
+	GetLatestReviewsByHotelIDs(hotelIDs []uint, limit int) ([]*models.Review, error)
 	CreateReview(review *models.Review) error
+	UpdateReview(review *models.Review) error
+	DeleteReview(id uint) error
+	RestoreReview(id uint) error
 	UpsertReview(review *models.Review) error
+	ModerateReviews(ids []uint, from []string, moderation *dto.ReviewModeration) ([]uint, error)
+
+	// ReviewResponse methods
+	CreateReviewResponse(reviewResponse *models.ReviewResponse) error
+	UpdateReviewResponse(reviewResponse *models.ReviewResponse) error
+	DeleteReviewResponse(id uint) error
+	UpsertProviderReviewResponse(reviewResponse *models.ReviewResponse) error
 
 	// AuditLog methods
+	GetAuditLogsList(queryParams *dto.AuditLogsQueryParams) ([]*models.AuditLog, int, error)
 	CreateAuditLog(auditLog *models.AuditLog) error
+
+	// Webhook methods
+	GetWebhookSubscriptionsList(queryParams *dto.WebhookSubscriptionsQueryParams) ([]*models.WebhookSubscription, int, error)
+	GetWebhookSubscriptionByID(id uint) (*models.WebhookSubscription, error)
+	GetWebhookSubscriptionsByIDs(ids []uint) ([]*models.WebhookSubscription, error)
+	GetMatchingWebhookSubscriptions(eventType string, hotelID, providerID uint) ([]*models.WebhookSubscription, error)
+	CreateWebhookSubscription(subscription *models.WebhookSubscription) error
+	UpdateWebhookSubscription(subscription *models.WebhookSubscription) error
+	DeleteWebhookSubscription(id uint) error
+	GetWebhookDeliveriesList(queryParams *dto.WebhookDeliveriesQueryParams) ([]*models.WebhookDelivery, int, error)
+	GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, error)
+	CreateWebhookDeliveries(deliveries []*models.WebhookDelivery) error
+	UpdateWebhookDelivery(delivery *models.WebhookDelivery, leasedUntil time.Time) (bool, error)
+	ClaimDueWebhookDeliveries(now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error)
+
+	// Soft delete methods
+	PurgeDeleted(before time.Time) (*dto.PurgeResult, error)
+	Unscoped() ReviewRepository
 }
 
 type reviewRepository struct {
@@ -49,14 +103,99 @@ func NewReviewRepository(dataSource *db.DataSource) ReviewRepository {
 	}
 }
 
+// relationAssociations maps the relations a response can include to the
+// associations preloaded for them.
+var relationAssociations = map[string]string{
+	dto.RelationProvider: "Provider",
+	dto.RelationHotel:    "Hotel",
+	dto.RelationResponse: "Response",
+}
+
+// preloadRelations preloads the associations of the included relations.
+// Relations are validated before they reach the repository, so unknown names
+// are ignored.
+func preloadRelations(dbQuery *gorm.DB, include []string) *gorm.DB {
+	for _, relation := range include {
+		if association, ok := relationAssociations[relation]; ok {
+			dbQuery = dbQuery.Preload(association)
+		}
+	}
+	return dbQuery
+}
+
+// GetAuditLogsList retrieves the audit logs of processed files, newest first.
+func (r *reviewRepository) GetAuditLogsList(queryParams *dto.AuditLogsQueryParams) ([]*models.AuditLog, int, error) {
+	var auditLogs []*models.AuditLog
+	var totalCount int64
+
+	dbQuery := r.db.Model(&models.AuditLog{})
+	if queryParams.FileName != "" {
+		dbQuery = dbQuery.Where("file_name = ?", queryParams.FileName)
+	}
+	if !queryParams.CreatedFrom.IsZero() {
+		dbQuery = dbQuery.Where("created_at >= ?", queryParams.CreatedFrom)
+	}
+	if !queryParams.CreatedTo.IsZero() {
+		dbQuery = dbQuery.Where("created_at <= ?", queryParams.CreatedTo)
+	}
+
+	// Get total count using the same conditions
+	if err := dbQuery.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := dbQuery.
+		Order("created_at desc, id desc").
+		Offset(queryParams.Offset).
+		Limit(queryParams.Limit).
+		Find(&auditLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, int(totalCount), nil
+}
+
 func (r *reviewRepository) CreateAuditLog(auditLog *models.AuditLog) error {
 	return r.db.Create(auditLog).Error
 }
 
+// reviewUpsertColumns are the columns ingesting a stored review again updates.
+var reviewUpsertColumns = []string{"rating", "title", "comment", "review_date", "reviewer_info", "moderation_rules"}
+
+// reviewModerationColumns are the columns of a moderation decision, which
+// ingesting a stored review again only updates when auto-moderation flags it.
+var reviewModerationColumns = []string{"status", "moderation_reason", "moderated_by", "moderated_at"}
+
+// reviewReflagged is whether auto-moderation flagged a stored review that can
+// still be flagged. A review a person has already decided on is only flagged
+// again when its text or rating changed.
+var reviewReflagged = fmt.Sprintf("(excluded.status = '%s' AND reviews.status IN ('%s', '%s') AND "+
+	"((reviews.rating, reviews.title, reviews.comment) IS DISTINCT FROM (excluded.rating, excluded.title, excluded.comment) OR "+
+	"reviews.moderated_by IS NULL OR reviews.moderated_by = excluded.moderated_by))",
+	models.ReviewStatusFlagged, models.ReviewStatusPending, models.ReviewStatusPublished)
+
 func (r *reviewRepository) UpsertReview(review *models.Review) error {
+	assignments := clause.AssignmentColumns(reviewUpsertColumns)
+	for _, column := range reviewModerationColumns {
+		assignments = append(assignments, clause.Assignment{
+			Column: clause.Column{Name: column},
+			Value:  gorm.Expr(fmt.Sprintf("CASE WHEN %s THEN excluded.%s ELSE reviews.%s END", reviewReflagged, column, column)),
+		})
+	}
+
+	// updated_at only moves when the review changed, so Last-Modified moves
+	// with its content
+	changed := fmt.Sprintf("(reviews.%s) IS DISTINCT FROM (excluded.%s)",
+		strings.Join(reviewUpsertColumns, ", reviews."), strings.Join(reviewUpsertColumns, ", excluded."))
+	assignments = append(assignments, clause.Assignment{
+		Column: clause.Column{Name: "updated_at"},
+		Value:  gorm.Expr(fmt.Sprintf("CASE WHEN %s OR %s THEN excluded.updated_at ELSE reviews.updated_at END", changed, reviewReflagged)),
+	})
+
 	// Use Clauses to handle the conflict
 	return r.db.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"rating", "comment", "review_date"}),
+		DoUpdates: assignments,
 	}).Create(review).Error
 }
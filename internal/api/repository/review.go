@@ -1,8 +1,28 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"slices"
+
 	"github.com/kirananto/review-system/internal/api/dto"
 	models "github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// searchConfig is the text search configuration the search vector of a
+	// review is built with; queries must use the same one.
+	searchConfig = "english"
+
+	// searchHeadlineOptions controls the highlighted snippets of search results.
+	searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+
+	// escapedComment is the comment of a review with its HTML special
+	// characters escaped. Snippets are built from it, so the <mark> tags are
+	// the only markup in them.
+	escapedComment = `replace(replace(replace(replace(replace(COALESCE(comment, ''), '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
 )
 
 func (r *reviewRepository) GetReviewsList(queryParams *dto.ReviewQueryParams) ([]*models.Review, int, error) {
@@ -10,8 +30,205 @@ func (r *reviewRepository) GetReviewsList(queryParams *dto.ReviewQueryParams) ([
 	var totalCount int64
 
 	// Initialize query
-	dbQuery := r.db.Model(&models.Review{})
+	dbQuery := applyReviewFilters(r.db.Model(&models.Review{}), queryParams)
+
+	// Get total count using the same conditions
+	if err := dbQuery.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := preloadRelations(dbQuery, queryParams.IncludeList()).
+		Order(reviewListOrder(queryParams)).
+		Offset(queryParams.Offset).
+		Limit(queryParams.Limit).
+		Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, int(totalCount), nil
+}
+
+// GetReviewsPage returns the page of reviews after the cursor, or before it
+// when the cursor points backwards, using keyset pagination on the sort column
+// and ID. A nil cursor starts at the beginning of the list. The returned flag
+// reports whether more reviews exist beyond the page in the direction of travel.
+func (r *reviewRepository) GetReviewsPage(queryParams *dto.ReviewQueryParams, cursor *dto.ReviewCursor) ([]*models.Review, bool, error) {
+	var reviews []*models.Review
+
+	field, desc := dto.ParseReviewSort(queryParams.Sort)
+	backward := cursor != nil && cursor.Backward
+
+	// Walking backwards reads the preceding rows in reverse order
+	if backward {
+		desc = !desc
+	}
+
+	dbQuery := applyReviewFilters(r.db.Model(&models.Review{}), queryParams)
+	if cursor != nil {
+		operator := ">"
+		if desc {
+			operator = "<"
+		}
+		dbQuery = dbQuery.Where(fmt.Sprintf("(%s, id) %s (?, ?)", field, operator), cursor.Value(), cursor.ID)
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	if err := preloadRelations(dbQuery, queryParams.IncludeList()).
+		Order(keysetOrder(field, desc)).
+		Limit(queryParams.Limit + 1).
+		Find(&reviews).Error; err != nil {
+		return nil, false, err
+	}
+
+	more := len(reviews) > queryParams.Limit
+	if more {
+		reviews = reviews[:queryParams.Limit]
+	}
+	if backward {
+		slices.Reverse(reviews)
+	}
 
+	return reviews, more, nil
+}
+
+// StreamReviews calls fn with every review matching the filters, in the order
+// of the reviews list. Rows are read from the database one at a time, so memory
+// use does not grow with the number of reviews. It stops at the first error fn
+// returns, or when ctx is cancelled.
+func (r *reviewRepository) StreamReviews(ctx context.Context, queryParams *dto.ReviewQueryParams, fn func(*models.Review) error) error {
+	rows, err := applyReviewFilters(r.db.WithContext(ctx).Model(&models.Review{}), queryParams).
+		Order(reviewListOrder(queryParams)).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var review models.Review
+		if err := r.db.ScanRows(rows, &review); err != nil {
+			return err
+		}
+		if err := fn(&review); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// CountReviews returns the number of reviews matching the filters.
+func (r *reviewRepository) CountReviews(queryParams *dto.ReviewQueryParams) (int, error) {
+	var totalCount int64
+	if err := applyReviewFilters(r.db.Model(&models.Review{}), queryParams).Count(&totalCount).Error; err != nil {
+		return 0, err
+	}
+	return int(totalCount), nil
+}
+
+// SearchReviews runs a full-text search over review titles and comments. Each
+// result carries its rank and a highlighted snippet of the comment.
+func (r *reviewRepository) SearchReviews(queryParams *dto.ReviewQueryParams) ([]*dto.ReviewSearchResult, int, error) {
+	var results []*dto.ReviewSearchResult
+	var totalCount int64
+
+	dbQuery := applyReviewFilters(r.db.Model(&models.Review{}), queryParams)
+
+	if err := dbQuery.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := interface{}(reviewOrder(queryParams.Sort))
+	if queryParams.Sort == "" {
+		order = searchRankOrder(queryParams.Q)
+	}
+
+	if err := dbQuery.
+		Select(
+			"reviews.*, ts_rank(search_vector, websearch_to_tsquery(?, ?)) AS rank, ts_headline(?, "+escapedComment+", websearch_to_tsquery(?, ?), ?) AS snippet",
+			searchConfig, queryParams.Q, searchConfig, searchConfig, queryParams.Q, searchHeadlineOptions,
+		).
+		Order(order).
+		Offset(queryParams.Offset).
+		Limit(queryParams.Limit).
+		Scan(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Scanning into search results does not preload, so relations are
+	// loaded separately
+	if err := r.loadSearchResultRelations(results, queryParams.IncludeList()); err != nil {
+		return nil, 0, err
+	}
+
+	return results, int(totalCount), nil
+}
+
+// loadSearchResultRelations attaches the included providers, hotels and
+// responses to search results, with one query per relation.
+func (r *reviewRepository) loadSearchResultRelations(results []*dto.ReviewSearchResult, include []string) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	if slices.Contains(include, dto.RelationProvider) {
+		var providers []*models.Provider
+		if err := r.db.Find(&providers, searchResultIDs(results, func(review *models.Review) uint { return review.ProviderID })).Error; err != nil {
+			return err
+		}
+		byID := make(map[uint]*models.Provider, len(providers))
+		for _, provider := range providers {
+			byID[provider.ID] = provider
+		}
+		for _, result := range results {
+			result.Provider = byID[result.ProviderID]
+		}
+	}
+
+	if slices.Contains(include, dto.RelationHotel) {
+		var hotels []*models.Hotel
+		if err := r.db.Find(&hotels, searchResultIDs(results, func(review *models.Review) uint { return review.HotelID })).Error; err != nil {
+			return err
+		}
+		byID := make(map[uint]*models.Hotel, len(hotels))
+		for _, hotel := range hotels {
+			byID[hotel.ID] = hotel
+		}
+		for _, result := range results {
+			result.Hotel = byID[result.HotelID]
+		}
+	}
+
+	if slices.Contains(include, dto.RelationResponse) {
+		var responses []*models.ReviewResponse
+		if err := r.db.Where("review_id IN ?", searchResultIDs(results, func(review *models.Review) uint { return review.ID })).Find(&responses).Error; err != nil {
+			return err
+		}
+		byReviewID := make(map[uint]*models.ReviewResponse, len(responses))
+		for _, response := range responses {
+			byReviewID[response.ReviewID] = response
+		}
+		for _, result := range results {
+			result.Response = byReviewID[result.ID]
+		}
+	}
+
+	return nil
+}
+
+// searchResultIDs returns the distinct IDs key picks from the results.
+func searchResultIDs(results []*dto.ReviewSearchResult, key func(*models.Review) uint) []uint {
+	var ids []uint
+	for _, result := range results {
+		if id := key(&result.Review); !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// applyReviewFilters adds a condition for every filter set in queryParams.
+func applyReviewFilters(dbQuery *gorm.DB, queryParams *dto.ReviewQueryParams) *gorm.DB {
 	// Build conditions map with only non-zero values
 	conditions := make(map[string]interface{})
 	if queryParams.HotelID != 0 {
@@ -20,43 +237,157 @@ func (r *reviewRepository) GetReviewsList(queryParams *dto.ReviewQueryParams) ([
 	if queryParams.ProviderID != 0 {
 		conditions["provider_id"] = queryParams.ProviderID
 	}
+	if queryParams.Lang != "" {
+		conditions["lang"] = queryParams.Lang
+	}
 
 	// Apply non-zero conditions (GORM will AND them together)
 	if len(conditions) > 0 {
 		dbQuery = dbQuery.Where(conditions)
 	}
 
-	// Get paginated results
-	if err := dbQuery.
-		Order("updated_at desc").
-		Offset(queryParams.Offset).
-		Limit(queryParams.Limit).
-		Find(&reviews).Error; err != nil {
-		return nil, 0, err
+	// Soft-deleted reviews are left out unless an admin asks for them
+	if queryParams.IncludeDeleted {
+		dbQuery = dbQuery.Unscoped()
 	}
 
-	// Get total count using the same conditions
-	if err := dbQuery.Count(&totalCount).Error; err != nil {
-		return nil, 0, err
+	// Only published reviews are listed unless other statuses are asked for
+	dbQuery = dbQuery.Where("status IN ?", queryParams.StatusList())
+
+	if queryParams.MinRating != nil {
+		dbQuery = dbQuery.Where("rating >= ?", *queryParams.MinRating)
+	}
+	if queryParams.MaxRating != nil {
+		dbQuery = dbQuery.Where("rating <= ?", *queryParams.MaxRating)
+	}
+	if !queryParams.ReviewDateFrom.IsZero() {
+		dbQuery = dbQuery.Where("review_date >= ?", queryParams.ReviewDateFrom)
 	}
+	dbQuery = whereUntil(dbQuery, "review_date", queryParams.ReviewDateTo)
 
-	return reviews, int(totalCount), nil
+	// Traveler type and country are matched case-insensitively against the
+	// reviewer info the provider sent with the review.
+	if queryParams.TravelerType != "" {
+		dbQuery = dbQuery.Where("LOWER(reviewer_info->>'reviewGroupName') = LOWER(?)", queryParams.TravelerType)
+	}
+	if queryParams.Country != "" {
+		dbQuery = dbQuery.Where("LOWER(reviewer_info->>'countryName') = LOWER(?)", queryParams.Country)
+	}
+
+	// Queries use web search syntax: quoted phrases, "or" and -excluded words
+	if queryParams.Q != "" {
+		dbQuery = dbQuery.Where("search_vector @@ websearch_to_tsquery(?, ?)", searchConfig, queryParams.Q)
+	}
+
+	if queryParams.HasComment != nil {
+		if *queryParams.HasComment {
+			dbQuery = dbQuery.Where("COALESCE(TRIM(comment), '') <> ''")
+		} else {
+			dbQuery = dbQuery.Where("COALESCE(TRIM(comment), '') = ''")
+		}
+	}
+
+	return dbQuery
+}
+
+// whereUntil limits column to an inclusive range end, if there is one. A plain
+// date includes the whole day, up to the next midnight.
+func whereUntil(dbQuery *gorm.DB, column string, end dto.RangeEnd) *gorm.DB {
+	if end.IsZero() {
+		return dbQuery
+	}
+	if end.DateOnly {
+		return dbQuery.Where(column+" < ?", end.AddDate(0, 0, 1))
+	}
+	return dbQuery.Where(column+" <= ?", end.Time)
+}
+
+// reviewListOrder is the order of the reviews list. A search without an
+// explicit sort returns the most relevant reviews first.
+func reviewListOrder(queryParams *dto.ReviewQueryParams) interface{} {
+	if queryParams.Q != "" && queryParams.Sort == "" {
+		return searchRankOrder(queryParams.Q)
+	}
+	return reviewOrder(queryParams.Sort)
 }
 
-// GetReviewByID retrieves a review by its ID.
-func (r *reviewRepository) GetReviewByID(id uint) (*models.Review, error) {
+// searchRankOrder orders reviews by how well they match a full-text query.
+func searchRankOrder(q string) clause.OrderBy {
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                "ts_rank(search_vector, websearch_to_tsquery(?, ?)) DESC, id DESC",
+		Vars:               []interface{}{searchConfig, q},
+		WithoutParentheses: true,
+	}}
+}
+
+// reviewOrder turns a sort parameter such as "-rating" into an ORDER BY
+// clause. The review ID breaks ties so pages do not overlap. Unknown fields
+// fall back to the most recently updated reviews first.
+func reviewOrder(sort string) string {
+	field, desc := dto.ParseReviewSort(sort)
+	return keysetOrder(field, desc)
+}
+
+func keysetOrder(field string, desc bool) string {
+	direction := "asc"
+	if desc {
+		direction = "desc"
+	}
+	return fmt.Sprintf("%s %s, id %s", field, direction, direction)
+}
+
+// GetReviewByID retrieves a review by its ID, with the included relations
+// preloaded.
+func (r *reviewRepository) GetReviewByID(id uint, include ...string) (*models.Review, error) {
 	var review models.Review
-	if err := r.db.First(&review, id).Error; err != nil {
+	if err := preloadRelations(r.db, include).First(&review, id).Error; err != nil {
 		return nil, err
 	}
 	return &review, nil
 }
 
+// GetLatestReviewsByHotelIDs retrieves up to limit of the latest published
+// reviews of each of the given hotels, with their responses, in one query.
+// The reviews are grouped by hotel, newest first.
+func (r *reviewRepository) GetLatestReviewsByHotelIDs(hotelIDs []uint, limit int) ([]*models.Review, error) {
+	var reviews []*models.Review
+
+	// Rank the reviews of every hotel, then keep the first few of each
+	ranked := r.db.Model(&models.Review{}).
+		Select("reviews.*, ROW_NUMBER() OVER (PARTITION BY hotel_id ORDER BY review_date DESC, id DESC) AS hotel_rank").
+		Where("hotel_id IN ? AND status = ?", hotelIDs, models.ReviewStatusPublished)
+
+	if err := r.db.Table("(?) AS reviews", ranked).
+		Preload("Response").
+		Where("hotel_rank <= ?", limit).
+		Order("hotel_id, review_date desc, id desc").
+		Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
 // CreateReview creates a new review.
 func (r *reviewRepository) CreateReview(review *models.Review) error {
 	return r.db.Create(review).Error
 }
 
+// UpdateReview updates an existing review.
+func (r *reviewRepository) UpdateReview(review *models.Review) error {
+	return r.db.Save(review).Error
+}
+
+// DeleteReview soft-deletes a review by its ID.
+func (r *reviewRepository) DeleteReview(id uint) error {
+	return r.db.Delete(&models.Review{}, id).Error
+}
+
+// RestoreReview restores a soft-deleted review.
+func (r *reviewRepository) RestoreReview(id uint) error {
+	return r.db.Unscoped().Model(&models.Review{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
 // GetReviews retrieves all reviews.
 // TODO: Use GetReviewsList with pagination and filters instead of this method.
 func (r *reviewRepository) GetReviews() ([]*models.Review, error) {
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"github.com/kirananto/review-system/internal/api/dto"
+	models "github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+// responseHours is the time from a review to its response in hours. Provider
+// feeds date responses by the day, so a same-day response can seem to predate
+// its review; those count as immediate.
+const responseHours = "GREATEST(EXTRACT(EPOCH FROM review_responses.response_date - reviews.review_date), 0) / 3600"
+
+// CreateReviewResponse creates a response to a review.
+func (r *reviewRepository) CreateReviewResponse(reviewResponse *models.ReviewResponse) error {
+	return r.db.Create(reviewResponse).Error
+}
+
+// UpdateReviewResponse updates an existing response.
+func (r *reviewRepository) UpdateReviewResponse(reviewResponse *models.ReviewResponse) error {
+	return r.db.Save(reviewResponse).Error
+}
+
+// DeleteReviewResponse deletes a response by its ID.
+func (r *reviewRepository) DeleteReviewResponse(id uint) error {
+	return r.db.Delete(&models.ReviewResponse{}, id).Error
+}
+
+// UpsertProviderReviewResponse creates or updates the response a provider's
+// feed carries for a review. Responses the hotel posted through the API are
+// left alone.
+func (r *reviewRepository) UpsertProviderReviewResponse(reviewResponse *models.ReviewResponse) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "review_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"responder_name", "response_date", "response_date_text", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: clause.Column{Table: "review_responses", Name: "source"}, Value: models.ReviewResponseSourceProvider},
+		}},
+	}).Create(reviewResponse).Error
+}
+
+// GetHotelResponseStats aggregates the responses to the published reviews of a
+// hotel.
+func (r *reviewRepository) GetHotelResponseStats(hotelID uint, params *dto.ResponseMetricsQueryParams) (*dto.ReviewResponseStats, error) {
+	var stats dto.ReviewResponseStats
+
+	dbQuery := r.db.Model(&models.Review{}).
+		Select(`COUNT(*) AS review_count,
+			COUNT(review_responses.id) AS response_count,
+			AVG(`+responseHours+`) AS average_response_hours,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY `+responseHours+`) AS median_response_hours`).
+		Joins("LEFT JOIN review_responses ON review_responses.review_id = reviews.id").
+		Where("reviews.hotel_id = ? AND reviews.status = ?", hotelID, models.ReviewStatusPublished)
+
+	if params.ProviderID != 0 {
+		dbQuery = dbQuery.Where("reviews.provider_id = ?", params.ProviderID)
+	}
+	if !params.From.IsZero() {
+		dbQuery = dbQuery.Where("reviews.review_date >= ?", params.From)
+	}
+	dbQuery = whereUntil(dbQuery, "reviews.review_date", params.To)
+
+	if err := dbQuery.Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	models "github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHotelResponseStats_OnlyPublished(t *testing.T) {
+	repo := &reviewRepository{db: newDryRunDB(t)}
+	query := captureQuerySQL(repo.db)
+
+	repo.GetHotelResponseStats(1, &dto.ResponseMetricsQueryParams{})
+
+	assert.Contains(t, query.SQL, `WHERE (reviews.hotel_id = $1 AND reviews.status = $2)`)
+	assert.Contains(t, query.Vars, models.ReviewStatusPublished)
+}
+
+func TestGetHotelResponseStats_DateOnlyTo(t *testing.T) {
+	repo := &reviewRepository{db: newDryRunDB(t)}
+	query := captureQuerySQL(repo.db)
+	day := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	repo.GetHotelResponseStats(1, &dto.ResponseMetricsQueryParams{To: dto.RangeEnd{Time: day, DateOnly: true}})
+
+	assert.Contains(t, query.SQL, `reviews.review_date < $3`)
+	assert.Contains(t, query.Vars, day.AddDate(0, 0, 1))
+}
+
+func TestGetHotelResponseStats_SkipsRejectedReviews(t *testing.T) {
+	db := newTestDB(t)
+	repo := &reviewRepository{db: db}
+
+	provider := &models.Provider{Name: "Response Stats Test Provider"}
+	require.NoError(t, db.Create(provider).Error)
+	hotel := &models.Hotel{HotelName: "Response Stats Test Hotel"}
+	require.NoError(t, db.Create(hotel).Error)
+
+	reviewDate := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	reviews := []*models.Review{
+		{ID: 900000001, ProviderID: provider.ID, HotelID: hotel.ID, Rating: 8, ReviewDate: reviewDate, Status: models.ReviewStatusPublished},
+		{ID: 900000002, ProviderID: provider.ID, HotelID: hotel.ID, Rating: 1, ReviewDate: reviewDate, Status: models.ReviewStatusRejected},
+	}
+	require.NoError(t, db.Create(reviews).Error)
+	respondedAt := reviewDate.Add(24 * time.Hour)
+	require.NoError(t, db.Create(&models.ReviewResponse{
+		ReviewID: 900000002, HotelID: hotel.ID, ResponderName: "Manager", ResponseDate: &respondedAt, Source: models.ReviewResponseSourceAPI,
+	}).Error)
+
+	stats, err := repo.GetHotelResponseStats(hotel.ID, &dto.ResponseMetricsQueryParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.ReviewCount)
+	assert.Equal(t, 0, stats.ResponseCount)
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	models "github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// newDryRunDB returns a database that builds statements without running
+// them, so tests can check the SQL queries would send.
+func newDryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true, Logger: gormlogger.Discard})
+	require.NoError(t, err)
+	return db
+}
+
+// capturedQuery is the SQL of a query and the values bound to it.
+type capturedQuery struct {
+	SQL  string
+	Vars []interface{}
+}
+
+// captureQuerySQL records the last query db builds with Scan, which dry runs
+// build but can not run.
+func captureQuerySQL(db *gorm.DB) *capturedQuery {
+	query := &capturedQuery{}
+	db.Callback().Row().After("gorm:row").Register("test:capture", func(tx *gorm.DB) {
+		query.SQL, query.Vars = tx.Statement.SQL.String(), tx.Statement.Vars
+	})
+	return query
+}
+
+// newTestDB returns a transaction on the database in TEST_DATABASE_DSN, rolled
+// back when the test ends. Tests that need it are skipped when it isn't set.
+func newTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Provider{}, &models.Hotel{}, &models.Review{}, &models.ReviewResponse{}, &models.ProviderHotel{}))
+
+	tx := db.Begin()
+	require.NoError(t, tx.Error)
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+func TestReviewOrder(t *testing.T) {
+	tests := []struct {
+		sort     string
+		expected string
+	}{
+		{sort: "", expected: "updated_at desc, id desc"},
+		{sort: "rating", expected: "rating asc, id asc"},
+		{sort: "-review_date", expected: "review_date desc, id desc"},
+		{sort: "created_at", expected: "created_at asc, id asc"},
+		{sort: "rating; DROP TABLE reviews", expected: "updated_at desc, id desc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			assert.Equal(t, tt.expected, reviewOrder(tt.sort))
+		})
+	}
+}
+
+func TestWhereUntil(t *testing.T) {
+	day := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		end          dto.RangeEnd
+		expectedSQL  string
+		expectedVars []interface{}
+	}{
+		{
+			name:         "no end",
+			end:          dto.RangeEnd{},
+			expectedSQL:  `SELECT * FROM "reviews" WHERE "reviews"."deleted_at" IS NULL`,
+			expectedVars: []interface{}{},
+		},
+		{
+			name:         "date includes the whole day",
+			end:          dto.RangeEnd{Time: day, DateOnly: true},
+			expectedSQL:  `SELECT * FROM "reviews" WHERE review_date < $1 AND "reviews"."deleted_at" IS NULL`,
+			expectedVars: []interface{}{day.AddDate(0, 0, 1)},
+		},
+		{
+			name:         "timestamp is inclusive",
+			end:          dto.RangeEnd{Time: day.Add(12 * time.Hour)},
+			expectedSQL:  `SELECT * FROM "reviews" WHERE review_date <= $1 AND "reviews"."deleted_at" IS NULL`,
+			expectedVars: []interface{}{day.Add(12 * time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reviews []*models.Review
+			stmt := whereUntil(newDryRunDB(t).Model(&models.Review{}), "review_date", tt.end).Find(&reviews).Statement
+
+			assert.Equal(t, tt.expectedSQL, stmt.SQL.String())
+			assert.Equal(t, tt.expectedVars, stmt.Vars)
+		})
+	}
+}
+
+func TestUpsertReview(t *testing.T) {
+	repo := &reviewRepository{db: newDryRunDB(t)}
+	review := &models.Review{ID: 1, ProviderID: 2, HotelID: 3, Rating: 8, ReviewDate: time.Now()}
+
+	var sql string
+	repo.db.Callback().Create().After("gorm:create").Register("test:capture", func(db *gorm.DB) {
+		sql = db.Statement.SQL.String()
+	})
+	require.NoError(t, repo.UpsertReview(review))
+
+	reflagged := `(excluded.status = 'flagged' AND reviews.status IN ('pending', 'published') AND ` +
+		`((reviews.rating, reviews.title, reviews.comment) IS DISTINCT FROM (excluded.rating, excluded.title, excluded.comment) OR ` +
+		`reviews.moderated_by IS NULL OR reviews.moderated_by = excluded.moderated_by))`
+	assert.Contains(t, sql, `ON CONFLICT ("id") DO UPDATE SET "rating"="excluded"."rating","title"="excluded"."title","comment"="excluded"."comment","review_date"="excluded"."review_date","reviewer_info"="excluded"."reviewer_info","moderation_rules"="excluded"."moderation_rules",`+
+		`"status"=CASE WHEN `+reflagged+` THEN excluded.status ELSE reviews.status END,`+
+		`"moderation_reason"=CASE WHEN `+reflagged+` THEN excluded.moderation_reason ELSE reviews.moderation_reason END,`+
+		`"moderated_by"=CASE WHEN `+reflagged+` THEN excluded.moderated_by ELSE reviews.moderated_by END,`+
+		`"moderated_at"=CASE WHEN `+reflagged+` THEN excluded.moderated_at ELSE reviews.moderated_at END,`+
+		`"updated_at"=CASE WHEN (reviews.rating, reviews.title, reviews.comment, reviews.review_date, reviews.reviewer_info, reviews.moderation_rules) IS DISTINCT FROM (excluded.rating, excluded.title, excluded.comment, excluded.review_date, excluded.reviewer_info, excluded.moderation_rules) OR `+reflagged+` THEN excluded.updated_at ELSE reviews.updated_at END`)
+}
+
+func TestUpsertReview_AutoModerationFlag(t *testing.T) {
+	moderatedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		stored         models.Review
+		comment        string
+		expectedStatus string
+	}{
+		{
+			name:           "pending review is flagged",
+			stored:         models.Review{Status: models.ReviewStatusPending},
+			comment:        "Call me on 555",
+			expectedStatus: models.ReviewStatusFlagged,
+		},
+		{
+			name:           "rejected review stays rejected",
+			stored:         models.Review{Status: models.ReviewStatusRejected, ModerationReason: "spam", ModeratedBy: "alex", ModeratedAt: &moderatedAt},
+			comment:        "Call me on 555",
+			expectedStatus: models.ReviewStatusRejected,
+		},
+		{
+			name:           "approved review with the same text stays published",
+			stored:         models.Review{Status: models.ReviewStatusPublished, Comment: "Call me on 555", ModeratedBy: "alex", ModeratedAt: &moderatedAt},
+			comment:        "Call me on 555",
+			expectedStatus: models.ReviewStatusPublished,
+		},
+		{
+			name:           "approved review with new text is flagged",
+			stored:         models.Review{Status: models.ReviewStatusPublished, Comment: "Lovely stay", ModeratedBy: "alex", ModeratedAt: &moderatedAt},
+			comment:        "Call me on 555",
+			expectedStatus: models.ReviewStatusFlagged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			repo := &reviewRepository{db: db}
+
+			provider := &models.Provider{Name: "Upsert Test Provider"}
+			require.NoError(t, db.Create(provider).Error)
+			hotel := &models.Hotel{HotelName: "Upsert Test Hotel"}
+			require.NoError(t, db.Create(hotel).Error)
+
+			stored := tt.stored
+			stored.ID, stored.ProviderID, stored.HotelID, stored.Rating, stored.ReviewDate = 900000001, provider.ID, hotel.ID, 8, moderatedAt
+			require.NoError(t, db.Create(&stored).Error)
+
+			now := time.Now()
+			require.NoError(t, repo.UpsertReview(&models.Review{
+				ID: stored.ID, ProviderID: provider.ID, HotelID: hotel.ID, Rating: 8, Comment: tt.comment, ReviewDate: moderatedAt,
+				Status: models.ReviewStatusFlagged, ModerationReason: "Matched moderation rules: blocklist", ModeratedBy: "auto-moderation", ModeratedAt: &now,
+			}))
+
+			var review models.Review
+			require.NoError(t, db.First(&review, stored.ID).Error)
+			assert.Equal(t, tt.expectedStatus, review.Status)
+			if tt.expectedStatus == models.ReviewStatusFlagged {
+				assert.Equal(t, "auto-moderation", review.ModeratedBy)
+				assert.Equal(t, "Matched moderation rules: blocklist", review.ModerationReason)
+			} else {
+				assert.Equal(t, stored.ModeratedBy, review.ModeratedBy)
+			}
+		})
+	}
+}
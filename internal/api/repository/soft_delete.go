@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	models "github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm"
+)
+
+// Unscoped returns a repository that also sees soft-deleted rows. Deletes
+// through it are permanent.
+func (r *reviewRepository) Unscoped() ReviewRepository {
+	return &reviewRepository{db: r.db.Unscoped().Session(&gorm.Session{})}
+}
+
+// softDeleteWithChildren soft-deletes a hotel or provider along with its live
+// reviews and provider-hotel mappings, where foreignKey refers to it. They all
+// get the same deletion time, which is how restoring the parent finds them.
+func (r *reviewRepository) softDeleteWithChildren(model interface{}, id uint, foreignKey string) error {
+	// Postgres keeps microseconds, so the time is truncated to compare equal
+	// once stored
+	deletedAt := time.Now().Truncate(time.Microsecond)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(model).Where("id = ?", id).Update("deleted_at", deletedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		for _, child := range []interface{}{&models.Review{}, &models.ProviderHotel{}} {
+			if err := tx.Model(child).Where(foreignKey+" = ?", id).Update("deleted_at", deletedAt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// restoreWithChildren restores a soft-deleted hotel or provider along with the
+// reviews and provider-hotel mappings deleted with it. Children deleted on
+// their own before the parent stay deleted.
+func (r *reviewRepository) restoreWithChildren(model interface{}, id uint, foreignKey string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var deletedAt []time.Time
+		if err := tx.Unscoped().Model(model).Where("id = ? AND deleted_at IS NOT NULL", id).Pluck("deleted_at", &deletedAt).Error; err != nil {
+			return err
+		}
+		if len(deletedAt) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Unscoped().Model(model).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		for _, child := range []interface{}{&models.Review{}, &models.ProviderHotel{}} {
+			if err := tx.Unscoped().Model(child).
+				Where(foreignKey+" = ? AND deleted_at = ?", id, deletedAt[0]).
+				Update("deleted_at", nil).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PurgeDeleted permanently deletes the rows soft-deleted before the given
+// time, children first. A hotel or provider is only purged once none of its
+// reviews or mappings are left, so the cascading foreign keys never remove
+// rows that are still live or still within the retention window.
+func (r *reviewRepository) PurgeDeleted(before time.Time) (*dto.PurgeResult, error) {
+	result := &dto.PurgeResult{DeletedBefore: before}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Unscoped().Session(&gorm.Session{})
+
+		purged := tx.Where("deleted_at < ?", before).Delete(&models.Review{})
+		if purged.Error != nil {
+			return purged.Error
+		}
+		result.Reviews = purged.RowsAffected
+
+		purged = tx.Where("deleted_at < ?", before).Delete(&models.ProviderHotel{})
+		if purged.Error != nil {
+			return purged.Error
+		}
+		result.ProviderHotels = purged.RowsAffected
+
+		purged = tx.Where("deleted_at < ?", before).
+			Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.hotel_id = hotels.id)").
+			Where("NOT EXISTS (SELECT 1 FROM provider_hotels WHERE provider_hotels.hotel_id = hotels.id)").
+			Delete(&models.Hotel{})
+		if purged.Error != nil {
+			return purged.Error
+		}
+		result.Hotels = purged.RowsAffected
+
+		purged = tx.Where("deleted_at < ?", before).
+			Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.provider_id = providers.id)").
+			Where("NOT EXISTS (SELECT 1 FROM provider_hotels WHERE provider_hotels.provider_id = providers.id)").
+			Delete(&models.Provider{})
+		if purged.Error != nil {
+			return purged.Error
+		}
+		result.Providers = purged.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
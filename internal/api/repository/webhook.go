@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	models "github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm"
+)
+
+// GetWebhookSubscriptionsList retrieves webhook subscriptions, oldest first.
+func (r *reviewRepository) GetWebhookSubscriptionsList(queryParams *dto.WebhookSubscriptionsQueryParams) ([]*models.WebhookSubscription, int, error) {
+	var subscriptions []*models.WebhookSubscription
+	var totalCount int64
+
+	dbQuery := r.db.Model(&models.WebhookSubscription{})
+	if queryParams.EventType != "" {
+		eventTypes, err := json.Marshal([]string{queryParams.EventType})
+		if err != nil {
+			return nil, 0, err
+		}
+		dbQuery = dbQuery.Where("event_types @> ?", string(eventTypes))
+	}
+	if queryParams.HotelID != 0 {
+		dbQuery = dbQuery.Where("hotel_id = ?", queryParams.HotelID)
+	}
+	if queryParams.ProviderID != 0 {
+		dbQuery = dbQuery.Where("provider_id = ?", queryParams.ProviderID)
+	}
+
+	// Get total count using the same conditions
+	if err := dbQuery.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := dbQuery.
+		Order("id").
+		Offset(queryParams.Offset).
+		Limit(queryParams.Limit).
+		Find(&subscriptions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return subscriptions, int(totalCount), nil
+}
+
+func (r *reviewRepository) GetWebhookSubscriptionByID(id uint) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := r.db.First(&subscription, id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetWebhookSubscriptionsByIDs retrieves the subscriptions with the given IDs.
+// IDs that do not exist are skipped.
+func (r *reviewRepository) GetWebhookSubscriptionsByIDs(ids []uint) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	if err := r.db.Where("id IN ?", ids).Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// GetMatchingWebhookSubscriptions retrieves the enabled subscriptions to an
+// event type whose hotel and provider, when set, are those of the event.
+func (r *reviewRepository) GetMatchingWebhookSubscriptions(eventType string, hotelID, providerID uint) ([]*models.WebhookSubscription, error) {
+	eventTypes, err := json.Marshal([]string{eventType})
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []*models.WebhookSubscription
+	if err := r.db.
+		Where("disabled = ?", false).
+		Where("event_types @> ?", string(eventTypes)).
+		Where("hotel_id = 0 OR hotel_id = ?", hotelID).
+		Where("provider_id = 0 OR provider_id = ?", providerID).
+		Order("id").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *reviewRepository) CreateWebhookSubscription(subscription *models.WebhookSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+func (r *reviewRepository) UpdateWebhookSubscription(subscription *models.WebhookSubscription) error {
+	return r.db.Save(subscription).Error
+}
+
+// DeleteWebhookSubscription deletes a subscription along with its deliveries.
+func (r *reviewRepository) DeleteWebhookSubscription(id uint) error {
+	return r.db.Delete(&models.WebhookSubscription{}, id).Error
+}
+
+// GetWebhookDeliveriesList retrieves the delivery log, newest first.
+func (r *reviewRepository) GetWebhookDeliveriesList(queryParams *dto.WebhookDeliveriesQueryParams) ([]*models.WebhookDelivery, int, error) {
+	var deliveries []*models.WebhookDelivery
+	var totalCount int64
+
+	dbQuery := r.db.Model(&models.WebhookDelivery{})
+	if queryParams.SubscriptionID != 0 {
+		dbQuery = dbQuery.Where("subscription_id = ?", queryParams.SubscriptionID)
+	}
+	if queryParams.EventType != "" {
+		dbQuery = dbQuery.Where("event_type = ?", queryParams.EventType)
+	}
+	if queryParams.EventID != "" {
+		dbQuery = dbQuery.Where("event_id = ?", queryParams.EventID)
+	}
+	if queryParams.Status != "" {
+		dbQuery = dbQuery.Where("status = ?", queryParams.Status)
+	}
+
+	// Get total count using the same conditions
+	if err := dbQuery.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := dbQuery.
+		Order("created_at desc, id desc").
+		Offset(queryParams.Offset).
+		Limit(queryParams.Limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, int(totalCount), nil
+}
+
+func (r *reviewRepository) GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.First(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *reviewRepository) CreateWebhookDeliveries(deliveries []*models.WebhookDelivery) error {
+	return r.db.Create(&deliveries).Error
+}
+
+// UpdateWebhookDelivery saves a delivery unless its lease is no longer
+// leasedUntil, because another dispatcher claimed it since.
+func (r *reviewRepository) UpdateWebhookDelivery(delivery *models.WebhookDelivery, leasedUntil time.Time) (bool, error) {
+	result := r.db.Model(delivery).
+		Where("next_attempt_at = ?", leasedUntil).
+		Select("*").
+		Updates(delivery)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ClaimDueWebhookDeliveries claims up to limit pending deliveries that are due
+// at now, oldest first, by moving their next attempt lease into the future.
+// Rows another dispatcher is claiming are skipped, so every delivery is only
+// claimed once.
+func (r *reviewRepository) ClaimDueWebhookDeliveries(now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.Raw(`UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at, id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), models.WebhookDeliveryPending, now, limit).
+		Scan(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	models "github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestUpdateWebhookDelivery_OnlyWhileLeased(t *testing.T) {
+	db := newDryRunDB(t)
+	repo := &reviewRepository{db: db}
+	query := &capturedQuery{}
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture", func(tx *gorm.DB) {
+		query.SQL, query.Vars = tx.Statement.SQL.String(), tx.Statement.Vars
+	}))
+	leasedUntil := time.Date(2024, 5, 1, 12, 2, 0, 0, time.UTC)
+
+	repo.UpdateWebhookDelivery(&models.WebhookDelivery{ID: 7, Status: models.WebhookDeliverySucceeded}, leasedUntil)
+
+	assert.Contains(t, query.SQL, `WHERE next_attempt_at = $`)
+	assert.Contains(t, query.SQL, `AND "id" = $`)
+	assert.Contains(t, query.SQL, `"next_attempt_at"=$`)
+	assert.Contains(t, query.Vars, leasedUntil)
+}
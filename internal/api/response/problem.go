@@ -0,0 +1,198 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gorilla/schema"
+)
+
+// ProblemContentType is the media type of error responses, RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix prefixes the error code to form the problem type URI.
+const problemTypePrefix = "urn:review-system:problem:"
+
+// Error codes identify the kind of failure in a stable, machine-readable way.
+// Clients should branch on the code rather than on the detail text.
+const (
+	CodeBadRequest          = "bad_request"
+	CodeMalformedRequest    = "malformed_request"
+	CodeValidationFailed    = "validation_failed"
+	CodeUnauthorized        = "unauthorized"
+	CodeForbidden           = "forbidden"
+	CodeNotFound            = "not_found"
+	CodeMethodNotAllowed    = "method_not_allowed"
+	CodeConflict            = "conflict"
+	CodeAlreadyExists       = "already_exists"
+	CodeNotDeleted          = "not_deleted"
+	CodeParentDeleted       = "parent_deleted"
+	CodeInvalidTransition   = "invalid_status_transition"
+	CodeProviderManaged     = "provider_managed"
+	CodePreconditionFailed  = "precondition_failed"
+	CodeInternalServerError = "internal_error"
+)
+
+// statusCodes are the error codes used when a failure has no more specific one.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          CodeBadRequest,
+	http.StatusUnauthorized:        CodeUnauthorized,
+	http.StatusForbidden:           CodeForbidden,
+	http.StatusNotFound:            CodeNotFound,
+	http.StatusMethodNotAllowed:    CodeMethodNotAllowed,
+	http.StatusConflict:            CodeConflict,
+	http.StatusPreconditionFailed:  CodePreconditionFailed,
+	http.StatusInternalServerError: CodeInternalServerError,
+}
+
+// Problem is an RFC 7807 problem details object, extended with an error code,
+// the trace ID of the request and the fields that failed validation.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	TraceID  string       `json:"trace_id,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is a problem with one field of the request body or query.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// NewProblem returns the problem for a failure with the given status. An empty
+// code falls back to the generic code of the status.
+func NewProblem(statusCode int, code, detail string) *Problem {
+	if code == "" {
+		code = StatusErrorCode(statusCode)
+	}
+	return &Problem{
+		Type:   problemTypePrefix + code,
+		Title:  http.StatusText(statusCode),
+		Status: statusCode,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// StatusErrorCode returns the generic error code of an HTTP status.
+func StatusErrorCode(statusCode int) string {
+	if code, ok := statusCodes[statusCode]; ok {
+		return code
+	}
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(statusCode)), " ", "_")
+}
+
+// WriteProblem writes problem as the response to r, tagged with the trace ID
+// of the request.
+func WriteProblem(w http.ResponseWriter, r *http.Request, problem *Problem) error {
+	if r != nil {
+		problem.Instance = r.URL.Path
+		problem.TraceID = TraceID(r.Context())
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	return json.NewEncoder(w).Encode(problem)
+}
+
+// WriteError writes a problem with the generic code of statusCode.
+func WriteError(w http.ResponseWriter, r *http.Request, statusCode int, detail string) error {
+	return WriteProblem(w, r, NewProblem(statusCode, "", detail))
+}
+
+// WriteErrorDetails writes the error a service returned. Only the message
+// reaches the client; the underlying error never does.
+func WriteErrorDetails(w http.ResponseWriter, r *http.Request, details *ErrorDetails) error {
+	problem := NewProblem(details.Code, details.ErrorCode, details.Message)
+	problem.Errors = details.Fields
+	return WriteProblem(w, r, problem)
+}
+
+// ValidationErrorDetails returns the details of a request whose field failed
+// validation.
+func ValidationErrorDetails(field, message string, err error) *ErrorDetails {
+	return &ErrorDetails{
+		Code:      http.StatusBadRequest,
+		ErrorCode: CodeValidationFailed,
+		Message:   message,
+		Error:     err,
+		Fields:    []FieldError{{Field: field, Message: message}},
+	}
+}
+
+// DecodeErrorDetails returns the details of a request body or query that
+// could not be decoded, naming the offending fields where the decoder does.
+func DecodeErrorDetails(message string, err error) *ErrorDetails {
+	details := &ErrorDetails{
+		Code:      http.StatusBadRequest,
+		ErrorCode: CodeMalformedRequest,
+		Message:   message,
+		Error:     err,
+	}
+
+	var multiErr schema.MultiError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &multiErr):
+		for field, fieldErr := range multiErr {
+			details.Fields = append(details.Fields, FieldError{Field: field, Message: queryFieldMessage(fieldErr)})
+		}
+		// Map order is random, so fields are sorted for a stable response
+		slices.SortFunc(details.Fields, func(a, b FieldError) int {
+			return strings.Compare(a.Field, b.Field)
+		})
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		details.Fields = []FieldError{{Field: typeErr.Field, Message: "must be " + jsonTypeName(typeErr.Type)}}
+	}
+	return details
+}
+
+// jsonTypeName describes the JSON value a Go type decodes from.
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	}
+	return "a valid value"
+}
+
+// queryFieldMessage describes a query parameter decoding error without the
+// Go types and values the decoder puts in its messages.
+func queryFieldMessage(err error) string {
+	var conversionErr schema.ConversionError
+	var unknownErr schema.UnknownKeyError
+	var emptyErr schema.EmptyFieldError
+	switch {
+	case errors.As(err, &conversionErr):
+		if conversionErr.Err != nil {
+			return conversionErr.Err.Error()
+		}
+		return "invalid value"
+	case errors.As(err, &unknownErr):
+		return "unknown parameter"
+	case errors.As(err, &emptyErr):
+		return "is required"
+	}
+	return "invalid value"
+}
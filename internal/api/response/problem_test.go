@@ -0,0 +1,92 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteErrorDetails(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/hotels/1", nil)
+	req = req.WithContext(WithTraceID(req.Context(), "trace-1"))
+	rr := httptest.NewRecorder()
+
+	err := WriteErrorDetails(rr, req, &ErrorDetails{
+		Code:    http.StatusInternalServerError,
+		Message: "Internal server error",
+		Error:   errors.New(`pq: relation "hotels" does not exist`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, ProblemContentType, rr.Header().Get("Content-Type"))
+	assert.NotContains(t, rr.Body.String(), "relation")
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, Problem{
+		Type:     "urn:review-system:problem:internal_error",
+		Title:    "Internal Server Error",
+		Status:   http.StatusInternalServerError,
+		Detail:   "Internal server error",
+		Instance: "/api/v1/hotels/1",
+		Code:     CodeInternalServerError,
+		TraceID:  "trace-1",
+	}, problem)
+}
+
+func TestValidationErrorDetails(t *testing.T) {
+	details := ValidationErrorDetails("rating", "rating must be between 0 and 10", errors.New("invalid"))
+
+	assert.Equal(t, http.StatusBadRequest, details.Code)
+	assert.Equal(t, CodeValidationFailed, details.ErrorCode)
+	assert.Equal(t, []FieldError{{Field: "rating", Message: "rating must be between 0 and 10"}}, details.Fields)
+}
+
+func TestDecodeErrorDetails(t *testing.T) {
+	t.Run("query", func(t *testing.T) {
+		var params struct {
+			Limit  int     `schema:"limit"`
+			Rating float64 `schema:"min_rating"`
+		}
+		err := schema.NewDecoder().Decode(&params, map[string][]string{"limit": {"ten"}, "min_rating": {"high"}})
+
+		details := DecodeErrorDetails("Invalid query parameters", err)
+
+		assert.Equal(t, http.StatusBadRequest, details.Code)
+		assert.Equal(t, CodeMalformedRequest, details.ErrorCode)
+		assert.Equal(t, []FieldError{
+			{Field: "limit", Message: "invalid value"},
+			{Field: "min_rating", Message: "invalid value"},
+		}, details.Fields)
+	})
+
+	t.Run("body", func(t *testing.T) {
+		var body struct {
+			Rating float64 `json:"rating"`
+		}
+		err := json.Unmarshal([]byte(`{"rating": "ten"}`), &body)
+
+		details := DecodeErrorDetails("Invalid request body", err)
+
+		assert.Equal(t, []FieldError{{Field: "rating", Message: "must be a number"}}, details.Fields)
+	})
+
+	t.Run("syntax", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{`), &struct{}{})
+
+		details := DecodeErrorDetails("Invalid request body", err)
+
+		assert.Empty(t, details.Fields)
+	})
+}
+
+func TestStatusErrorCode(t *testing.T) {
+	assert.Equal(t, CodeNotFound, StatusErrorCode(http.StatusNotFound))
+	assert.Equal(t, "unsupported_media_type", StatusErrorCode(http.StatusUnsupportedMediaType))
+}
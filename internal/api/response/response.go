@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type HTTPResponse struct {
@@ -13,10 +14,12 @@ type HTTPResponse struct {
 }
 
 type HTTPResponseContent struct {
-	Count    int         `json:"count"`
-	Previous *string     `json:"prev"`
-	Next     *string     `json:"next"`
-	Results  interface{} `json:"results"`
+	Count      int         `json:"count"`
+	Previous   *string     `json:"prev"`
+	Next       *string     `json:"next"`
+	PrevCursor *string     `json:"prev_cursor,omitempty"`
+	NextCursor *string     `json:"next_cursor,omitempty"`
+	Results    interface{} `json:"results"`
 }
 
 // Error details will be returned by a service function to the handler
@@ -24,6 +27,11 @@ type ErrorDetails struct {
 	Code    int
 	Message string
 	Error   error
+	// ErrorCode is the machine-readable code of the failure. When empty, the
+	// generic code of the HTTP status is used.
+	ErrorCode string
+	// Fields are the fields of the request that failed validation.
+	Fields []FieldError
 }
 
 // StatusCode refer to Http Code
@@ -36,11 +44,58 @@ func WriteHTTPResponse(w http.ResponseWriter, statusCode int, responseBody *HTTP
 	return json.NewEncoder(w).Encode(responseBody)
 }
 
-// Code refer to Application Code
-func GetErrorHTTPResponseBody(code int, message string) *HTTPResponse {
-	return &HTTPResponse{
-		Code:    code,
-		Message: message,
-		Content: map[string]interface{}{},
+// SetLastModified sets the Last-Modified header of a response to updatedAt,
+// unless it is zero. HTTP dates have second precision.
+func SetLastModified(w http.ResponseWriter, updatedAt time.Time) {
+	if updatedAt.IsZero() {
+		return
 	}
+	w.Header().Set("Last-Modified", updatedAt.UTC().Format(http.TimeFormat))
+}
+
+// SelectFields trims content down to the given top-level fields. Content must
+// encode to a JSON object or an array of objects. Without fields content is
+// returned unchanged.
+func SelectFields(content interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return content, nil
+	}
+
+	body, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, err
+	}
+
+	switch value := decoded.(type) {
+	case nil:
+		return content, nil
+	case map[string]interface{}:
+		return selectObjectFields(value, fields), nil
+	case []interface{}:
+		for i, item := range value {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("can not select fields of %T", item)
+			}
+			value[i] = selectObjectFields(object, fields)
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("can not select fields of %T", decoded)
+	}
+}
+
+func selectObjectFields(object map[string]interface{}, fields []string) map[string]interface{} {
+	selected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if value, ok := object[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -36,9 +37,54 @@ func TestWriteHTTPResponse(t *testing.T) {
 	})
 }
 
-func TestGetErrorHTTPResponseBody(t *testing.T) {
-	responseBody := GetErrorHTTPResponseBody(http.StatusBadRequest, "Bad Request")
-	assert.Equal(t, http.StatusBadRequest, responseBody.Code)
-	assert.Equal(t, "Bad Request", responseBody.Message)
-	assert.Equal(t, map[string]interface{}{}, responseBody.Content)
+func TestSelectFields(t *testing.T) {
+	type item struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Score int    `json:"score"`
+	}
+
+	t.Run("object", func(t *testing.T) {
+		selected, err := SelectFields(&item{ID: 1, Name: "a", Score: 5}, []string{"id", "score", "missing"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": float64(1), "score": float64(5)}, selected)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		selected, err := SelectFields([]item{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, []string{"name"})
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b"},
+		}, selected)
+	})
+
+	t.Run("no fields", func(t *testing.T) {
+		content := &item{ID: 1}
+		selected, err := SelectFields(content, nil)
+		assert.NoError(t, err)
+		assert.Same(t, content, selected)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var content []item
+		selected, err := SelectFields(content, []string{"id"})
+		assert.NoError(t, err)
+		assert.Nil(t, selected)
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		_, err := SelectFields(42, []string{"id"})
+		assert.Error(t, err)
+	})
+}
+
+func TestSetLastModified(t *testing.T) {
+	rr := httptest.NewRecorder()
+	SetLastModified(rr, time.Date(2025, 4, 1, 14, 30, 15, 500, time.FixedZone("CEST", 2*60*60)))
+	assert.Equal(t, "Tue, 01 Apr 2025 12:30:15 GMT", rr.Header().Get("Last-Modified"))
+
+	rr = httptest.NewRecorder()
+	SetLastModified(rr, time.Time{})
+	assert.Empty(t, rr.Header().Get("Last-Modified"))
 }
@@ -0,0 +1,19 @@
+package response
+
+import "context"
+
+// TraceHeader carries the trace ID of a request, both ways.
+const TraceHeader = "X-Request-Id"
+
+type traceIDContextKey struct{}
+
+// WithTraceID attaches a trace ID to a request context.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDContextKey{}, traceID)
+}
+
+// TraceID returns the trace ID attached to a request context, or "".
+func TraceID(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDContextKey{}).(string)
+	return traceID
+}
@@ -1,13 +1,18 @@
 package api
 
 import (
+	"net/http"
+
 	"github.com/gorilla/mux"
+	"github.com/kirananto/review-system/internal/api/graphql"
 	"github.com/kirananto/review-system/internal/api/handler"
 	"github.com/kirananto/review-system/internal/api/middleware"
 	"github.com/kirananto/review-system/internal/api/repository"
+	"github.com/kirananto/review-system/internal/api/response"
 	"github.com/kirananto/review-system/internal/api/service"
 	"github.com/kirananto/review-system/internal/db"
 	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/webhook"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
@@ -31,12 +36,49 @@ func getProviderHotelHandler(dataSource *db.DataSource, log *logger.Logger) *han
 
 func getReviewHandler(dataSource *db.DataSource, log *logger.Logger) *handler.ReviewHandler {
 	repository := repository.NewReviewRepository(dataSource)
-	service := service.NewReviewService(repository, log)
+	service := service.NewReviewService(repository, log, nil, newDispatcher(repository, log), nil)
 	return handler.NewReviewHandler(service, log)
 }
 
+func getWebhookHandler(dataSource *db.DataSource, log *logger.Logger) *handler.WebhookHandler {
+	repository := repository.NewReviewRepository(dataSource)
+	service := service.NewWebhookService(repository, log, newDispatcher(repository, log))
+	return handler.NewWebhookHandler(service, log)
+}
+
+// newDispatcher builds the dispatcher that queues and delivers webhooks.
+func newDispatcher(repository repository.ReviewRepository, log *logger.Logger) *webhook.Dispatcher {
+	return webhook.NewDispatcher(repository, webhook.NewClient(webhook.DefaultTimeout), log)
+}
+
+func getGraphQLHandler(dataSource *db.DataSource, log *logger.Logger) *graphql.Handler {
+	repository := repository.NewReviewRepository(dataSource)
+	return graphql.NewHandler(graphql.Services{
+		Hotels:         service.NewHotelService(repository, log),
+		Providers:      service.NewProviderService(repository, log),
+		ProviderHotels: service.NewProviderHotelService(repository, log),
+		Reviews:        service.NewReviewService(repository, log, nil, nil, nil),
+		AuditLogs:      service.NewAuditLogService(repository, log),
+	}, log)
+}
+
+// Unmatched requests skip the router's middleware, so these are traced on
+// their own.
+var (
+	notFoundHandler = middleware.Trace(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.WriteError(w, r, http.StatusNotFound, "No route matches this path")
+	}))
+	methodNotAllowedHandler = middleware.Trace(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.WriteError(w, r, http.StatusMethodNotAllowed, "This method is not allowed on this path")
+	}))
+)
+
 func SetUpRoutes(dataSource *db.DataSource, log *logger.Logger) *mux.Router {
 	r := mux.NewRouter()
+	r.Use(middleware.Trace)
+
+	r.NotFoundHandler = notFoundHandler
+	r.MethodNotAllowedHandler = methodNotAllowedHandler
 
 	// Swagger documentation
 	r.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
@@ -48,28 +90,90 @@ func SetUpRoutes(dataSource *db.DataSource, log *logger.Logger) *mux.Router {
 
 	// API routes
 	api := r.PathPrefix("/api/v1").Subrouter()
-	api.Use(middleware.Auth)
+	api.Use(middleware.ConditionalGet, middleware.Compress, middleware.Auth)
+	api.NotFoundHandler = notFoundHandler
+	api.MethodNotAllowedHandler = methodNotAllowedHandler
 
 	// Initialize handlers
 	providerHandler := getProviderHandler(dataSource, log)
 	hotelHandler := getHotelHandler(dataSource, log)
 	providerHotelHandler := getProviderHotelHandler(dataSource, log)
 	reviewHandler := getReviewHandler(dataSource, log)
+	webhookHandler := getWebhookHandler(dataSource, log)
+	nestedHandler := handler.NewNestedHandler(hotelHandler, providerHandler, reviewHandler)
+
+	// The catalogue and its reviews are read by every key and written by
+	// admin keys only
 
 	// Provider routes
 	api.HandleFunc("/providers", providerHandler.GetProvidersList).Methods("GET")
+	api.HandleFunc("/providers", middleware.RequireAdmin(providerHandler.CreateProvider)).Methods("POST")
 	api.HandleFunc("/providers/{id:[0-9]+}", providerHandler.GetProvider).Methods("GET")
+	api.HandleFunc("/providers/{id:[0-9]+}", middleware.RequireAdmin(providerHandler.UpdateProvider)).Methods("PUT")
+	api.HandleFunc("/providers/{id:[0-9]+}", middleware.RequireAdmin(providerHandler.DeleteProvider)).Methods("DELETE")
+	api.HandleFunc("/providers/{id:[0-9]+}/restore", middleware.RequireAdmin(providerHandler.RestoreProvider)).Methods("POST")
+	api.HandleFunc("/providers/{id:[0-9]+}/hotels", nestedHandler.GetProviderHotels).Methods("GET")
+	api.HandleFunc("/providers/{id:[0-9]+}/reviews", nestedHandler.GetProviderReviews).Methods("GET")
 
 	// Hotel routes
 	api.HandleFunc("/hotels", hotelHandler.GetHotelsList).Methods("GET")
+	api.HandleFunc("/hotels", middleware.RequireAdmin(hotelHandler.CreateHotel)).Methods("POST")
 	api.HandleFunc("/hotels/{id:[0-9]+}", hotelHandler.GetHotel).Methods("GET")
+	api.HandleFunc("/hotels/{id:[0-9]+}", middleware.RequireAdmin(hotelHandler.UpdateHotel)).Methods("PUT")
+	api.HandleFunc("/hotels/{id:[0-9]+}", middleware.RequireAdmin(hotelHandler.DeleteHotel)).Methods("DELETE")
+	api.HandleFunc("/hotels/{id:[0-9]+}/restore", middleware.RequireAdmin(hotelHandler.RestoreHotel)).Methods("POST")
+	api.HandleFunc("/hotels/{id:[0-9]+}/summary", hotelHandler.GetHotelSummary).Methods("GET")
+	api.HandleFunc("/hotels/{id:[0-9]+}/reviews", nestedHandler.GetHotelReviews).Methods("GET")
+	api.HandleFunc("/hotels/{id:[0-9]+}/providers", nestedHandler.GetHotelProviders).Methods("GET")
+	api.HandleFunc("/hotels/{id:[0-9]+}/ratings/timeseries", hotelHandler.GetHotelRatingTimeseries).Methods("GET")
+	api.HandleFunc("/hotels/{id:[0-9]+}/responses/metrics", hotelHandler.GetHotelResponseMetrics).Methods("GET")
 
 	// ProviderHotel routes
 	api.HandleFunc("/provider-hotels", providerHotelHandler.GetProviderHotelsList).Methods("GET")
+	api.HandleFunc("/provider-hotels", middleware.RequireAdmin(providerHotelHandler.CreateProviderHotel)).Methods("POST")
+	api.HandleFunc("/provider-hotels/{provider_id:[0-9]+}/{hotel_id:[0-9]+}", providerHotelHandler.GetProviderHotel).Methods("GET")
+	api.HandleFunc("/provider-hotels/{provider_id:[0-9]+}/{hotel_id:[0-9]+}", middleware.RequireAdmin(providerHotelHandler.UpdateProviderHotel)).Methods("PUT")
+	api.HandleFunc("/provider-hotels/{provider_id:[0-9]+}/{hotel_id:[0-9]+}", middleware.RequireAdmin(providerHotelHandler.DeleteProviderHotel)).Methods("DELETE")
+	api.HandleFunc("/provider-hotels/{provider_id:[0-9]+}/{hotel_id:[0-9]+}/restore", middleware.RequireAdmin(providerHotelHandler.RestoreProviderHotel)).Methods("POST")
 
 	// Review routes
 	api.HandleFunc("/reviews", reviewHandler.GetReviewsList).Methods("GET")
+	api.HandleFunc("/reviews", middleware.RequireAdmin(reviewHandler.CreateReview)).Methods("POST")
 	api.HandleFunc("/reviews/{id:[0-9]+}", reviewHandler.GetReview).Methods("GET")
+	api.HandleFunc("/reviews/{id:[0-9]+}", middleware.RequireAdmin(reviewHandler.UpdateReview)).Methods("PUT")
+	api.HandleFunc("/reviews/{id:[0-9]+}", middleware.RequireAdmin(reviewHandler.PatchReview)).Methods("PATCH")
+	api.HandleFunc("/reviews/{id:[0-9]+}", middleware.RequireAdmin(reviewHandler.DeleteReview)).Methods("DELETE")
+	api.HandleFunc("/reviews/{id:[0-9]+}/restore", middleware.RequireAdmin(reviewHandler.RestoreReview)).Methods("POST")
+	api.HandleFunc("/reviews/{id:[0-9]+}/{action:approve|reject|flag}", middleware.RequireAdmin(reviewHandler.ModerateReview)).Methods("POST")
+
+	// Hotel responses to reviews
+	api.HandleFunc("/reviews/{id:[0-9]+}/response", reviewHandler.GetReviewResponse).Methods("GET")
+	api.HandleFunc("/reviews/{id:[0-9]+}/response", reviewHandler.CreateReviewResponse).Methods("POST")
+	api.HandleFunc("/reviews/{id:[0-9]+}/response", reviewHandler.UpdateReviewResponse).Methods("PUT")
+	api.HandleFunc("/reviews/{id:[0-9]+}/response", reviewHandler.DeleteReviewResponse).Methods("DELETE")
+
+	// Moderation routes
+	api.HandleFunc("/moderation/reviews", middleware.RequireAdmin(reviewHandler.GetModerationQueue)).Methods("GET")
+	api.HandleFunc("/moderation/reviews/{action:approve|reject|flag}", middleware.RequireAdmin(reviewHandler.ModerateReviews)).Methods("POST")
+
+	// Export routes
+	api.HandleFunc("/exports/reviews", reviewHandler.ExportReviews).Methods("GET")
+
+	// Search routes
+	api.HandleFunc("/search/reviews", reviewHandler.SearchReviews).Methods("GET")
+
+	// Webhook routes
+	api.HandleFunc("/webhooks", middleware.RequireAdmin(webhookHandler.GetWebhookSubscriptionsList)).Methods("GET")
+	api.HandleFunc("/webhooks", middleware.RequireAdmin(webhookHandler.CreateWebhookSubscription)).Methods("POST")
+	api.HandleFunc("/webhooks/deliveries", middleware.RequireAdmin(webhookHandler.GetWebhookDeliveriesList)).Methods("GET")
+	api.HandleFunc("/webhooks/deliveries/{id:[0-9]+}", middleware.RequireAdmin(webhookHandler.GetWebhookDelivery)).Methods("GET")
+	api.HandleFunc("/webhooks/deliveries/{id:[0-9]+}/replay", middleware.RequireAdmin(webhookHandler.ReplayWebhookDelivery)).Methods("POST")
+	api.HandleFunc("/webhooks/{id:[0-9]+}", middleware.RequireAdmin(webhookHandler.GetWebhookSubscription)).Methods("GET")
+	api.HandleFunc("/webhooks/{id:[0-9]+}", middleware.RequireAdmin(webhookHandler.UpdateWebhookSubscription)).Methods("PUT")
+	api.HandleFunc("/webhooks/{id:[0-9]+}", middleware.RequireAdmin(webhookHandler.DeleteWebhookSubscription)).Methods("DELETE")
+
+	// GraphQL
+	api.Handle("/graphql", getGraphQLHandler(dataSource, log)).Methods("GET", "POST")
 
 	return r
 }
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/db"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// testAPIKeys are the API keys the tests call with.
+var testAPIKeys = []middleware.APIKey{
+	{Key: "secret", Name: "client"},
+	{Key: "admin-secret", Name: "admin", Scopes: []string{middleware.ScopeAdmin}},
+	{Key: "hotel-secret", Name: "hotel", Scopes: []string{middleware.ScopeRespond}, HotelID: 1},
+}
+
+func TestMain(m *testing.M) {
+	middleware.SetAPIKeys(testAPIKeys)
+	os.Exit(m.Run())
+}
+
+// newTestRouter returns the API routes over a database that builds queries
+// without running them, for tests that stop before the database matters.
+func newTestRouter(t *testing.T) http.Handler {
+	gormDB, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true, Logger: gormlogger.Discard})
+	require.NoError(t, err)
+	return SetUpRoutes(&db.DataSource{Db: gormDB}, logger.NewLogger(&logger.LogConfig{LogLevel: "info"}))
+}
+
+// serve sends a request with a malformed body as apiKey. Handlers reject the
+// body and invalidID before reaching the database, so a request that gets past
+// the scope check answers 400 rather than 403.
+func serve(router http.Handler, apiKey, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader("{"))
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+// invalidID matches the ID patterns of the routes, but overflows an int.
+const invalidID = "99999999999999999999"
+
+// writeRoutes are the routes that change the catalogue or its reviews.
+var writeRoutes = []struct {
+	method string
+	path   string
+}{
+	{http.MethodPost, "/api/v1/providers"},
+	{http.MethodPut, "/api/v1/providers/" + invalidID},
+	{http.MethodDelete, "/api/v1/providers/" + invalidID},
+	{http.MethodPost, "/api/v1/hotels"},
+	{http.MethodPut, "/api/v1/hotels/" + invalidID},
+	{http.MethodDelete, "/api/v1/hotels/" + invalidID},
+	{http.MethodPost, "/api/v1/provider-hotels"},
+	{http.MethodPut, "/api/v1/provider-hotels/" + invalidID + "/" + invalidID},
+	{http.MethodDelete, "/api/v1/provider-hotels/" + invalidID + "/" + invalidID},
+	{http.MethodPost, "/api/v1/reviews"},
+	{http.MethodPut, "/api/v1/reviews/" + invalidID},
+	{http.MethodPatch, "/api/v1/reviews/" + invalidID},
+	{http.MethodDelete, "/api/v1/reviews/" + invalidID},
+}
+
+func TestWriteRoutes_RequireAdmin(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, route := range writeRoutes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			rr := serve(router, "secret", route.method, route.path)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+			assert.Contains(t, rr.Body.String(), "requires the admin scope")
+		})
+	}
+}
+
+func TestWriteRoutes_AllowAdmin(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, route := range writeRoutes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			rr := serve(router, "admin-secret", route.method, route.path)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestPatchReview_DoesNotLeakUnpublishedReviews(t *testing.T) {
+	router := newTestRouter(t)
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/reviews/1", strings.NewReader("{}"))
+	req.Header.Set("Authorization", "Bearer secret")
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, req)
+
+	// An empty patch used to echo the stored review, published or not
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.NotContains(t, rr.Body.String(), `"rating"`)
+	assert.NotContains(t, rr.Body.String(), `"comment"`)
+}
+
+func TestHotelKeys_OnlyRespond(t *testing.T) {
+	router := newTestRouter(t)
+
+	t.Run("write routes", func(t *testing.T) {
+		for _, route := range writeRoutes {
+			rr := serve(router, "hotel-secret", route.method, route.path)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code, route.method+" "+route.path)
+		}
+	})
+
+	t.Run("another hotel", func(t *testing.T) {
+		// The key acts for hotel 1, so hotel 2 and review 7 of it are out of reach
+		assert.Equal(t, http.StatusForbidden, serve(router, "hotel-secret", http.MethodDelete, "/api/v1/hotels/2").Code)
+		assert.Equal(t, http.StatusForbidden, serve(router, "hotel-secret", http.MethodPatch, "/api/v1/reviews/7").Code)
+	})
+
+	t.Run("response routes", func(t *testing.T) {
+		for _, method := range []string{http.MethodPost, http.MethodPut} {
+			rr := serve(router, "hotel-secret", method, "/api/v1/reviews/"+invalidID+"/response")
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, method)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/repository"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/validator"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+)
+
+type AuditLogService interface {
+	GetAuditLogsList(queryParams *dto.AuditLogsQueryParams) ([]*models.AuditLog, int, *response.ErrorDetails)
+}
+
+type auditLogService struct {
+	repo      repository.ReviewRepository
+	logger    *logger.Logger
+	validator *validator.AuditLogValidator
+}
+
+func NewAuditLogService(repo repository.ReviewRepository, logger *logger.Logger) AuditLogService {
+	return &auditLogService{
+		repo:      repo,
+		logger:    logger,
+		validator: validator.NewAuditLogValidator(),
+	}
+}
+
+// GetAuditLogsList returns a page of the audit logs of processed files.
+func (s *auditLogService) GetAuditLogsList(queryParams *dto.AuditLogsQueryParams) ([]*models.AuditLog, int, *response.ErrorDetails) {
+	if err := s.validator.ValidateAuditLogsQueryParams(queryParams); err != nil {
+		return nil, 0, validationErrorDetails(err)
+	}
+
+	auditLogs, total, err := s.repo.GetAuditLogsList(queryParams)
+	if err != nil {
+		return nil, 0, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	return auditLogs, total, nil
+}
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/kirananto/review-system/internal/api/dto"
 	"github.com/kirananto/review-system/internal/api/repository"
 	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/validator"
 	"github.com/kirananto/review-system/internal/logger"
 	"github.com/kirananto/review-system/internal/models"
 	"gorm.io/gorm"
@@ -14,24 +17,35 @@ import (
 type HotelService interface {
 	GetHotelsList(queryParam *dto.HotelsQueryParams) ([]*models.Hotel, int, *response.ErrorDetails)
 	GetHotelByID(id uint) (*models.Hotel, *response.ErrorDetails)
+	GetHotelsByIDs(ids []uint) ([]*models.Hotel, *response.ErrorDetails)
 	CreateHotel(hotel *dto.HotelRequestBody) (*models.Hotel, *response.ErrorDetails)
 	UpdateHotel(id uint, hotel *dto.HotelRequestBody) (*models.Hotel, *response.ErrorDetails)
 	DeleteHotel(id uint) *response.ErrorDetails
+	RestoreHotel(id uint) (*models.Hotel, *response.ErrorDetails)
+	GetHotelSummary(id uint) (*dto.HotelSummary, *response.ErrorDetails)
+	GetHotelRatingTimeseries(id uint, params *dto.RatingTimeseriesQueryParams) (*dto.RatingTimeseries, *response.ErrorDetails)
+	GetHotelResponseMetrics(id uint, params *dto.ResponseMetricsQueryParams) (*dto.HotelResponseMetrics, *response.ErrorDetails)
 }
 
 type hotelService struct {
-	repo   repository.ReviewRepository
-	logger *logger.Logger
+	repo      repository.ReviewRepository
+	logger    *logger.Logger
+	validator *validator.HotelValidator
 }
 
 func NewHotelService(repo repository.ReviewRepository, logger *logger.Logger) HotelService {
 	return &hotelService{
-		repo:   repo,
-		logger: logger,
+		repo:      repo,
+		logger:    logger,
+		validator: validator.NewHotelValidator(),
 	}
 }
 
 func (s *hotelService) GetHotelsList(queryParam *dto.HotelsQueryParams) ([]*models.Hotel, int, *response.ErrorDetails) {
+	if err := s.validator.ValidateHotelsQueryParams(queryParam); err != nil {
+		return nil, 0, validationErrorDetails(err)
+	}
+
 	hotels, total, err := s.repo.GetHotelsList(queryParam)
 	if err != nil {
 		return nil, 0, &response.ErrorDetails{
@@ -63,12 +77,37 @@ func (s *hotelService) GetHotelByID(id uint) (*models.Hotel, *response.ErrorDeta
 	return hotels, nil
 }
 
+// GetHotelsByIDs returns the hotels with the given IDs, leaving out the ones
+// that do not exist.
+func (s *hotelService) GetHotelsByIDs(ids []uint) ([]*models.Hotel, *response.ErrorDetails) {
+	hotels, err := s.repo.GetHotelsByIDs(ids)
+	if err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	return hotels, nil
+}
+
 func (s *hotelService) CreateHotel(hotelDto *dto.HotelRequestBody) (*models.Hotel, *response.ErrorDetails) {
+	if err := s.validator.ValidateHotel(hotelDto); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
 	hotel := &models.Hotel{
-		HotelName: hotelDto.HotelName,
+		HotelName:     strings.TrimSpace(hotelDto.HotelName),
+		HotelLocation: normalizeLocation(hotelDto.HotelLocation),
 	}
+
+	// Hotel names and locations identify hotels during ingestion, so the
+	// unique index on them turns a taken one into a conflict
 	err := s.repo.CreateHotel(hotel)
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, hotelConflictErrorDetails(err)
+		}
 		return nil, &response.ErrorDetails{
 			Code:    http.StatusInternalServerError,
 			Message: "Failed to create hotel",
@@ -79,14 +118,23 @@ func (s *hotelService) CreateHotel(hotelDto *dto.HotelRequestBody) (*models.Hote
 }
 
 func (s *hotelService) UpdateHotel(id uint, hotelDto *dto.HotelRequestBody) (*models.Hotel, *response.ErrorDetails) {
+	if err := s.validator.ValidateHotel(hotelDto); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
 	hotel, errDetails := s.GetHotelByID(id)
 	if errDetails != nil {
 		return nil, errDetails
 	}
 
-	hotel.HotelName = hotelDto.HotelName
+	hotel.HotelName = strings.TrimSpace(hotelDto.HotelName)
+	hotel.HotelLocation = normalizeLocation(hotelDto.HotelLocation)
+
 	err := s.repo.UpdateHotel(hotel)
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, hotelConflictErrorDetails(err)
+		}
 		return nil, &response.ErrorDetails{
 			Code:    http.StatusInternalServerError,
 			Message: "Failed to update hotel",
@@ -112,3 +160,126 @@ func (s *hotelService) DeleteHotel(id uint) *response.ErrorDetails {
 	}
 	return nil
 }
+
+// RestoreHotel restores a soft-deleted hotel along with the reviews and
+// provider mappings deleted with it.
+func (s *hotelService) RestoreHotel(id uint) (*models.Hotel, *response.ErrorDetails) {
+	hotel, err := s.repo.Unscoped().GetHotelByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.ErrorDetails{
+				Code:    http.StatusNotFound,
+				Message: "Hotel not found",
+				Error:   err,
+			}
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	if !hotel.DeletedAt.Valid {
+		return nil, notDeletedErrorDetails("Hotel")
+	}
+
+	if err := s.repo.RestoreHotel(id); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, hotelConflictErrorDetails(err)
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to restore hotel",
+			Error:   err,
+		}
+	}
+	return s.GetHotelByID(id)
+}
+
+func hotelConflictErrorDetails(err error) *response.ErrorDetails {
+	return &response.ErrorDetails{
+		Code:      http.StatusConflict,
+		ErrorCode: response.CodeAlreadyExists,
+		Message:   "A hotel with this name already exists in this city and country",
+		Error:     err,
+	}
+}
+
+// GetHotelSummary aggregates the ratings of a hotel from its stored reviews and
+// the scores its providers report.
+func (s *hotelService) GetHotelSummary(id uint) (*dto.HotelSummary, *response.ErrorDetails) {
+	hotel, errDetails := s.GetHotelByID(id)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+
+	stats, err := s.repo.GetHotelReviewStats(id)
+	if err != nil {
+		return nil, hotelSummaryErrorDetails(err)
+	}
+	buckets, err := s.repo.GetHotelRatingHistogram(id)
+	if err != nil {
+		return nil, hotelSummaryErrorDetails(err)
+	}
+	scores, err := s.repo.GetHotelProviderScores(id)
+	if err != nil {
+		return nil, hotelSummaryErrorDetails(err)
+	}
+
+	return dto.NewHotelSummary(hotel, stats, buckets, scores), nil
+}
+
+// GetHotelRatingTimeseries returns the rating trend of a hotel from its stored reviews.
+func (s *hotelService) GetHotelRatingTimeseries(id uint, params *dto.RatingTimeseriesQueryParams) (*dto.RatingTimeseries, *response.ErrorDetails) {
+	if err := s.validator.ValidateRatingTimeseriesParams(params); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	if _, errDetails := s.GetHotelByID(id); errDetails != nil {
+		return nil, errDetails
+	}
+
+	points, err := s.repo.GetHotelRatingTimeseries(id, params)
+	if err != nil {
+		return nil, hotelSummaryErrorDetails(err)
+	}
+
+	return dto.NewRatingTimeseries(id, params, points), nil
+}
+
+// GetHotelResponseMetrics returns how often and how quickly a hotel responds
+// to its reviews.
+func (s *hotelService) GetHotelResponseMetrics(id uint, params *dto.ResponseMetricsQueryParams) (*dto.HotelResponseMetrics, *response.ErrorDetails) {
+	if err := s.validator.ValidateResponseMetricsParams(params); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	if _, errDetails := s.GetHotelByID(id); errDetails != nil {
+		return nil, errDetails
+	}
+
+	stats, err := s.repo.GetHotelResponseStats(id, params)
+	if err != nil {
+		return nil, hotelSummaryErrorDetails(err)
+	}
+
+	return dto.NewHotelResponseMetrics(id, params, stats), nil
+}
+
+func hotelSummaryErrorDetails(err error) *response.ErrorDetails {
+	return &response.ErrorDetails{
+		Code:    http.StatusInternalServerError,
+		Message: "Failed to summarize hotel",
+		Error:   err,
+	}
+}
+
+// normalizeLocation trims a validated location and upper-cases its country
+// code, so filters can match it exactly.
+func normalizeLocation(location models.HotelLocation) models.HotelLocation {
+	location.Address = strings.TrimSpace(location.Address)
+	location.City = strings.TrimSpace(location.City)
+	location.CountryCode = strings.ToUpper(strings.TrimSpace(location.CountryCode))
+	location.Timezone = strings.TrimSpace(location.Timezone)
+	return location
+}
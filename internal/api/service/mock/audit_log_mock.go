@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/api/service/audit_log.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kirananto/review-system/internal/api/dto"
+	response "github.com/kirananto/review-system/internal/api/response"
+	models "github.com/kirananto/review-system/internal/models"
+)
+
+// MockAuditLogService is a mock of AuditLogService interface.
+type MockAuditLogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogServiceMockRecorder
+}
+
+// MockAuditLogServiceMockRecorder is the mock recorder for MockAuditLogService.
+type MockAuditLogServiceMockRecorder struct {
+	mock *MockAuditLogService
+}
+
+// NewMockAuditLogService creates a new mock instance.
+func NewMockAuditLogService(ctrl *gomock.Controller) *MockAuditLogService {
+	mock := &MockAuditLogService{ctrl: ctrl}
+	mock.recorder = &MockAuditLogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogService) EXPECT() *MockAuditLogServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLogsList mocks base method.
+func (m *MockAuditLogService) GetAuditLogsList(queryParams *dto.AuditLogsQueryParams) ([]*models.AuditLog, int, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogsList", queryParams)
+	ret0, _ := ret[0].([]*models.AuditLog)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*response.ErrorDetails)
+	return ret0, ret1, ret2
+}
+
+// GetAuditLogsList indicates an expected call of GetAuditLogsList.
+func (mr *MockAuditLogServiceMockRecorder) GetAuditLogsList(queryParams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogsList", reflect.TypeOf((*MockAuditLogService)(nil).GetAuditLogsList), queryParams)
+}
@@ -80,6 +80,66 @@ func (mr *MockHotelServiceMockRecorder) GetHotelByID(id interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotelByID", reflect.TypeOf((*MockHotelService)(nil).GetHotelByID), id)
 }
 
+// GetHotelRatingTimeseries mocks base method.
+func (m *MockHotelService) GetHotelRatingTimeseries(id uint, params *dto.RatingTimeseriesQueryParams) (*dto.RatingTimeseries, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotelRatingTimeseries", id, params)
+	ret0, _ := ret[0].(*dto.RatingTimeseries)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetHotelRatingTimeseries indicates an expected call of GetHotelRatingTimeseries.
+func (mr *MockHotelServiceMockRecorder) GetHotelRatingTimeseries(id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotelRatingTimeseries", reflect.TypeOf((*MockHotelService)(nil).GetHotelRatingTimeseries), id, params)
+}
+
+// GetHotelResponseMetrics mocks base method.
+func (m *MockHotelService) GetHotelResponseMetrics(id uint, params *dto.ResponseMetricsQueryParams) (*dto.HotelResponseMetrics, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotelResponseMetrics", id, params)
+	ret0, _ := ret[0].(*dto.HotelResponseMetrics)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetHotelResponseMetrics indicates an expected call of GetHotelResponseMetrics.
+func (mr *MockHotelServiceMockRecorder) GetHotelResponseMetrics(id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotelResponseMetrics", reflect.TypeOf((*MockHotelService)(nil).GetHotelResponseMetrics), id, params)
+}
+
+// GetHotelSummary mocks base method.
+func (m *MockHotelService) GetHotelSummary(id uint) (*dto.HotelSummary, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotelSummary", id)
+	ret0, _ := ret[0].(*dto.HotelSummary)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetHotelSummary indicates an expected call of GetHotelSummary.
+func (mr *MockHotelServiceMockRecorder) GetHotelSummary(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotelSummary", reflect.TypeOf((*MockHotelService)(nil).GetHotelSummary), id)
+}
+
+// GetHotelsByIDs mocks base method.
+func (m *MockHotelService) GetHotelsByIDs(ids []uint) ([]*models.Hotel, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotelsByIDs", ids)
+	ret0, _ := ret[0].([]*models.Hotel)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetHotelsByIDs indicates an expected call of GetHotelsByIDs.
+func (mr *MockHotelServiceMockRecorder) GetHotelsByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotelsByIDs", reflect.TypeOf((*MockHotelService)(nil).GetHotelsByIDs), ids)
+}
+
 // GetHotelsList mocks base method.
 func (m *MockHotelService) GetHotelsList(queryParam *dto.HotelsQueryParams) ([]*models.Hotel, int, *response.ErrorDetails) {
 	m.ctrl.T.Helper()
@@ -96,6 +156,21 @@ func (mr *MockHotelServiceMockRecorder) GetHotelsList(queryParam interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotelsList", reflect.TypeOf((*MockHotelService)(nil).GetHotelsList), queryParam)
 }
 
+// RestoreHotel mocks base method.
+func (m *MockHotelService) RestoreHotel(id uint) (*models.Hotel, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreHotel", id)
+	ret0, _ := ret[0].(*models.Hotel)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// RestoreHotel indicates an expected call of RestoreHotel.
+func (mr *MockHotelServiceMockRecorder) RestoreHotel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreHotel", reflect.TypeOf((*MockHotelService)(nil).RestoreHotel), id)
+}
+
 // UpdateHotel mocks base method.
 func (m *MockHotelService) UpdateHotel(id uint, hotel *dto.HotelRequestBody) (*models.Hotel, *response.ErrorDetails) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/api/service/provider_hotel.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kirananto/review-system/internal/api/dto"
+	response "github.com/kirananto/review-system/internal/api/response"
+	models "github.com/kirananto/review-system/internal/models"
+)
+
+// MockProviderHotelService is a mock of ProviderHotelService interface.
+type MockProviderHotelService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderHotelServiceMockRecorder
+}
+
+// MockProviderHotelServiceMockRecorder is the mock recorder for MockProviderHotelService.
+type MockProviderHotelServiceMockRecorder struct {
+	mock *MockProviderHotelService
+}
+
+// NewMockProviderHotelService creates a new mock instance.
+func NewMockProviderHotelService(ctrl *gomock.Controller) *MockProviderHotelService {
+	mock := &MockProviderHotelService{ctrl: ctrl}
+	mock.recorder = &MockProviderHotelServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProviderHotelService) EXPECT() *MockProviderHotelServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateProviderHotel mocks base method.
+func (m *MockProviderHotelService) CreateProviderHotel(providerHotel *dto.ProviderHotelRequestBody) (*models.ProviderHotel, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProviderHotel", providerHotel)
+	ret0, _ := ret[0].(*models.ProviderHotel)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// CreateProviderHotel indicates an expected call of CreateProviderHotel.
+func (mr *MockProviderHotelServiceMockRecorder) CreateProviderHotel(providerHotel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProviderHotel", reflect.TypeOf((*MockProviderHotelService)(nil).CreateProviderHotel), providerHotel)
+}
+
+// DeleteProviderHotel mocks base method.
+func (m *MockProviderHotelService) DeleteProviderHotel(providerID, hotelID uint) *response.ErrorDetails {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProviderHotel", providerID, hotelID)
+	ret0, _ := ret[0].(*response.ErrorDetails)
+	return ret0
+}
+
+// DeleteProviderHotel indicates an expected call of DeleteProviderHotel.
+func (mr *MockProviderHotelServiceMockRecorder) DeleteProviderHotel(providerID, hotelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProviderHotel", reflect.TypeOf((*MockProviderHotelService)(nil).DeleteProviderHotel), providerID, hotelID)
+}
+
+// GetProviderHotel mocks base method.
+func (m *MockProviderHotelService) GetProviderHotel(providerID, hotelID uint, include ...string) (*models.ProviderHotel, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{providerID, hotelID}
+	for _, a := range include {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetProviderHotel", varargs...)
+	ret0, _ := ret[0].(*models.ProviderHotel)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetProviderHotel indicates an expected call of GetProviderHotel.
+func (mr *MockProviderHotelServiceMockRecorder) GetProviderHotel(providerID, hotelID interface{}, include ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{providerID, hotelID}, include...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderHotel", reflect.TypeOf((*MockProviderHotelService)(nil).GetProviderHotel), varargs...)
+}
+
+// GetProviderHotelsByHotelIDs mocks base method.
+func (m *MockProviderHotelService) GetProviderHotelsByHotelIDs(hotelIDs []uint) ([]*models.ProviderHotel, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviderHotelsByHotelIDs", hotelIDs)
+	ret0, _ := ret[0].([]*models.ProviderHotel)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetProviderHotelsByHotelIDs indicates an expected call of GetProviderHotelsByHotelIDs.
+func (mr *MockProviderHotelServiceMockRecorder) GetProviderHotelsByHotelIDs(hotelIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderHotelsByHotelIDs", reflect.TypeOf((*MockProviderHotelService)(nil).GetProviderHotelsByHotelIDs), hotelIDs)
+}
+
+// GetProviderHotelsByProviderIDs mocks base method.
+func (m *MockProviderHotelService) GetProviderHotelsByProviderIDs(providerIDs []uint) ([]*models.ProviderHotel, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviderHotelsByProviderIDs", providerIDs)
+	ret0, _ := ret[0].([]*models.ProviderHotel)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetProviderHotelsByProviderIDs indicates an expected call of GetProviderHotelsByProviderIDs.
+func (mr *MockProviderHotelServiceMockRecorder) GetProviderHotelsByProviderIDs(providerIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderHotelsByProviderIDs", reflect.TypeOf((*MockProviderHotelService)(nil).GetProviderHotelsByProviderIDs), providerIDs)
+}
+
+// GetProviderHotelsList mocks base method.
+func (m *MockProviderHotelService) GetProviderHotelsList(queryParam *dto.ProviderHotelsQueryParams) ([]*models.ProviderHotel, int, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviderHotelsList", queryParam)
+	ret0, _ := ret[0].([]*models.ProviderHotel)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*response.ErrorDetails)
+	return ret0, ret1, ret2
+}
+
+// GetProviderHotelsList indicates an expected call of GetProviderHotelsList.
+func (mr *MockProviderHotelServiceMockRecorder) GetProviderHotelsList(queryParam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderHotelsList", reflect.TypeOf((*MockProviderHotelService)(nil).GetProviderHotelsList), queryParam)
+}
+
+// RestoreProviderHotel mocks base method.
+func (m *MockProviderHotelService) RestoreProviderHotel(providerID, hotelID uint) (*models.ProviderHotel, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProviderHotel", providerID, hotelID)
+	ret0, _ := ret[0].(*models.ProviderHotel)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// RestoreProviderHotel indicates an expected call of RestoreProviderHotel.
+func (mr *MockProviderHotelServiceMockRecorder) RestoreProviderHotel(providerID, hotelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProviderHotel", reflect.TypeOf((*MockProviderHotelService)(nil).RestoreProviderHotel), providerID, hotelID)
+}
+
+// UpdateProviderHotel mocks base method.
+func (m *MockProviderHotelService) UpdateProviderHotel(providerID, hotelID uint, stats *dto.ProviderHotelStatsBody) (*models.ProviderHotel, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProviderHotel", providerID, hotelID, stats)
+	ret0, _ := ret[0].(*models.ProviderHotel)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// UpdateProviderHotel indicates an expected call of UpdateProviderHotel.
+func (mr *MockProviderHotelServiceMockRecorder) UpdateProviderHotel(providerID, hotelID, stats interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProviderHotel", reflect.TypeOf((*MockProviderHotelService)(nil).UpdateProviderHotel), providerID, hotelID, stats)
+}
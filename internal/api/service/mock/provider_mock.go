@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/api/service/provider.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kirananto/review-system/internal/api/dto"
+	response "github.com/kirananto/review-system/internal/api/response"
+	models "github.com/kirananto/review-system/internal/models"
+)
+
+// MockProviderService is a mock of ProviderService interface.
+type MockProviderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderServiceMockRecorder
+}
+
+// MockProviderServiceMockRecorder is the mock recorder for MockProviderService.
+type MockProviderServiceMockRecorder struct {
+	mock *MockProviderService
+}
+
+// NewMockProviderService creates a new mock instance.
+func NewMockProviderService(ctrl *gomock.Controller) *MockProviderService {
+	mock := &MockProviderService{ctrl: ctrl}
+	mock.recorder = &MockProviderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProviderService) EXPECT() *MockProviderServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateProvider mocks base method.
+func (m *MockProviderService) CreateProvider(provider *dto.ProviderRequestBody) (*models.Provider, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProvider", provider)
+	ret0, _ := ret[0].(*models.Provider)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// CreateProvider indicates an expected call of CreateProvider.
+func (mr *MockProviderServiceMockRecorder) CreateProvider(provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProvider", reflect.TypeOf((*MockProviderService)(nil).CreateProvider), provider)
+}
+
+// DeleteProvider mocks base method.
+func (m *MockProviderService) DeleteProvider(id uint) *response.ErrorDetails {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProvider", id)
+	ret0, _ := ret[0].(*response.ErrorDetails)
+	return ret0
+}
+
+// DeleteProvider indicates an expected call of DeleteProvider.
+func (mr *MockProviderServiceMockRecorder) DeleteProvider(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProvider", reflect.TypeOf((*MockProviderService)(nil).DeleteProvider), id)
+}
+
+// GetProviderByID mocks base method.
+func (m *MockProviderService) GetProviderByID(id uint) (*models.Provider, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviderByID", id)
+	ret0, _ := ret[0].(*models.Provider)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetProviderByID indicates an expected call of GetProviderByID.
+func (mr *MockProviderServiceMockRecorder) GetProviderByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderByID", reflect.TypeOf((*MockProviderService)(nil).GetProviderByID), id)
+}
+
+// GetProvidersByIDs mocks base method.
+func (m *MockProviderService) GetProvidersByIDs(ids []uint) ([]*models.Provider, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvidersByIDs", ids)
+	ret0, _ := ret[0].([]*models.Provider)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetProvidersByIDs indicates an expected call of GetProvidersByIDs.
+func (mr *MockProviderServiceMockRecorder) GetProvidersByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvidersByIDs", reflect.TypeOf((*MockProviderService)(nil).GetProvidersByIDs), ids)
+}
+
+// GetProvidersList mocks base method.
+func (m *MockProviderService) GetProvidersList(queryParams *dto.ProvidersQueryParams) ([]*models.Provider, int, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvidersList", queryParams)
+	ret0, _ := ret[0].([]*models.Provider)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*response.ErrorDetails)
+	return ret0, ret1, ret2
+}
+
+// GetProvidersList indicates an expected call of GetProvidersList.
+func (mr *MockProviderServiceMockRecorder) GetProvidersList(queryParams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvidersList", reflect.TypeOf((*MockProviderService)(nil).GetProvidersList), queryParams)
+}
+
+// RestoreProvider mocks base method.
+func (m *MockProviderService) RestoreProvider(id uint) (*models.Provider, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProvider", id)
+	ret0, _ := ret[0].(*models.Provider)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// RestoreProvider indicates an expected call of RestoreProvider.
+func (mr *MockProviderServiceMockRecorder) RestoreProvider(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProvider", reflect.TypeOf((*MockProviderService)(nil).RestoreProvider), id)
+}
+
+// UpdateProvider mocks base method.
+func (m *MockProviderService) UpdateProvider(id uint, provider *dto.ProviderRequestBody) (*models.Provider, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProvider", id, provider)
+	ret0, _ := ret[0].(*models.Provider)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// UpdateProvider indicates an expected call of UpdateProvider.
+func (mr *MockProviderServiceMockRecorder) UpdateProvider(id, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProvider", reflect.TypeOf((*MockProviderService)(nil).UpdateProvider), id, provider)
+}
@@ -38,19 +38,111 @@ func (m *MockReviewService) EXPECT() *MockReviewServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateReview mocks base method.
+func (m *MockReviewService) CreateReview(review *dto.ReviewRequestBody) (*models.Review, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", review)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockReviewServiceMockRecorder) CreateReview(review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockReviewService)(nil).CreateReview), review)
+}
+
+// CreateReviewResponse mocks base method.
+func (m *MockReviewService) CreateReviewResponse(reviewID, hotelID uint, body *dto.ReviewResponseRequestBody) (*models.ReviewResponse, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReviewResponse", reviewID, hotelID, body)
+	ret0, _ := ret[0].(*models.ReviewResponse)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// CreateReviewResponse indicates an expected call of CreateReviewResponse.
+func (mr *MockReviewServiceMockRecorder) CreateReviewResponse(reviewID, hotelID, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReviewResponse", reflect.TypeOf((*MockReviewService)(nil).CreateReviewResponse), reviewID, hotelID, body)
+}
+
+// DeleteReview mocks base method.
+func (m *MockReviewService) DeleteReview(id uint) *response.ErrorDetails {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", id)
+	ret0, _ := ret[0].(*response.ErrorDetails)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockReviewServiceMockRecorder) DeleteReview(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockReviewService)(nil).DeleteReview), id)
+}
+
+// DeleteReviewResponse mocks base method.
+func (m *MockReviewService) DeleteReviewResponse(reviewID, hotelID uint) *response.ErrorDetails {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReviewResponse", reviewID, hotelID)
+	ret0, _ := ret[0].(*response.ErrorDetails)
+	return ret0
+}
+
+// DeleteReviewResponse indicates an expected call of DeleteReviewResponse.
+func (mr *MockReviewServiceMockRecorder) DeleteReviewResponse(reviewID, hotelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReviewResponse", reflect.TypeOf((*MockReviewService)(nil).DeleteReviewResponse), reviewID, hotelID)
+}
+
+// ExportReviews mocks base method.
+func (m *MockReviewService) ExportReviews(ctx context.Context, queryParam *dto.ReviewExportQueryParams, write func(*models.Review) error) *response.ErrorDetails {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportReviews", ctx, queryParam, write)
+	ret0, _ := ret[0].(*response.ErrorDetails)
+	return ret0
+}
+
+// ExportReviews indicates an expected call of ExportReviews.
+func (mr *MockReviewServiceMockRecorder) ExportReviews(ctx, queryParam, write interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReviews", reflect.TypeOf((*MockReviewService)(nil).ExportReviews), ctx, queryParam, write)
+}
+
+// GetLatestReviewsByHotelIDs mocks base method.
+func (m *MockReviewService) GetLatestReviewsByHotelIDs(hotelIDs []uint, limit int) ([]*models.Review, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestReviewsByHotelIDs", hotelIDs, limit)
+	ret0, _ := ret[0].([]*models.Review)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetLatestReviewsByHotelIDs indicates an expected call of GetLatestReviewsByHotelIDs.
+func (mr *MockReviewServiceMockRecorder) GetLatestReviewsByHotelIDs(hotelIDs, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestReviewsByHotelIDs", reflect.TypeOf((*MockReviewService)(nil).GetLatestReviewsByHotelIDs), hotelIDs, limit)
+}
+
 // GetReviewByID mocks base method.
-func (m *MockReviewService) GetReviewByID(id uint) (*models.Review, *response.ErrorDetails) {
+func (m *MockReviewService) GetReviewByID(id uint, include ...string) (*models.Review, *response.ErrorDetails) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReviewByID", id)
+	varargs := []interface{}{id}
+	for _, a := range include {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetReviewByID", varargs...)
 	ret0, _ := ret[0].(*models.Review)
 	ret1, _ := ret[1].(*response.ErrorDetails)
 	return ret0, ret1
 }
 
 // GetReviewByID indicates an expected call of GetReviewByID.
-func (mr *MockReviewServiceMockRecorder) GetReviewByID(id interface{}) *gomock.Call {
+func (mr *MockReviewServiceMockRecorder) GetReviewByID(id interface{}, include ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewByID", reflect.TypeOf((*MockReviewService)(nil).GetReviewByID), id)
+	varargs := append([]interface{}{id}, include...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewByID", reflect.TypeOf((*MockReviewService)(nil).GetReviewByID), varargs...)
 }
 
 // GetReviewsList mocks base method.
@@ -69,6 +161,80 @@ func (mr *MockReviewServiceMockRecorder) GetReviewsList(queryParam interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsList", reflect.TypeOf((*MockReviewService)(nil).GetReviewsList), queryParam)
 }
 
+// GetReviewsPage mocks base method.
+func (m *MockReviewService) GetReviewsPage(queryParam *dto.ReviewQueryParams) (*dto.ReviewPage, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewsPage", queryParam)
+	ret0, _ := ret[0].(*dto.ReviewPage)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetReviewsPage indicates an expected call of GetReviewsPage.
+func (mr *MockReviewServiceMockRecorder) GetReviewsPage(queryParam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsPage", reflect.TypeOf((*MockReviewService)(nil).GetReviewsPage), queryParam)
+}
+
+// ModerateReview mocks base method.
+func (m *MockReviewService) ModerateReview(id uint, action string, body *dto.ModerationRequestBody) (*models.Review, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReview", id, action, body)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// ModerateReview indicates an expected call of ModerateReview.
+func (mr *MockReviewServiceMockRecorder) ModerateReview(id, action, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReview", reflect.TypeOf((*MockReviewService)(nil).ModerateReview), id, action, body)
+}
+
+// ModerateReviews mocks base method.
+func (m *MockReviewService) ModerateReviews(action string, body *dto.BatchModerationRequestBody) (*dto.BatchModerationResult, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReviews", action, body)
+	ret0, _ := ret[0].(*dto.BatchModerationResult)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// ModerateReviews indicates an expected call of ModerateReviews.
+func (mr *MockReviewServiceMockRecorder) ModerateReviews(action, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReviews", reflect.TypeOf((*MockReviewService)(nil).ModerateReviews), action, body)
+}
+
+// PatchReview mocks base method.
+func (m *MockReviewService) PatchReview(id uint, patch *dto.ReviewPatchBody) (*models.Review, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchReview", id, patch)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// PatchReview indicates an expected call of PatchReview.
+func (mr *MockReviewServiceMockRecorder) PatchReview(id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchReview", reflect.TypeOf((*MockReviewService)(nil).PatchReview), id, patch)
+}
+
+// ProcessReview mocks base method.
+func (m *MockReviewService) ProcessReview(ctx context.Context, line []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessReview", ctx, line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessReview indicates an expected call of ProcessReview.
+func (mr *MockReviewServiceMockRecorder) ProcessReview(ctx, line interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessReview", reflect.TypeOf((*MockReviewService)(nil).ProcessReview), ctx, line)
+}
+
 // ProcessReviews mocks base method.
 func (m *MockReviewService) ProcessReviews(ctx context.Context, reader io.Reader, fileName string) error {
 	m.ctrl.T.Helper()
@@ -82,3 +248,64 @@ func (mr *MockReviewServiceMockRecorder) ProcessReviews(ctx, reader, fileName in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessReviews", reflect.TypeOf((*MockReviewService)(nil).ProcessReviews), ctx, reader, fileName)
 }
+
+// RestoreReview mocks base method.
+func (m *MockReviewService) RestoreReview(id uint) (*models.Review, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreReview", id)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// RestoreReview indicates an expected call of RestoreReview.
+func (mr *MockReviewServiceMockRecorder) RestoreReview(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReview", reflect.TypeOf((*MockReviewService)(nil).RestoreReview), id)
+}
+
+// SearchReviews mocks base method.
+func (m *MockReviewService) SearchReviews(queryParam *dto.ReviewQueryParams) ([]*dto.ReviewSearchResult, int, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchReviews", queryParam)
+	ret0, _ := ret[0].([]*dto.ReviewSearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*response.ErrorDetails)
+	return ret0, ret1, ret2
+}
+
+// SearchReviews indicates an expected call of SearchReviews.
+func (mr *MockReviewServiceMockRecorder) SearchReviews(queryParam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchReviews", reflect.TypeOf((*MockReviewService)(nil).SearchReviews), queryParam)
+}
+
+// UpdateReview mocks base method.
+func (m *MockReviewService) UpdateReview(id uint, review *dto.ReviewRequestBody) (*models.Review, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReview", id, review)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// UpdateReview indicates an expected call of UpdateReview.
+func (mr *MockReviewServiceMockRecorder) UpdateReview(id, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockReviewService)(nil).UpdateReview), id, review)
+}
+
+// UpdateReviewResponse mocks base method.
+func (m *MockReviewService) UpdateReviewResponse(reviewID, hotelID uint, body *dto.ReviewResponseRequestBody) (*models.ReviewResponse, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReviewResponse", reviewID, hotelID, body)
+	ret0, _ := ret[0].(*models.ReviewResponse)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// UpdateReviewResponse indicates an expected call of UpdateReviewResponse.
+func (mr *MockReviewServiceMockRecorder) UpdateReviewResponse(reviewID, hotelID, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewResponse", reflect.TypeOf((*MockReviewService)(nil).UpdateReviewResponse), reviewID, hotelID, body)
+}
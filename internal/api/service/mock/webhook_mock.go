@@ -0,0 +1,195 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/api/service/webhook.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/kirananto/review-system/internal/api/dto"
+	response "github.com/kirananto/review-system/internal/api/response"
+	models "github.com/kirananto/review-system/internal/models"
+	webhook "github.com/kirananto/review-system/internal/webhook"
+)
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(event *webhook.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), event)
+}
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockWebhookService) CreateWebhookSubscription(body *dto.WebhookSubscriptionRequestBody) (*dto.WebhookSubscriptionWithSecret, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", body)
+	ret0, _ := ret[0].(*dto.WebhookSubscriptionWithSecret)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockWebhookServiceMockRecorder) CreateWebhookSubscription(body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockWebhookService)(nil).CreateWebhookSubscription), body)
+}
+
+// DeleteWebhookSubscription mocks base method.
+func (m *MockWebhookService) DeleteWebhookSubscription(id uint) *response.ErrorDetails {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscription", id)
+	ret0, _ := ret[0].(*response.ErrorDetails)
+	return ret0
+}
+
+// DeleteWebhookSubscription indicates an expected call of DeleteWebhookSubscription.
+func (mr *MockWebhookServiceMockRecorder) DeleteWebhookSubscription(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscription", reflect.TypeOf((*MockWebhookService)(nil).DeleteWebhookSubscription), id)
+}
+
+// GetWebhookDeliveriesList mocks base method.
+func (m *MockWebhookService) GetWebhookDeliveriesList(queryParams *dto.WebhookDeliveriesQueryParams) ([]*models.WebhookDelivery, int, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveriesList", queryParams)
+	ret0, _ := ret[0].([]*models.WebhookDelivery)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*response.ErrorDetails)
+	return ret0, ret1, ret2
+}
+
+// GetWebhookDeliveriesList indicates an expected call of GetWebhookDeliveriesList.
+func (mr *MockWebhookServiceMockRecorder) GetWebhookDeliveriesList(queryParams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveriesList", reflect.TypeOf((*MockWebhookService)(nil).GetWebhookDeliveriesList), queryParams)
+}
+
+// GetWebhookDeliveryByID mocks base method.
+func (m *MockWebhookService) GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveryByID", id)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveryByID indicates an expected call of GetWebhookDeliveryByID.
+func (mr *MockWebhookServiceMockRecorder) GetWebhookDeliveryByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveryByID", reflect.TypeOf((*MockWebhookService)(nil).GetWebhookDeliveryByID), id)
+}
+
+// GetWebhookSubscriptionByID mocks base method.
+func (m *MockWebhookService) GetWebhookSubscriptionByID(id uint) (*models.WebhookSubscription, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscriptionByID", id)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// GetWebhookSubscriptionByID indicates an expected call of GetWebhookSubscriptionByID.
+func (mr *MockWebhookServiceMockRecorder) GetWebhookSubscriptionByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscriptionByID", reflect.TypeOf((*MockWebhookService)(nil).GetWebhookSubscriptionByID), id)
+}
+
+// GetWebhookSubscriptionsList mocks base method.
+func (m *MockWebhookService) GetWebhookSubscriptionsList(queryParams *dto.WebhookSubscriptionsQueryParams) ([]*models.WebhookSubscription, int, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscriptionsList", queryParams)
+	ret0, _ := ret[0].([]*models.WebhookSubscription)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*response.ErrorDetails)
+	return ret0, ret1, ret2
+}
+
+// GetWebhookSubscriptionsList indicates an expected call of GetWebhookSubscriptionsList.
+func (mr *MockWebhookServiceMockRecorder) GetWebhookSubscriptionsList(queryParams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscriptionsList", reflect.TypeOf((*MockWebhookService)(nil).GetWebhookSubscriptionsList), queryParams)
+}
+
+// ReplayWebhookDelivery mocks base method.
+func (m *MockWebhookService) ReplayWebhookDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// ReplayWebhookDelivery indicates an expected call of ReplayWebhookDelivery.
+func (mr *MockWebhookServiceMockRecorder) ReplayWebhookDelivery(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayWebhookDelivery", reflect.TypeOf((*MockWebhookService)(nil).ReplayWebhookDelivery), ctx, id)
+}
+
+// UpdateWebhookSubscription mocks base method.
+func (m *MockWebhookService) UpdateWebhookSubscription(id uint, body *dto.WebhookSubscriptionRequestBody) (*dto.WebhookSubscriptionWithSecret, *response.ErrorDetails) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookSubscription", id, body)
+	ret0, _ := ret[0].(*dto.WebhookSubscriptionWithSecret)
+	ret1, _ := ret[1].(*response.ErrorDetails)
+	return ret0, ret1
+}
+
+// UpdateWebhookSubscription indicates an expected call of UpdateWebhookSubscription.
+func (mr *MockWebhookServiceMockRecorder) UpdateWebhookSubscription(id, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookSubscription", reflect.TypeOf((*MockWebhookService)(nil).UpdateWebhookSubscription), id, body)
+}
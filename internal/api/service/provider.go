@@ -1,11 +1,14 @@
 package service
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/kirananto/review-system/internal/api/dto"
 	"github.com/kirananto/review-system/internal/api/repository"
 	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/validator"
 	"github.com/kirananto/review-system/internal/logger"
 	"github.com/kirananto/review-system/internal/models"
 	"gorm.io/gorm"
@@ -14,17 +17,24 @@ import (
 type ProviderService interface {
 	GetProvidersList(queryParams *dto.ProvidersQueryParams) ([]*models.Provider, int, *response.ErrorDetails)
 	GetProviderByID(id uint) (*models.Provider, *response.ErrorDetails)
+	GetProvidersByIDs(ids []uint) ([]*models.Provider, *response.ErrorDetails)
+	CreateProvider(provider *dto.ProviderRequestBody) (*models.Provider, *response.ErrorDetails)
+	UpdateProvider(id uint, provider *dto.ProviderRequestBody) (*models.Provider, *response.ErrorDetails)
+	DeleteProvider(id uint) *response.ErrorDetails
+	RestoreProvider(id uint) (*models.Provider, *response.ErrorDetails)
 }
 
 type providerService struct {
-	repo   repository.ReviewRepository
-	logger *logger.Logger
+	repo      repository.ReviewRepository
+	logger    *logger.Logger
+	validator *validator.ProviderValidator
 }
 
 func NewProviderService(repo repository.ReviewRepository, logger *logger.Logger) ProviderService {
 	return &providerService{
-		repo:   repo,
-		logger: logger,
+		repo:      repo,
+		logger:    logger,
+		validator: validator.NewProviderValidator(),
 	}
 }
 
@@ -59,3 +69,125 @@ func (s *providerService) GetProviderByID(id uint) (*models.Provider, *response.
 	}
 	return provider, nil
 }
+
+// GetProvidersByIDs returns the providers with the given IDs, leaving out the
+// ones that do not exist.
+func (s *providerService) GetProvidersByIDs(ids []uint) ([]*models.Provider, *response.ErrorDetails) {
+	providers, err := s.repo.GetProvidersByIDs(ids)
+	if err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	return providers, nil
+}
+
+func (s *providerService) CreateProvider(providerDto *dto.ProviderRequestBody) (*models.Provider, *response.ErrorDetails) {
+	if err := s.validator.ValidateProvider(providerDto); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	provider := &models.Provider{
+		Name: strings.TrimSpace(providerDto.Name),
+	}
+
+	// The unique index on provider names turns a taken name into a conflict
+	if err := s.repo.CreateProvider(provider); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, providerConflictErrorDetails(err)
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to create provider",
+			Error:   err,
+		}
+	}
+	return provider, nil
+}
+
+func (s *providerService) UpdateProvider(id uint, providerDto *dto.ProviderRequestBody) (*models.Provider, *response.ErrorDetails) {
+	if err := s.validator.ValidateProvider(providerDto); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	provider, errDetails := s.GetProviderByID(id)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+
+	provider.Name = strings.TrimSpace(providerDto.Name)
+
+	if err := s.repo.UpdateProvider(provider); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, providerConflictErrorDetails(err)
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to update provider",
+			Error:   err,
+		}
+	}
+	return provider, nil
+}
+
+func (s *providerService) DeleteProvider(id uint) *response.ErrorDetails {
+	_, errDetails := s.GetProviderByID(id)
+	if errDetails != nil {
+		return errDetails
+	}
+
+	if err := s.repo.DeleteProvider(id); err != nil {
+		return &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete provider",
+			Error:   err,
+		}
+	}
+	return nil
+}
+
+// RestoreProvider restores a soft-deleted provider along with the reviews and
+// hotel mappings deleted with it.
+func (s *providerService) RestoreProvider(id uint) (*models.Provider, *response.ErrorDetails) {
+	provider, err := s.repo.Unscoped().GetProviderByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.ErrorDetails{
+				Code:    http.StatusNotFound,
+				Message: "Provider not found",
+				Error:   err,
+			}
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	if !provider.DeletedAt.Valid {
+		return nil, notDeletedErrorDetails("Provider")
+	}
+
+	if err := s.repo.RestoreProvider(id); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, providerConflictErrorDetails(err)
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to restore provider",
+			Error:   err,
+		}
+	}
+	return s.GetProviderByID(id)
+}
+
+func providerConflictErrorDetails(err error) *response.ErrorDetails {
+	return &response.ErrorDetails{
+		Code:      http.StatusConflict,
+		ErrorCode: response.CodeAlreadyExists,
+		Message:   "A provider with this name already exists",
+		Error:     err,
+	}
+}
@@ -1,28 +1,41 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/kirananto/review-system/internal/api/dto"
 	"github.com/kirananto/review-system/internal/api/repository"
 	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/validator"
 	"github.com/kirananto/review-system/internal/logger"
 	"github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm"
 )
 
 type ProviderHotelService interface {
 	GetProviderHotelsList(queryParam *dto.ProviderHotelsQueryParams) ([]*models.ProviderHotel, int, *response.ErrorDetails)
+	GetProviderHotel(providerID, hotelID uint, include ...string) (*models.ProviderHotel, *response.ErrorDetails)
+	GetProviderHotelsByHotelIDs(hotelIDs []uint) ([]*models.ProviderHotel, *response.ErrorDetails)
+	GetProviderHotelsByProviderIDs(providerIDs []uint) ([]*models.ProviderHotel, *response.ErrorDetails)
+	CreateProviderHotel(providerHotel *dto.ProviderHotelRequestBody) (*models.ProviderHotel, *response.ErrorDetails)
+	UpdateProviderHotel(providerID, hotelID uint, stats *dto.ProviderHotelStatsBody) (*models.ProviderHotel, *response.ErrorDetails)
+	DeleteProviderHotel(providerID, hotelID uint) *response.ErrorDetails
+	RestoreProviderHotel(providerID, hotelID uint) (*models.ProviderHotel, *response.ErrorDetails)
 }
 
 type providerHotelService struct {
-	repo   repository.ReviewRepository
-	logger *logger.Logger
+	repo      repository.ReviewRepository
+	logger    *logger.Logger
+	validator *validator.ProviderHotelValidator
 }
 
 func NewProviderHotelService(repo repository.ReviewRepository, logger *logger.Logger) ProviderHotelService {
 	return &providerHotelService{
-		repo:   repo,
-		logger: logger,
+		repo:      repo,
+		logger:    logger,
+		validator: validator.NewProviderHotelValidator(repo),
 	}
 }
 
@@ -38,3 +51,177 @@ func (s *providerHotelService) GetProviderHotelsList(queryParam *dto.ProviderHot
 
 	return providerHotels, total, nil
 }
+
+func (s *providerHotelService) GetProviderHotel(providerID, hotelID uint, include ...string) (*models.ProviderHotel, *response.ErrorDetails) {
+	providerHotel, err := s.repo.GetProviderHotel(providerID, hotelID, include...)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.ErrorDetails{
+				Code:    http.StatusNotFound,
+				Message: "Provider hotel not found",
+				Error:   err,
+			}
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	return providerHotel, nil
+}
+
+// GetProviderHotelsByHotelIDs returns the provider mappings of the given hotels.
+func (s *providerHotelService) GetProviderHotelsByHotelIDs(hotelIDs []uint) ([]*models.ProviderHotel, *response.ErrorDetails) {
+	providerHotels, err := s.repo.GetProviderHotelsByHotelIDs(hotelIDs)
+	if err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	return providerHotels, nil
+}
+
+// GetProviderHotelsByProviderIDs returns the hotel mappings of the given providers.
+func (s *providerHotelService) GetProviderHotelsByProviderIDs(providerIDs []uint) ([]*models.ProviderHotel, *response.ErrorDetails) {
+	providerHotels, err := s.repo.GetProviderHotelsByProviderIDs(providerIDs)
+	if err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	return providerHotels, nil
+}
+
+func (s *providerHotelService) CreateProviderHotel(providerHotelDto *dto.ProviderHotelRequestBody) (*models.ProviderHotel, *response.ErrorDetails) {
+	if err := s.validator.ValidateCreateProviderHotel(providerHotelDto); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	// A deleted mapping still holds the key, so it has to be restored instead
+	if _, err := s.repo.Unscoped().GetProviderHotel(providerHotelDto.ProviderID, providerHotelDto.HotelID); err == nil {
+		return nil, providerHotelConflictErrorDetails(fmt.Errorf("provider hotel %d/%d already exists", providerHotelDto.ProviderID, providerHotelDto.HotelID))
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to create provider hotel",
+			Error:   err,
+		}
+	}
+
+	providerHotel := &models.ProviderHotel{
+		ProviderID:   providerHotelDto.ProviderID,
+		HotelID:      providerHotelDto.HotelID,
+		OverallScore: providerHotelDto.OverallScore,
+		ReviewCount:  providerHotelDto.ReviewCount,
+		Grades:       gradesOrEmpty(providerHotelDto.Grades),
+	}
+
+	if err := s.repo.CreateProviderHotel(providerHotel); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, providerHotelConflictErrorDetails(err)
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to create provider hotel",
+			Error:   err,
+		}
+	}
+	return providerHotel, nil
+}
+
+func (s *providerHotelService) UpdateProviderHotel(providerID, hotelID uint, stats *dto.ProviderHotelStatsBody) (*models.ProviderHotel, *response.ErrorDetails) {
+	if err := s.validator.ValidateProviderHotelStats(stats); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	providerHotel, errDetails := s.GetProviderHotel(providerID, hotelID)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+
+	providerHotel.OverallScore = stats.OverallScore
+	providerHotel.ReviewCount = stats.ReviewCount
+	providerHotel.Grades = gradesOrEmpty(stats.Grades)
+
+	if err := s.repo.UpdateProviderHotel(providerHotel); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to update provider hotel",
+			Error:   err,
+		}
+	}
+	return providerHotel, nil
+}
+
+func (s *providerHotelService) DeleteProviderHotel(providerID, hotelID uint) *response.ErrorDetails {
+	_, errDetails := s.GetProviderHotel(providerID, hotelID)
+	if errDetails != nil {
+		return errDetails
+	}
+
+	if err := s.repo.DeleteProviderHotel(providerID, hotelID); err != nil {
+		return &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete provider hotel",
+			Error:   err,
+		}
+	}
+	return nil
+}
+
+// RestoreProviderHotel restores a soft-deleted provider-specific hotel mapping.
+// Its provider and hotel have to be restored first.
+func (s *providerHotelService) RestoreProviderHotel(providerID, hotelID uint) (*models.ProviderHotel, *response.ErrorDetails) {
+	providerHotel, err := s.repo.Unscoped().GetProviderHotel(providerID, hotelID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.ErrorDetails{
+				Code:    http.StatusNotFound,
+				Message: "Provider hotel not found",
+				Error:   err,
+			}
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	if !providerHotel.DeletedAt.Valid {
+		return nil, notDeletedErrorDetails("Provider hotel")
+	}
+	if errDetails := checkParentsRestored(s.repo, providerID, hotelID); errDetails != nil {
+		return nil, errDetails
+	}
+
+	if err := s.repo.RestoreProviderHotel(providerID, hotelID); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to restore provider hotel",
+			Error:   err,
+		}
+	}
+	return s.GetProviderHotel(providerID, hotelID)
+}
+
+// gradesOrEmpty stores missing grades as an empty JSON object.
+func gradesOrEmpty(grades []byte) []byte {
+	if len(grades) == 0 {
+		return []byte(`{}`)
+	}
+	return grades
+}
+
+func providerHotelConflictErrorDetails(err error) *response.ErrorDetails {
+	return &response.ErrorDetails{
+		Code:      http.StatusConflict,
+		ErrorCode: response.CodeAlreadyExists,
+		Message:   "Provider hotel already exists",
+		Error:     err,
+	}
+}
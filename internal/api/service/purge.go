@@ -0,0 +1,45 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/repository"
+	"github.com/kirananto/review-system/internal/logger"
+)
+
+type PurgeService interface {
+	Purge(retention time.Duration) (*dto.PurgeResult, error)
+}
+
+type purgeService struct {
+	repo   repository.ReviewRepository
+	logger *logger.Logger
+}
+
+func NewPurgeService(repo repository.ReviewRepository, logger *logger.Logger) PurgeService {
+	return &purgeService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Purge permanently deletes the hotels, providers, mappings and reviews that
+// were soft-deleted longer than retention ago.
+func (s *purgeService) Purge(retention time.Duration) (*dto.PurgeResult, error) {
+	if retention < 0 {
+		return nil, fmt.Errorf("retention must not be negative")
+	}
+
+	result, err := s.repo.PurgeDeleted(time.Now().Add(-retention))
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted entities: %w", err)
+	}
+
+	s.logger.Info(fmt.Sprintf(
+		"Purged entities deleted before %s: %d reviews, %d provider hotels, %d hotels, %d providers",
+		result.DeletedBefore.Format(time.RFC3339), result.Reviews, result.ProviderHotels, result.Hotels, result.Providers,
+	))
+	return result, nil
+}
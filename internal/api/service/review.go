@@ -4,39 +4,83 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/kirananto/review-system/internal/api/dto"
 	"github.com/kirananto/review-system/internal/api/repository"
 	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/validator"
 	"github.com/kirananto/review-system/internal/logger"
 	"github.com/kirananto/review-system/internal/models"
+	"github.com/kirananto/review-system/internal/moderation"
+	"github.com/kirananto/review-system/internal/notify"
+	"github.com/kirananto/review-system/internal/webhook"
 	"gorm.io/gorm"
 )
 
 type ReviewService interface {
 	GetReviewsList(queryParam *dto.ReviewQueryParams) ([]*models.Review, int, *response.ErrorDetails)
-	GetReviewByID(id uint) (*models.Review, *response.ErrorDetails)
+	GetReviewsPage(queryParam *dto.ReviewQueryParams) (*dto.ReviewPage, *response.ErrorDetails)
+	SearchReviews(queryParam *dto.ReviewQueryParams) ([]*dto.ReviewSearchResult, int, *response.ErrorDetails)
+	ExportReviews(ctx context.Context, queryParam *dto.ReviewExportQueryParams, write func(*models.Review) error) *response.ErrorDetails
+	GetReviewByID(id uint, include ...string) (*models.Review, *response.ErrorDetails)
+	GetLatestReviewsByHotelIDs(hotelIDs []uint, limit int) ([]*models.Review, *response.ErrorDetails)
+	CreateReview(review *dto.ReviewRequestBody) (*models.Review, *response.ErrorDetails)
+	UpdateReview(id uint, review *dto.ReviewRequestBody) (*models.Review, *response.ErrorDetails)
+	PatchReview(id uint, patch *dto.ReviewPatchBody) (*models.Review, *response.ErrorDetails)
+	DeleteReview(id uint) *response.ErrorDetails
+	RestoreReview(id uint) (*models.Review, *response.ErrorDetails)
+	ModerateReview(id uint, action string, body *dto.ModerationRequestBody) (*models.Review, *response.ErrorDetails)
+	ModerateReviews(action string, body *dto.BatchModerationRequestBody) (*dto.BatchModerationResult, *response.ErrorDetails)
+	CreateReviewResponse(reviewID, hotelID uint, body *dto.ReviewResponseRequestBody) (*models.ReviewResponse, *response.ErrorDetails)
+	UpdateReviewResponse(reviewID, hotelID uint, body *dto.ReviewResponseRequestBody) (*models.ReviewResponse, *response.ErrorDetails)
+	DeleteReviewResponse(reviewID, hotelID uint) *response.ErrorDetails
 	ProcessReviews(ctx context.Context, reader io.Reader, fileName string) error
+	ProcessReview(ctx context.Context, line []byte) error
 }
 
+// ErrInvalidReview is returned by ProcessReview when a record can not be parsed
+// or fails validation. Retrying such a record will never succeed.
+var ErrInvalidReview = errors.New("invalid review")
+
 type reviewService struct {
-	repo   repository.ReviewRepository
-	logger *logger.Logger
+	repo           repository.ReviewRepository
+	logger         *logger.Logger
+	validator      *validator.ReviewValidator
+	hotelValidator *validator.HotelValidator
+	rules          *moderation.Pipeline
+	events         EventPublisher
+	notifier       *notify.Notifier
 }
 
-func NewReviewService(repo repository.ReviewRepository, logger *logger.Logger) ReviewService {
+// NewReviewService creates a review service. Ingested reviews are run through
+// the auto-moderation rules, unless rules is nil, and review and ingestion
+// events are published to events, unless it is nil. Summaries of ingested
+// files are sent through notifier, unless it is nil.
+func NewReviewService(repo repository.ReviewRepository, logger *logger.Logger, rules *moderation.Pipeline, events EventPublisher, notifier *notify.Notifier) ReviewService {
 	return &reviewService{
-		repo:   repo,
-		logger: logger,
+		repo:           repo,
+		logger:         logger,
+		validator:      validator.NewReviewValidator(repo),
+		hotelValidator: validator.NewHotelValidator(),
+		rules:          rules,
+		events:         events,
+		notifier:       notifier,
 	}
 }
 
 func (s *reviewService) GetReviewsList(queryParam *dto.ReviewQueryParams) ([]*models.Review, int, *response.ErrorDetails) {
+	if err := s.validator.ValidateReviewQueryParams(queryParam); err != nil {
+		return nil, 0, validationErrorDetails(err)
+	}
+
 	reviews, total, err := s.repo.GetReviewsList(queryParam)
 	if err != nil {
 		return nil, 0, &response.ErrorDetails{
@@ -49,8 +93,105 @@ func (s *reviewService) GetReviewsList(queryParam *dto.ReviewQueryParams) ([]*mo
 	return reviews, total, nil
 }
 
-func (s *reviewService) GetReviewByID(id uint) (*models.Review, *response.ErrorDetails) {
-	review, err := s.repo.GetReviewByID(id)
+// GetReviewsPage returns a page of reviews in cursor mode along with the
+// cursors of the pages either side of it.
+func (s *reviewService) GetReviewsPage(queryParam *dto.ReviewQueryParams) (*dto.ReviewPage, *response.ErrorDetails) {
+	if err := s.validator.ValidateReviewQueryParams(queryParam); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	var cursor *dto.ReviewCursor
+	if queryParam.Cursor != "" {
+		var err error
+		cursor, err = dto.DecodeReviewCursor(queryParam.Cursor)
+		if err == nil && cursor.Sort != queryParam.Sort {
+			err = fmt.Errorf("cursor was issued for sort %q: %w", cursor.Sort, dto.ErrInvalidCursor)
+		}
+		if err != nil {
+			return nil, &response.ErrorDetails{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid cursor",
+				Error:   err,
+			}
+		}
+	}
+
+	reviews, more, err := s.repo.GetReviewsPage(queryParam, cursor)
+	if err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	total, err := s.repo.CountReviews(queryParam)
+	if err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	page := &dto.ReviewPage{Reviews: reviews, Total: total}
+	if len(reviews) == 0 {
+		return page, nil
+	}
+
+	// A backward page always has a next page, the one its cursor came from,
+	// and a forward page reached through a cursor always has a previous one.
+	backward := cursor != nil && cursor.Backward
+	if more || backward {
+		next := dto.NewReviewCursor(queryParam.Sort, reviews[len(reviews)-1], false).Encode()
+		page.Next = &next
+	}
+	if (more && backward) || (cursor != nil && !backward) {
+		prev := dto.NewReviewCursor(queryParam.Sort, reviews[0], true).Encode()
+		page.Prev = &prev
+	}
+
+	return page, nil
+}
+
+func (s *reviewService) SearchReviews(queryParam *dto.ReviewQueryParams) ([]*dto.ReviewSearchResult, int, *response.ErrorDetails) {
+	if err := s.validator.ValidateReviewSearchParams(queryParam); err != nil {
+		return nil, 0, validationErrorDetails(err)
+	}
+
+	results, total, err := s.repo.SearchReviews(queryParam)
+	if err != nil {
+		return nil, 0, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	return results, total, nil
+}
+
+// ExportReviews passes every review matching the filters to write, in the
+// order of the reviews list, without holding them all in memory.
+func (s *reviewService) ExportReviews(ctx context.Context, queryParam *dto.ReviewExportQueryParams, write func(*models.Review) error) *response.ErrorDetails {
+	if err := s.validator.ValidateReviewExportParams(queryParam); err != nil {
+		return validationErrorDetails(err)
+	}
+
+	if err := s.repo.StreamReviews(ctx, &queryParam.ReviewQueryParams, write); err != nil {
+		return &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	return nil
+}
+
+// GetReviewByID returns a review with the included relations embedded.
+func (s *reviewService) GetReviewByID(id uint, include ...string) (*models.Review, *response.ErrorDetails) {
+	review, err := s.repo.GetReviewByID(id, include...)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, &response.ErrorDetails{
@@ -69,17 +210,259 @@ func (s *reviewService) GetReviewByID(id uint) (*models.Review, *response.ErrorD
 	return review, nil
 }
 
+// GetLatestReviewsByHotelIDs returns up to limit of the latest published
+// reviews of each of the given hotels, with their responses embedded.
+func (s *reviewService) GetLatestReviewsByHotelIDs(hotelIDs []uint, limit int) ([]*models.Review, *response.ErrorDetails) {
+	if limit <= 0 {
+		return nil, response.ValidationErrorDetails("limit", "limit must be greater than 0", nil)
+	}
+
+	reviews, err := s.repo.GetLatestReviewsByHotelIDs(hotelIDs, limit)
+	if err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	return reviews, nil
+}
+
+func (s *reviewService) CreateReview(reviewDto *dto.ReviewRequestBody) (*models.Review, *response.ErrorDetails) {
+	review := &models.Review{ID: reviewDto.ID, Status: models.ReviewStatusPending}
+	applyReviewRequestBody(review, reviewDto)
+
+	if err := s.validator.ValidateCreateReview(review); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	// A deleted review still holds its ID, so it has to be restored instead
+	if _, err := s.repo.Unscoped().GetReviewByID(review.ID); err == nil {
+		return nil, &response.ErrorDetails{
+			Code:      http.StatusConflict,
+			ErrorCode: response.CodeAlreadyExists,
+			Message:   "Review already exists",
+			Error:     fmt.Errorf("review %d already exists", review.ID),
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to create review",
+			Error:   err,
+		}
+	}
+
+	if err := s.repo.CreateReview(review); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to create review",
+			Error:   err,
+		}
+	}
+	s.publishReview(webhook.EventReviewCreated, review)
+	return review, nil
+}
+
+func (s *reviewService) UpdateReview(id uint, reviewDto *dto.ReviewRequestBody) (*models.Review, *response.ErrorDetails) {
+	review, errDetails := s.GetReviewByID(id)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+
+	applyReviewRequestBody(review, reviewDto)
+
+	if err := s.validator.ValidateReview(review); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	if err := s.repo.UpdateReview(review); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to update review",
+			Error:   err,
+		}
+	}
+	s.publishReview(webhook.EventReviewUpdated, review)
+	return review, nil
+}
+
+func (s *reviewService) PatchReview(id uint, patch *dto.ReviewPatchBody) (*models.Review, *response.ErrorDetails) {
+	review, errDetails := s.GetReviewByID(id)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+
+	if patch.ProviderID != nil {
+		review.ProviderID = *patch.ProviderID
+	}
+	if patch.HotelID != nil {
+		review.HotelID = *patch.HotelID
+	}
+	if patch.Rating != nil {
+		review.Rating = *patch.Rating
+	}
+	if patch.Title != nil {
+		review.Title = *patch.Title
+	}
+	if patch.Comment != nil {
+		review.Comment = *patch.Comment
+	}
+	if patch.Lang != nil {
+		review.Lang = *patch.Lang
+	}
+	if patch.ReviewDate != nil {
+		review.ReviewDate = *patch.ReviewDate
+	}
+	if len(patch.ReviewerInfo) > 0 {
+		review.ReviewerInfo = patch.ReviewerInfo
+	}
+
+	if err := s.validator.ValidateReview(review); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	if err := s.repo.UpdateReview(review); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to update review",
+			Error:   err,
+		}
+	}
+	s.publishReview(webhook.EventReviewUpdated, review)
+	return review, nil
+}
+
+func (s *reviewService) DeleteReview(id uint) *response.ErrorDetails {
+	review, errDetails := s.GetReviewByID(id)
+	if errDetails != nil {
+		return errDetails
+	}
+
+	if err := s.repo.DeleteReview(id); err != nil {
+		return &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete review",
+			Error:   err,
+		}
+	}
+	s.publishReview(webhook.EventReviewDeleted, review)
+	return nil
+}
+
+// RestoreReview restores a soft-deleted review. Its provider and hotel have to
+// be restored first.
+func (s *reviewService) RestoreReview(id uint) (*models.Review, *response.ErrorDetails) {
+	review, err := s.repo.Unscoped().GetReviewByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.ErrorDetails{
+				Code:    http.StatusNotFound,
+				Message: "Review not found",
+				Error:   err,
+			}
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	if !review.DeletedAt.Valid {
+		return nil, notDeletedErrorDetails("Review")
+	}
+	if errDetails := checkParentsRestored(s.repo, review.ProviderID, review.HotelID); errDetails != nil {
+		return nil, errDetails
+	}
+
+	if err := s.repo.RestoreReview(id); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to restore review",
+			Error:   err,
+		}
+	}
+
+	review, errDetails := s.GetReviewByID(id)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+	s.publishReview(webhook.EventReviewUpdated, review)
+	return review, nil
+}
+
+// publishReview publishes an event about a review, when the service has
+// somewhere to publish it.
+func (s *reviewService) publishReview(eventType string, review *models.Review) {
+	if s.events == nil {
+		return
+	}
+	s.events.Publish(&webhook.Event{
+		Type:       eventType,
+		HotelID:    review.HotelID,
+		ProviderID: review.ProviderID,
+		Data:       review,
+	})
+}
+
+// applyReviewRequestBody copies the writable fields of a request onto a review.
+func applyReviewRequestBody(review *models.Review, reviewDto *dto.ReviewRequestBody) {
+	review.ProviderID = reviewDto.ProviderID
+	review.HotelID = reviewDto.HotelID
+	review.Rating = reviewDto.Rating
+	review.Title = reviewDto.Title
+	review.Comment = reviewDto.Comment
+	review.Lang = reviewDto.Lang
+	review.ReviewDate = reviewDto.ReviewDate
+	review.ReviewerInfo = reviewDto.ReviewerInfo
+
+	if review.Lang == "" {
+		review.Lang = "en"
+	}
+	if len(review.ReviewerInfo) == 0 {
+		review.ReviewerInfo = []byte(`{}`)
+	}
+}
+
+// validationErrorDetails maps a validator error to a 400, or a 500 when the
+// validator itself failed.
+func validationErrorDetails(err error) *response.ErrorDetails {
+	var validationErr *validator.ValidationError
+	if errors.As(err, &validationErr) {
+		return response.ValidationErrorDetails(validationErr.Field, validationErr.Message, err)
+	}
+	return &response.ErrorDetails{
+		Code:    http.StatusInternalServerError,
+		Message: "Internal server error",
+		Error:   err,
+	}
+}
+
 type ReviewData struct {
 	HotelID   int    `json:"hotelId"`
 	Platform  string `json:"platform"`
 	HotelName string `json:"hotelName"`
-	Comment   struct {
-		HotelReviewID      int     `json:"hotelReviewId"`
-		Rating             float64 `json:"rating"`
-		ReviewComments     string  `json:"reviewComments"`
-		ReviewTitle        string  `json:"reviewTitle"`
-		ReviewDate         string  `json:"reviewDate"`
-		ReviewProviderText string  `json:"reviewProviderText"`
+	// HotelLocation is only sent by some providers
+	HotelLocation *struct {
+		Address     string   `json:"address"`
+		City        string   `json:"city"`
+		CountryCode string   `json:"countryCode"`
+		Latitude    *float64 `json:"latitude"`
+		Longitude   *float64 `json:"longitude"`
+		TimeZone    string   `json:"timeZone"`
+	} `json:"hotelLocation"`
+	Comment struct {
+		HotelReviewID         int             `json:"hotelReviewId"`
+		Rating                float64         `json:"rating"`
+		ReviewComments        string          `json:"reviewComments"`
+		ReviewTitle           string          `json:"reviewTitle"`
+		ReviewDate            string          `json:"reviewDate"`
+		ReviewProviderText    string          `json:"reviewProviderText"`
+		ReviewerInfo          json.RawMessage `json:"reviewerInfo"`
+		IsShowReviewResponse  bool            `json:"isShowReviewResponse"`
+		ResponderName         string          `json:"responderName"`
+		ResponseDateText      string          `json:"responseDateText"`
+		FormattedResponseDate string          `json:"formattedResponseDate"`
 	} `json:"comment"`
 	OverallByProviders []struct {
 		ProviderID   int     `json:"providerId"`
@@ -101,33 +484,39 @@ func (s *reviewService) ProcessReviews(ctx context.Context, reader io.Reader, fi
 	scanner := bufio.NewScanner(reader)
 	log := s.logger
 	var successCount, failureCount, totalCount int
+	ruleHits := make(map[string]int)
+	run := s.notifier.Start(fileName)
 
 	for scanner.Scan() {
 		totalCount++
-		var data ReviewData
 		line := scanner.Bytes()
 
-		if err := json.Unmarshal(line, &data); err != nil {
-			log.Error(err, fmt.Sprintf("Failed to parse JSON line: %v. Line: %s", err, string(line)))
+		provider, moderationResult, err := s.processReview(ctx, line)
+		run.Record(ctx, provider, err)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Failed to process line %d: %v. Line: %s", totalCount, err, string(line)))
 			failureCount++
 			continue
 		}
+		successCount++
 
-		if err := s.validateData(&data); err != nil {
-			log.Error(err, fmt.Sprintf("Invalid data: %v. Data: %+v", err, data))
-			failureCount++
-			continue
+		for _, rule := range moderationResult.Rules {
+			ruleHits[rule]++
 		}
+	}
 
-		if err := s.processRecord(ctx, &data); err != nil {
-			log.Error(err, fmt.Sprintf("Failed to process record: %v. Data: %+v", err, data))
-			failureCount++
-			continue
-		}
-		successCount++
+	importData := &webhook.ImportData{
+		FileName:     fileName,
+		SuccessCount: successCount,
+		FailureCount: failureCount,
+		TotalCount:   totalCount,
+		RuleHits:     ruleHits,
 	}
 
 	if err := scanner.Err(); err != nil {
+		importData.Error = fmt.Sprintf("error reading input: %v", err)
+		s.publishImport(webhook.EventImportFailed, importData)
+		run.Finish(ctx, err)
 		return fmt.Errorf("error reading input: %w", err)
 	}
 
@@ -136,6 +525,7 @@ func (s *reviewService) ProcessReviews(ctx context.Context, reader io.Reader, fi
 		SuccessCount: successCount,
 		FailureCount: failureCount,
 		TotalCount:   totalCount,
+		RuleHits:     ruleHits,
 	}
 
 	if err := s.repo.CreateAuditLog(auditLog); err != nil {
@@ -145,9 +535,54 @@ func (s *reviewService) ProcessReviews(ctx context.Context, reader io.Reader, fi
 
 	log.Info(fmt.Sprintf("Processed file: %s, Success: %d, Failed: %d, Total: %d", fileName, successCount, failureCount, totalCount))
 
+	if totalCount > 0 && failureCount == totalCount {
+		importData.Error = "no review could be imported"
+		s.publishImport(webhook.EventImportFailed, importData)
+	} else {
+		s.publishImport(webhook.EventImportCompleted, importData)
+	}
+	run.Finish(ctx, nil)
+
 	return nil
 }
 
+// publishImport publishes an event about an imported file, when the service
+// has somewhere to publish it.
+func (s *reviewService) publishImport(eventType string, data *webhook.ImportData) {
+	if s.events == nil {
+		return
+	}
+	s.events.Publish(&webhook.Event{Type: eventType, Data: data})
+}
+
+// ProcessReview parses, validates and upserts a single review line. Parse and
+// validation failures wrap ErrInvalidReview.
+func (s *reviewService) ProcessReview(ctx context.Context, line []byte) error {
+	_, _, err := s.processReview(ctx, line)
+	return err
+}
+
+// processReview ingests a review line and returns the provider it names, when
+// the line could be parsed, and what auto-moderation made of it.
+func (s *reviewService) processReview(ctx context.Context, line []byte) (string, *moderation.Result, error) {
+	var data ReviewData
+	if err := json.Unmarshal(line, &data); err != nil {
+		return "", nil, fmt.Errorf("%w: failed to parse JSON: %v", ErrInvalidReview, err)
+	}
+	provider := data.Comment.ReviewProviderText
+
+	if err := s.validateData(&data); err != nil {
+		return provider, nil, fmt.Errorf("%w: %v", ErrInvalidReview, err)
+	}
+
+	moderationResult, err := s.processRecord(ctx, &data)
+	if err != nil {
+		return provider, nil, fmt.Errorf("failed to process record: %w", err)
+	}
+
+	return provider, moderationResult, nil
+}
+
 func (s *reviewService) validateData(data *ReviewData) error {
 	if data.Comment.HotelReviewID == 0 {
 		return fmt.Errorf("HotelReviewID is required")
@@ -181,7 +616,7 @@ func (s *reviewService) validateData(data *ReviewData) error {
 	return nil
 }
 
-func (s *reviewService) processRecord(ctx context.Context, data *ReviewData) error {
+func (s *reviewService) processRecord(ctx context.Context, data *ReviewData) (*moderation.Result, error) {
 	log := s.logger
 	var wg sync.WaitGroup
 	errChan := make(chan error, 2)
@@ -202,7 +637,7 @@ func (s *reviewService) processRecord(ctx context.Context, data *ReviewData) err
 	go func() {
 		defer wg.Done()
 		var err error
-		hotel, err = s.getOrCreateHotel(data.HotelName)
+		hotel, err = s.getOrCreateHotel(data.HotelName, s.feedLocation(data))
 		if err != nil {
 			errChan <- err
 		}
@@ -213,7 +648,7 @@ func (s *reviewService) processRecord(ctx context.Context, data *ReviewData) err
 
 	for err := range errChan {
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -234,12 +669,16 @@ func (s *reviewService) processRecord(ctx context.Context, data *ReviewData) err
 
 	gradesJSON, err := json.Marshal(providerData.Grades)
 	if err != nil {
-		return fmt.Errorf("failed to marshal grades: %w", err)
+		return nil, fmt.Errorf("failed to marshal grades: %w", err)
 	}
 
+	// Records stored under a deleted hotel or provider are stored deleted with
+	// it, so restoring it brings them back
+	deletedAt := inheritedDeletion(provider.DeletedAt, hotel.DeletedAt)
+
 	// Get or create provider-hotel mapping
-	if _, err := s.getOrCreateProviderHotel(provider.ID, hotel.ID, providerData.OverallScore, providerData.ReviewCount, string(gradesJSON)); err != nil {
-		return err
+	if _, err := s.getOrCreateProviderHotel(provider.ID, hotel.ID, providerData.OverallScore, providerData.ReviewCount, string(gradesJSON), deletedAt); err != nil {
+		return nil, err
 	}
 
 	reviewDate, err := time.Parse(time.RFC3339, data.Comment.ReviewDate)
@@ -248,61 +687,218 @@ func (s *reviewService) processRecord(ctx context.Context, data *ReviewData) err
 		reviewDate = time.Now()
 	}
 
+	reviewerInfo := data.Comment.ReviewerInfo
+	if len(reviewerInfo) == 0 || string(reviewerInfo) == "null" {
+		reviewerInfo = []byte(`{}`)
+	}
+
 	// Create review
 	review := &models.Review{
 		ProviderID:   provider.ID,
 		HotelID:      hotel.ID,
 		ID:           uint(data.Comment.HotelReviewID),
 		Rating:       data.Comment.Rating,
+		Title:        data.Comment.ReviewTitle,
 		Comment:      data.Comment.ReviewComments,
 		Lang:         "en",
 		ReviewDate:   reviewDate,
-		ReviewerInfo: []byte(`{}`), // Empty JSON object since we don't have reviewer info
+		ReviewerInfo: reviewerInfo,
+		Status:       models.ReviewStatusPending,
+		DeletedAt:    deletedAt,
+	}
+	moderationResult := s.autoModerate(review)
+
+	// Whether the review is new decides which event it is published as
+	var existing *models.Review
+	if s.events != nil && !deletedAt.Valid {
+		existing, err = s.repo.Unscoped().GetReviewByID(review.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get review: %w", err)
+		}
 	}
 
+	// The status applies to new reviews. Updates keep the moderation decision
+	// already made, unless auto-moderation flags a review that can be flagged
 	if err := s.repo.UpsertReview(review); err != nil {
-		return fmt.Errorf("failed to create or update review: %w", err)
+		return nil, fmt.Errorf("failed to create or update review: %w", err)
 	}
 
-	return nil
+	// Deleted reviews are not visible, so they are not published
+	if s.events != nil && !deletedAt.Valid {
+		if existing == nil {
+			s.publishReview(webhook.EventReviewCreated, review)
+		} else if !existing.DeletedAt.Valid {
+			// The upsert decides the status, so the event carries the review
+			// as stored
+			updated, err := s.repo.GetReviewByID(review.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get review: %w", err)
+			}
+			// Files are re-ingested whole, so most lines change nothing
+			if ingestedReviewChanged(existing, updated) {
+				s.publishReview(webhook.EventReviewUpdated, updated)
+			}
+		}
+	}
+
+	if err := s.processReviewResponse(data, review); err != nil {
+		return nil, err
+	}
+
+	return moderationResult, nil
 }
 
+// ingestedReviewChanged reports whether re-ingesting a review changed what
+// ingestion writes or its status.
+func ingestedReviewChanged(before, after *models.Review) bool {
+	return before.Rating != after.Rating ||
+		before.Title != after.Title ||
+		before.Comment != after.Comment ||
+		!before.ReviewDate.Equal(after.ReviewDate) ||
+		!slices.Equal(before.ModerationRules, after.ModerationRules) ||
+		before.Status != after.Status
+}
+
+// autoModerate runs the auto-moderation rules over an ingested review, masking
+// its text and flagging it as they decide, and records the rules that fired.
+func (s *reviewService) autoModerate(review *models.Review) *moderation.Result {
+	if s.rules == nil {
+		return &moderation.Result{}
+	}
+
+	result := s.rules.Apply(review)
+	review.ModerationRules = result.Rules
+	if result.Flagged() {
+		now := time.Now()
+		review.Status = models.ReviewStatusFlagged
+		review.ModerationReason = fmt.Sprintf("Matched moderation rules: %s", strings.Join(result.FlaggedBy, ", "))
+		review.ModeratedBy = moderation.Moderator
+		review.ModeratedAt = &now
+	}
+	return result
+}
+
+// getOrCreateProvider finds a provider by name, deleted or not, so a deleted
+// provider is never created again.
 func (s *reviewService) getOrCreateProvider(name string) (*models.Provider, error) {
-	provider, err := s.repo.GetProviderByName(name)
+	provider, err := s.repo.Unscoped().GetProviderByName(name)
 	if err == nil {
 		return provider, nil
 	}
 
 	provider = &models.Provider{Name: name}
 	if err := s.repo.CreateProvider(provider); err != nil {
+		// Another ingestion created it in the meantime
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return s.repo.GetProviderByName(name)
+		}
 		return nil, fmt.Errorf("failed to create provider: %w", err)
 	}
 
 	return provider, nil
 }
 
-func (s *reviewService) getOrCreateHotel(name string) (*models.Hotel, error) {
-	hotel, err := s.repo.GetHotelByName(name)
-	if err == nil {
+// feedLocation returns the hotel location a feed line carries, or nil when it
+// has none or it is invalid.
+func (s *reviewService) feedLocation(data *ReviewData) *models.HotelLocation {
+	if data.HotelLocation == nil {
+		return nil
+	}
+
+	location := normalizeLocation(models.HotelLocation{
+		Address:     data.HotelLocation.Address,
+		City:        data.HotelLocation.City,
+		CountryCode: data.HotelLocation.CountryCode,
+		Latitude:    data.HotelLocation.Latitude,
+		Longitude:   data.HotelLocation.Longitude,
+		Timezone:    data.HotelLocation.TimeZone,
+	})
+	if err := s.hotelValidator.ValidateLocation(&location); err != nil {
+		s.logger.Error(err, fmt.Sprintf("Ignoring invalid location of hotel %s", data.HotelName))
+		return nil
+	}
+	return &location
+}
+
+// getOrCreateHotel finds the hotel of a name at a location, deleted or not, so
+// a deleted hotel is never created again. The location from the feed fills in
+// whatever the hotel is missing, but never overwrites what is already there.
+func (s *reviewService) getOrCreateHotel(name string, location *models.HotelLocation) (*models.Hotel, error) {
+	repo := s.repo.Unscoped()
+	hotels, err := repo.GetHotelsByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hotel: %w", err)
+	}
+	if hotel := matchHotel(hotels, location); hotel != nil {
+		if location != nil && fillLocation(&hotel.HotelLocation, location) {
+			if err := repo.UpdateHotel(hotel); err != nil {
+				return nil, fmt.Errorf("failed to update hotel location: %w", err)
+			}
+		}
 		return hotel, nil
 	}
 
-	hotel = &models.Hotel{HotelName: name}
+	hotel := &models.Hotel{HotelName: name}
+	if location != nil {
+		hotel.HotelLocation = *location
+	}
 	if err := s.repo.CreateHotel(hotel); err != nil {
+		// Another ingestion created it in the meantime
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			hotels, err := s.repo.GetHotelsByName(name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get hotel: %w", err)
+			}
+			if hotel := matchHotel(hotels, location); hotel != nil {
+				return hotel, nil
+			}
+		}
 		return nil, fmt.Errorf("failed to create hotel: %w", err)
 	}
 
 	return hotel, nil
 }
 
-func (s *reviewService) getOrCreateProviderHotel(providerID, hotelID uint, overallScore float64, reviewCount int, gradesJSON string) (*models.ProviderHotel, error) {
-	providerHotel, err := s.repo.GetProviderHotel(providerID, hotelID)
+// matchHotel returns the hotel among hotels of the same name that is at
+// location, or nil. A hotel is at a location when its city and country agree
+// with it wherever both are known, so a line without a location matches any of
+// them. Hotels known to be at the location are preferred over hotels missing
+// their city or country; otherwise the order of hotels decides.
+func matchHotel(hotels []*models.Hotel, location *models.HotelLocation) *models.Hotel {
+	var city, country string
+	if location != nil {
+		city, country = location.City, location.CountryCode
+	}
+
+	var match *models.Hotel
+	best := -1
+	for _, hotel := range hotels {
+		known := 0
+		agrees := func(part, other string) bool {
+			if part == "" || other == "" {
+				return true
+			}
+			known++
+			return strings.EqualFold(part, other)
+		}
+		if agrees(hotel.City, city) && agrees(hotel.CountryCode, country) && known > best {
+			match, best = hotel, known
+		}
+	}
+	return match
+}
+
+// getOrCreateProviderHotel updates the stats of a provider-hotel mapping,
+// deleted or not, or creates it with the given deletion state.
+func (s *reviewService) getOrCreateProviderHotel(providerID, hotelID uint, overallScore float64, reviewCount int, gradesJSON string, deletedAt gorm.DeletedAt) (*models.ProviderHotel, error) {
+	repo := s.repo.Unscoped()
+	providerHotel, err := repo.GetProviderHotel(providerID, hotelID)
 	if err == nil {
 		// Update existing record
 		providerHotel.OverallScore = overallScore
 		providerHotel.ReviewCount = reviewCount
 		providerHotel.Grades = []byte(gradesJSON)
-		if err := s.repo.UpdateProviderHotel(providerHotel); err != nil {
+		if err := repo.UpdateProviderHotel(providerHotel); err != nil {
 			return nil, fmt.Errorf("failed to update provider hotel: %w", err)
 		}
 		return providerHotel, nil
@@ -315,11 +911,46 @@ func (s *reviewService) getOrCreateProviderHotel(providerID, hotelID uint, overa
 		OverallScore: overallScore,
 		ReviewCount:  reviewCount,
 		Grades:       []byte(gradesJSON),
+		DeletedAt:    deletedAt,
 	}
 
-	if err := s.repo.CreateProviderHotel(providerHotel); err != nil {
+	if err := repo.CreateProviderHotel(providerHotel); err != nil {
 		return nil, fmt.Errorf("failed to create provider hotel: %w", err)
 	}
 
 	return providerHotel, nil
 }
+
+// inheritedDeletion is the deletion state of a record stored under the given
+// parents: that of the first deleted parent, if any.
+func inheritedDeletion(parents ...gorm.DeletedAt) gorm.DeletedAt {
+	for _, deletedAt := range parents {
+		if deletedAt.Valid {
+			return deletedAt
+		}
+	}
+	return gorm.DeletedAt{}
+}
+
+// fillLocation copies the parts of from that are blank in location, the
+// coordinates only as a pair, and reports whether anything changed.
+func fillLocation(location, from *models.HotelLocation) bool {
+	changed := false
+	fill := func(field *string, value string) {
+		if *field == "" && value != "" {
+			*field = value
+			changed = true
+		}
+	}
+	fill(&location.Address, from.Address)
+	fill(&location.City, from.City)
+	fill(&location.CountryCode, from.CountryCode)
+	fill(&location.Timezone, from.Timezone)
+
+	if location.Latitude == nil && location.Longitude == nil && from.Latitude != nil && from.Longitude != nil {
+		location.Latitude = from.Latitude
+		location.Longitude = from.Longitude
+		changed = true
+	}
+	return changed
+}
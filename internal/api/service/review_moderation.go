@@ -0,0 +1,112 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/kirananto/review-system/internal/webhook"
+)
+
+// ModerateReview applies a moderation action to one review and returns the
+// review as moderated. Actions the review's current status does not allow are
+// a conflict.
+func (s *reviewService) ModerateReview(id uint, action string, body *dto.ModerationRequestBody) (*models.Review, *response.ErrorDetails) {
+	if err := s.validator.ValidateModeration(action, body); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	transition := dto.ModerationTransitions[action]
+	moderated, err := s.repo.ModerateReviews([]uint{id}, transition.From, newReviewModeration(transition, body))
+	if err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to moderate review",
+			Error:   err,
+		}
+	}
+
+	review, errDetails := s.GetReviewByID(id)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+	if len(moderated) == 0 {
+		return nil, &response.ErrorDetails{
+			Code:      http.StatusConflict,
+			ErrorCode: response.CodeInvalidTransition,
+			Message:   fmt.Sprintf("Can not %s a %s review", action, review.Status),
+			Error:     fmt.Errorf("review %d is %s", id, review.Status),
+		}
+	}
+	s.publishReview(webhook.EventReviewUpdated, review)
+
+	return review, nil
+}
+
+// ModerateReviews applies a moderation action to a batch of reviews. Reviews
+// that do not exist or whose status does not allow the action are skipped
+// rather than failing the batch.
+func (s *reviewService) ModerateReviews(action string, body *dto.BatchModerationRequestBody) (*dto.BatchModerationResult, *response.ErrorDetails) {
+	if err := s.validator.ValidateBatchModeration(action, body); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	ids := slices.Clone(body.IDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	transition := dto.ModerationTransitions[action]
+	moderated, err := s.repo.ModerateReviews(ids, transition.From, newReviewModeration(transition, &body.ModerationRequestBody))
+	if err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to moderate reviews",
+			Error:   err,
+		}
+	}
+
+	slices.Sort(moderated)
+	s.publishModerated(moderated)
+	result := &dto.BatchModerationResult{
+		Status:    transition.To,
+		Moderated: moderated,
+		Skipped:   []uint{},
+	}
+	for _, id := range ids {
+		if _, found := slices.BinarySearch(moderated, id); !found {
+			result.Skipped = append(result.Skipped, id)
+		}
+	}
+
+	return result, nil
+}
+
+// publishModerated publishes the reviews a batch moderated. Reviews that can
+// no longer be loaded are logged and skipped.
+func (s *reviewService) publishModerated(ids []uint) {
+	if s.events == nil {
+		return
+	}
+	for _, id := range ids {
+		review, err := s.repo.GetReviewByID(id)
+		if err != nil {
+			s.logger.Error(err, fmt.Sprintf("Failed to load moderated review %d to publish it", id))
+			continue
+		}
+		s.publishReview(webhook.EventReviewUpdated, review)
+	}
+}
+
+func newReviewModeration(transition dto.ModerationTransition, body *dto.ModerationRequestBody) *dto.ReviewModeration {
+	return &dto.ReviewModeration{
+		Status:    transition.To,
+		Reason:    strings.TrimSpace(body.Reason),
+		Moderator: strings.TrimSpace(body.Moderator),
+		At:        time.Now(),
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm"
+)
+
+// providerResponseDateLayout is the layout of formattedResponseDate in
+// provider feeds, e.g. "April 12, 2025".
+const providerResponseDateLayout = "January 2, 2006"
+
+// CreateReviewResponse posts a hotel's response to a review. hotelID is the
+// hotel the caller acts for, or 0 for admins, who may respond for any hotel.
+func (s *reviewService) CreateReviewResponse(reviewID, hotelID uint, body *dto.ReviewResponseRequestBody) (*models.ReviewResponse, *response.ErrorDetails) {
+	if err := s.validator.ValidateReviewResponse(body); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	review, errDetails := s.respondableReview(reviewID, hotelID)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+	if review.Response != nil {
+		return nil, reviewResponseConflictErrorDetails(fmt.Errorf("review %d already has a response", reviewID))
+	}
+
+	now := time.Now()
+	reviewResponse := &models.ReviewResponse{
+		ReviewID:      review.ID,
+		HotelID:       review.HotelID,
+		ResponderName: strings.TrimSpace(body.ResponderName),
+		Body:          body.Body,
+		ResponseDate:  &now,
+		Source:        models.ReviewResponseSourceAPI,
+	}
+	if err := s.repo.CreateReviewResponse(reviewResponse); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, reviewResponseConflictErrorDetails(err)
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to create review response",
+			Error:   err,
+		}
+	}
+	return reviewResponse, nil
+}
+
+// UpdateReviewResponse edits the response a hotel posted to a review.
+func (s *reviewService) UpdateReviewResponse(reviewID, hotelID uint, body *dto.ReviewResponseRequestBody) (*models.ReviewResponse, *response.ErrorDetails) {
+	if err := s.validator.ValidateReviewResponse(body); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	reviewResponse, errDetails := s.editableReviewResponse(reviewID, hotelID)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+
+	reviewResponse.ResponderName = strings.TrimSpace(body.ResponderName)
+	reviewResponse.Body = body.Body
+	if err := s.repo.UpdateReviewResponse(reviewResponse); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to update review response",
+			Error:   err,
+		}
+	}
+	return reviewResponse, nil
+}
+
+// DeleteReviewResponse removes the response a hotel posted to a review.
+func (s *reviewService) DeleteReviewResponse(reviewID, hotelID uint) *response.ErrorDetails {
+	reviewResponse, errDetails := s.editableReviewResponse(reviewID, hotelID)
+	if errDetails != nil {
+		return errDetails
+	}
+
+	if err := s.repo.DeleteReviewResponse(reviewResponse.ID); err != nil {
+		return &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete review response",
+			Error:   err,
+		}
+	}
+	return nil
+}
+
+// respondableReview loads a review, with its response, that the caller may
+// respond to. Hotels only see published reviews and may only respond to their
+// own.
+func (s *reviewService) respondableReview(reviewID, hotelID uint) (*models.Review, *response.ErrorDetails) {
+	review, errDetails := s.GetReviewByID(reviewID, dto.RelationResponse)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+	if hotelID == 0 {
+		return review, nil
+	}
+
+	if review.Status != models.ReviewStatusPublished {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Review not found",
+			Error:   fmt.Errorf("review %d is %s", reviewID, review.Status),
+		}
+	}
+	if review.HotelID != hotelID {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusForbidden,
+			Message: "You can only respond to reviews of your own hotel",
+			Error:   fmt.Errorf("review %d is of hotel %d, not %d", reviewID, review.HotelID, hotelID),
+		}
+	}
+	return review, nil
+}
+
+// editableReviewResponse loads the response to a review that the caller may
+// change. Responses imported from a provider mirror the provider's site, so
+// they can not be changed here.
+func (s *reviewService) editableReviewResponse(reviewID, hotelID uint) (*models.ReviewResponse, *response.ErrorDetails) {
+	review, errDetails := s.respondableReview(reviewID, hotelID)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+	if review.Response == nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusNotFound,
+			Message: "Review response not found",
+			Error:   fmt.Errorf("review %d has no response", reviewID),
+		}
+	}
+	if review.Response.Source != models.ReviewResponseSourceAPI {
+		return nil, &response.ErrorDetails{
+			Code:      http.StatusConflict,
+			ErrorCode: response.CodeProviderManaged,
+			Message:   "Responses imported from a provider can only be changed on the provider",
+			Error:     fmt.Errorf("response to review %d came from the provider", reviewID),
+		}
+	}
+	return review.Response, nil
+}
+
+func reviewResponseConflictErrorDetails(err error) *response.ErrorDetails {
+	return &response.ErrorDetails{
+		Code:      http.StatusConflict,
+		ErrorCode: response.CodeAlreadyExists,
+		Message:   "This review already has a response",
+		Error:     err,
+	}
+}
+
+// processReviewResponse stores the hotel's response a feed record carries, if
+// it shows one.
+func (s *reviewService) processReviewResponse(data *ReviewData, review *models.Review) error {
+	if !data.Comment.IsShowReviewResponse || strings.TrimSpace(data.Comment.ResponderName) == "" {
+		return nil
+	}
+
+	reviewResponse := &models.ReviewResponse{
+		ReviewID:         review.ID,
+		HotelID:          review.HotelID,
+		ResponderName:    data.Comment.ResponderName,
+		ResponseDateText: data.Comment.ResponseDateText,
+		Source:           models.ReviewResponseSourceProvider,
+	}
+	if responseDate, err := time.Parse(providerResponseDateLayout, data.Comment.FormattedResponseDate); err == nil {
+		reviewResponse.ResponseDate = &responseDate
+	} else {
+		s.logger.Info(fmt.Sprintf("Could not parse response date of review %d: %v", review.ID, err))
+	}
+
+	if err := s.repo.UpsertProviderReviewResponse(reviewResponse); err != nil {
+		return fmt.Errorf("failed to create or update review response: %w", err)
+	}
+	return nil
+}
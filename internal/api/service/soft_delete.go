@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kirananto/review-system/internal/api/repository"
+	"github.com/kirananto/review-system/internal/api/response"
+	"gorm.io/gorm"
+)
+
+// notDeletedErrorDetails answers a restore of an entity that is not deleted.
+func notDeletedErrorDetails(entity string) *response.ErrorDetails {
+	return &response.ErrorDetails{
+		Code:      http.StatusConflict,
+		ErrorCode: response.CodeNotDeleted,
+		Message:   entity + " is not deleted",
+		Error:     fmt.Errorf("%s is not deleted", entity),
+	}
+}
+
+// checkParentsRestored makes sure the provider and hotel a mapping or review
+// belongs to are not deleted, so nothing is restored under a deleted parent.
+func checkParentsRestored(repo repository.ReviewRepository, providerID, hotelID uint) *response.ErrorDetails {
+	if _, err := repo.GetProviderByID(providerID); err != nil {
+		return deletedParentErrorDetails("provider", err)
+	}
+	if _, err := repo.GetHotelByID(hotelID); err != nil {
+		return deletedParentErrorDetails("hotel", err)
+	}
+	return nil
+}
+
+func deletedParentErrorDetails(parent string, err error) *response.ErrorDetails {
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+	return &response.ErrorDetails{
+		Code:      http.StatusConflict,
+		ErrorCode: response.CodeParentDeleted,
+		Message:   fmt.Sprintf("The %s is deleted, restore it first", parent),
+		Error:     err,
+	}
+}
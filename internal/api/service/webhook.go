@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/repository"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/validator"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/kirananto/review-system/internal/webhook"
+	"gorm.io/gorm"
+)
+
+// EventPublisher is sent the review and ingestion events of the review
+// service.
+type EventPublisher interface {
+	Publish(event *webhook.Event)
+}
+
+type WebhookService interface {
+	GetWebhookSubscriptionsList(queryParams *dto.WebhookSubscriptionsQueryParams) ([]*models.WebhookSubscription, int, *response.ErrorDetails)
+	GetWebhookSubscriptionByID(id uint) (*models.WebhookSubscription, *response.ErrorDetails)
+	CreateWebhookSubscription(body *dto.WebhookSubscriptionRequestBody) (*dto.WebhookSubscriptionWithSecret, *response.ErrorDetails)
+	UpdateWebhookSubscription(id uint, body *dto.WebhookSubscriptionRequestBody) (*dto.WebhookSubscriptionWithSecret, *response.ErrorDetails)
+	DeleteWebhookSubscription(id uint) *response.ErrorDetails
+	GetWebhookDeliveriesList(queryParams *dto.WebhookDeliveriesQueryParams) ([]*models.WebhookDelivery, int, *response.ErrorDetails)
+	GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, *response.ErrorDetails)
+	ReplayWebhookDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, *response.ErrorDetails)
+}
+
+type webhookService struct {
+	repo       repository.ReviewRepository
+	logger     *logger.Logger
+	validator  *validator.WebhookValidator
+	dispatcher *webhook.Dispatcher
+}
+
+// NewWebhookService creates a webhook service. Replayed deliveries are sent
+// right away with dispatcher.
+func NewWebhookService(repo repository.ReviewRepository, logger *logger.Logger, dispatcher *webhook.Dispatcher) WebhookService {
+	return &webhookService{
+		repo:       repo,
+		logger:     logger,
+		validator:  validator.NewWebhookValidator(),
+		dispatcher: dispatcher,
+	}
+}
+
+func (s *webhookService) GetWebhookSubscriptionsList(queryParams *dto.WebhookSubscriptionsQueryParams) ([]*models.WebhookSubscription, int, *response.ErrorDetails) {
+	if err := s.validator.ValidateWebhookSubscriptionsQueryParams(queryParams); err != nil {
+		return nil, 0, validationErrorDetails(err)
+	}
+
+	subscriptions, total, err := s.repo.GetWebhookSubscriptionsList(queryParams)
+	if err != nil {
+		return nil, 0, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	return subscriptions, total, nil
+}
+
+func (s *webhookService) GetWebhookSubscriptionByID(id uint) (*models.WebhookSubscription, *response.ErrorDetails) {
+	subscription, err := s.repo.GetWebhookSubscriptionByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.ErrorDetails{
+				Code:    http.StatusNotFound,
+				Message: "Webhook subscription not found",
+				Error:   err,
+			}
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	return subscription, nil
+}
+
+// CreateWebhookSubscription creates a subscription and returns it along with
+// its secret, which is generated when the request has none.
+func (s *webhookService) CreateWebhookSubscription(body *dto.WebhookSubscriptionRequestBody) (*dto.WebhookSubscriptionWithSecret, *response.ErrorDetails) {
+	if err := s.validator.ValidateWebhookSubscriptionRequestBody(body); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	subscription := &models.WebhookSubscription{Secret: body.Secret}
+	if subscription.Secret == "" {
+		subscription.Secret = webhook.NewSecret()
+	}
+	applyWebhookSubscriptionRequestBody(subscription, body)
+
+	if err := s.repo.CreateWebhookSubscription(subscription); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to create webhook subscription",
+			Error:   err,
+		}
+	}
+	return &dto.WebhookSubscriptionWithSecret{WebhookSubscription: subscription, Secret: subscription.Secret}, nil
+}
+
+// UpdateWebhookSubscription replaces a subscription. Its secret is only
+// changed, and returned, when the request has one.
+func (s *webhookService) UpdateWebhookSubscription(id uint, body *dto.WebhookSubscriptionRequestBody) (*dto.WebhookSubscriptionWithSecret, *response.ErrorDetails) {
+	if err := s.validator.ValidateWebhookSubscriptionRequestBody(body); err != nil {
+		return nil, validationErrorDetails(err)
+	}
+
+	subscription, errDetails := s.GetWebhookSubscriptionByID(id)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+
+	applyWebhookSubscriptionRequestBody(subscription, body)
+	if body.Secret != "" {
+		subscription.Secret = body.Secret
+	}
+
+	if err := s.repo.UpdateWebhookSubscription(subscription); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to update webhook subscription",
+			Error:   err,
+		}
+	}
+	return &dto.WebhookSubscriptionWithSecret{WebhookSubscription: subscription, Secret: body.Secret}, nil
+}
+
+// DeleteWebhookSubscription deletes a subscription along with its delivery log.
+func (s *webhookService) DeleteWebhookSubscription(id uint) *response.ErrorDetails {
+	if _, errDetails := s.GetWebhookSubscriptionByID(id); errDetails != nil {
+		return errDetails
+	}
+
+	if err := s.repo.DeleteWebhookSubscription(id); err != nil {
+		return &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to delete webhook subscription",
+			Error:   err,
+		}
+	}
+	return nil
+}
+
+// GetWebhookDeliveriesList returns a page of the delivery log, newest first.
+func (s *webhookService) GetWebhookDeliveriesList(queryParams *dto.WebhookDeliveriesQueryParams) ([]*models.WebhookDelivery, int, *response.ErrorDetails) {
+	if err := s.validator.ValidateWebhookDeliveriesQueryParams(queryParams); err != nil {
+		return nil, 0, validationErrorDetails(err)
+	}
+
+	deliveries, total, err := s.repo.GetWebhookDeliveriesList(queryParams)
+	if err != nil {
+		return nil, 0, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	return deliveries, total, nil
+}
+
+func (s *webhookService) GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, *response.ErrorDetails) {
+	delivery, err := s.repo.GetWebhookDeliveryByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.ErrorDetails{
+				Code:    http.StatusNotFound,
+				Message: "Webhook delivery not found",
+				Error:   err,
+			}
+		}
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   err,
+		}
+	}
+
+	return delivery, nil
+}
+
+// ReplayWebhookDelivery sends the event of a delivery to its subscription
+// again, as a new delivery that is attempted right away. When that attempt
+// fails, the new delivery is retried like any other.
+func (s *webhookService) ReplayWebhookDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, *response.ErrorDetails) {
+	original, errDetails := s.GetWebhookDeliveryByID(id)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+
+	subscription, errDetails := s.GetWebhookSubscriptionByID(original.SubscriptionID)
+	if errDetails != nil {
+		return nil, errDetails
+	}
+	if subscription.Disabled {
+		return nil, &response.ErrorDetails{
+			Code:      http.StatusConflict,
+			ErrorCode: response.CodeConflict,
+			Message:   "Webhook subscription is disabled",
+			Error:     fmt.Errorf("webhook subscription %d is disabled", subscription.ID),
+		}
+	}
+
+	// The delivery is leased, so no dispatcher claims it while it is sent
+	leasedUntil := s.dispatcher.LeaseUntil(time.Now())
+	delivery := &models.WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  &leasedUntil,
+		ReplayOf:       &original.ID,
+	}
+	if err := s.repo.CreateWebhookDeliveries([]*models.WebhookDelivery{delivery}); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to replay webhook delivery",
+			Error:   err,
+		}
+	}
+
+	if err := s.dispatcher.Deliver(ctx, delivery, subscription); err != nil {
+		return nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to replay webhook delivery",
+			Error:   err,
+		}
+	}
+	return delivery, nil
+}
+
+// applyWebhookSubscriptionRequestBody copies the writable fields of a request
+// onto a subscription, except for its secret.
+func applyWebhookSubscriptionRequestBody(subscription *models.WebhookSubscription, body *dto.WebhookSubscriptionRequestBody) {
+	subscription.URL = body.URL
+	subscription.Description = body.Description
+	subscription.EventTypes = body.EventTypes
+	subscription.HotelID = body.HotelID
+	subscription.ProviderID = body.ProviderID
+	subscription.Disabled = body.Disabled
+}
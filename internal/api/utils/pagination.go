@@ -46,3 +46,30 @@ func generatePageURL(r *http.Request, offset, limit int) string {
 
 	return urlCopy.String()
 }
+
+// GetCursorLinks returns the previous and next page URLs for cursor pagination
+func GetCursorLinks(r *http.Request, prevCursor, nextCursor *string) (prevURL, nextURL *string) {
+	if prevCursor != nil {
+		prev := generateCursorURL(r, *prevCursor)
+		prevURL = &prev
+	}
+	if nextCursor != nil {
+		next := generateCursorURL(r, *nextCursor)
+		nextURL = &next
+	}
+	return prevURL, nextURL
+}
+
+// generateCursorURL creates a new URL with the given cursor
+func generateCursorURL(r *http.Request, cursor string) string {
+	urlCopy := *r.URL
+
+	q := urlCopy.Query()
+	q.Del("offset")
+	q.Del("pagination")
+	q.Set("cursor", cursor)
+
+	urlCopy.RawQuery = q.Encode()
+
+	return urlCopy.String()
+}
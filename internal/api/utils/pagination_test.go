@@ -71,3 +71,16 @@ func TestGetPaginationLinks(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestGetCursorLinks(t *testing.T) {
+	req := httptest.NewRequest("GET", "/reviews?pagination=cursor&limit=10&hotel_id=1", nil)
+
+	prev, next := GetCursorLinks(req, nil, stringPtr("abc"))
+	assert.Nil(t, prev)
+	assert.Equal(t, stringPtr("/reviews?cursor=abc&hotel_id=1&limit=10"), next)
+
+	req = httptest.NewRequest("GET", "/reviews?cursor=abc&limit=10", nil)
+	prev, next = GetCursorLinks(req, stringPtr("xyz"), nil)
+	assert.Equal(t, stringPtr("/reviews?cursor=xyz&limit=10"), prev)
+	assert.Nil(t, next)
+}
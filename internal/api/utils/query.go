@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/gorilla/schema"
+	"github.com/kirananto/review-system/internal/api/dto"
+)
+
+// NewQueryDecoder returns a decoder for query parameters that also understands
+// dates, given either as an RFC 3339 timestamp or as a plain YYYY-MM-DD date,
+// and positions given as lat,lng.
+func NewQueryDecoder() *schema.Decoder {
+	decoder := schema.NewDecoder()
+	decoder.RegisterConverter(time.Time{}, convertTime)
+	decoder.RegisterConverter(dto.RangeEnd{}, convertRangeEnd)
+	decoder.RegisterConverter(dto.GeoPoint{}, convertGeoPoint)
+	return decoder
+}
+
+func convertGeoPoint(value string) reflect.Value {
+	point, err := dto.ParseGeoPoint(value)
+	if err != nil {
+		// An invalid value makes the decoder report a conversion error.
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(point)
+}
+
+func convertRangeEnd(value string) reflect.Value {
+	end, err := dto.ParseRangeEnd(value)
+	if err != nil {
+		// An invalid value makes the decoder report a conversion error.
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(end)
+}
+
+func convertTime(value string) reflect.Value {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return reflect.ValueOf(t)
+		}
+	}
+	// An invalid value makes the decoder report a conversion error.
+	return reflect.Value{}
+}
@@ -0,0 +1,24 @@
+package validator
+
+import "github.com/kirananto/review-system/internal/api/dto"
+
+type AuditLogValidator struct{}
+
+func NewAuditLogValidator() *AuditLogValidator {
+	return &AuditLogValidator{}
+}
+
+// ValidateAuditLogsQueryParams validates the pagination and filters of an
+// audit logs list request.
+func (v *AuditLogValidator) ValidateAuditLogsQueryParams(params *dto.AuditLogsQueryParams) error {
+	if params.Limit <= 0 {
+		return newValidationError("limit", "limit must be greater than 0")
+	}
+	if params.Offset < 0 {
+		return newValidationError("offset", "offset can not be negative")
+	}
+	if !params.CreatedFrom.IsZero() && !params.CreatedTo.IsZero() && params.CreatedFrom.After(params.CreatedTo) {
+		return newValidationError("created_from", "created_from can not be after created_to")
+	}
+	return nil
+}
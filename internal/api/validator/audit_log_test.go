@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogValidator_ValidateAuditLogsQueryParams(t *testing.T) {
+	validator := NewAuditLogValidator()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		params      dto.AuditLogsQueryParams
+		expectedErr string
+	}{
+		{
+			name:        "valid request",
+			params:      dto.AuditLogsQueryParams{Limit: 20, CreatedFrom: now.Add(-time.Hour), CreatedTo: now},
+			expectedErr: "",
+		},
+		{
+			name:        "zero limit",
+			params:      dto.AuditLogsQueryParams{},
+			expectedErr: "limit must be greater than 0",
+		},
+		{
+			name:        "negative offset",
+			params:      dto.AuditLogsQueryParams{Limit: 20, Offset: -1},
+			expectedErr: "offset can not be negative",
+		},
+		{
+			name:        "created_from after created_to",
+			params:      dto.AuditLogsQueryParams{Limit: 20, CreatedFrom: now, CreatedTo: now.Add(-time.Hour)},
+			expectedErr: "created_from can not be after created_to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateAuditLogsQueryParams(&tt.params)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
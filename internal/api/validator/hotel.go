@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+	// Embeds the time zone database, which the Lambda image does not ship,
+	// so time zone names can be checked
+	_ "time/tzdata"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/models"
+)
+
+const (
+	// MaxAddressLength is the longest hotel address accepted.
+	MaxAddressLength = 500
+	// MaxRadiusKm is the widest radius a geo search accepts.
+	MaxRadiusKm = 500
+)
+
+type HotelValidator struct{}
+
+func NewHotelValidator() *HotelValidator {
+	return &HotelValidator{}
+}
+
+// ValidateHotel validates a hotel to be created or updated.
+func (v *HotelValidator) ValidateHotel(req *dto.HotelRequestBody) error {
+	if err := validateName("hotel_name", req.HotelName); err != nil {
+		return err
+	}
+	return v.ValidateLocation(&req.HotelLocation)
+}
+
+// ValidateLocation validates a hotel's location. Every part is optional, but
+// latitude and longitude go together.
+func (v *HotelValidator) ValidateLocation(location *models.HotelLocation) error {
+	if len(location.Address) > MaxAddressLength {
+		return newValidationError("address", fmt.Sprintf("address must be at most %d characters", MaxAddressLength))
+	}
+	if len(location.City) > MaxNameLength {
+		return newValidationError("city", fmt.Sprintf("city must be at most %d characters", MaxNameLength))
+	}
+	if location.CountryCode != "" && !isCountryCode(location.CountryCode) {
+		return newValidationError("country_code", "country_code must be an ISO 3166-1 alpha-2 code, e.g. VN")
+	}
+
+	if (location.Latitude == nil) != (location.Longitude == nil) {
+		return newValidationError("latitude", "latitude and longitude must be set together")
+	}
+	if location.Latitude != nil && (*location.Latitude < -90 || *location.Latitude > 90) {
+		return newValidationError("latitude", "latitude must be between -90 and 90")
+	}
+	if location.Longitude != nil && (*location.Longitude < -180 || *location.Longitude > 180) {
+		return newValidationError("longitude", "longitude must be between -180 and 180")
+	}
+
+	if location.Timezone != "" {
+		// LoadLocation also accepts "Local", the zone of the machine we run on
+		if _, err := time.LoadLocation(location.Timezone); err != nil || location.Timezone == "Local" {
+			return newValidationError("timezone", "timezone must be an IANA time zone name, e.g. Asia/Ho_Chi_Minh")
+		}
+	}
+	return nil
+}
+
+// ValidateHotelsQueryParams validates the geo search parameters of a hotels
+// list request.
+func (v *HotelValidator) ValidateHotelsQueryParams(params *dto.HotelsQueryParams) error {
+	if params.RadiusKm != 0 && params.Near == nil {
+		return newValidationError("radius_km", "radius_km requires near")
+	}
+	if params.RadiusKm < 0 || params.RadiusKm > MaxRadiusKm {
+		return newValidationError("radius_km", fmt.Sprintf("radius_km must be between 0 and %d", MaxRadiusKm))
+	}
+	if params.Country != "" && !isCountryCode(params.Country) {
+		return newValidationError("country", "country must be an ISO 3166-1 alpha-2 code, e.g. VN")
+	}
+	return nil
+}
+
+// isCountryCode reports whether code has the shape of an ISO 3166-1 alpha-2
+// code, two letters in either case.
+func isCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, c := range code {
+		if (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateRatingTimeseriesParams validates the interval and date range of a
+// rating time series request.
+func (v *HotelValidator) ValidateRatingTimeseriesParams(params *dto.RatingTimeseriesQueryParams) error {
+	if params.Interval != dto.IntervalWeek && params.Interval != dto.IntervalMonth {
+		return newValidationError("interval", fmt.Sprintf("interval must be %s or %s", dto.IntervalWeek, dto.IntervalMonth))
+	}
+	return validateDateRange(params.From, params.To.Time)
+}
+
+// ValidateResponseMetricsParams validates the date range of a response
+// metrics request.
+func (v *HotelValidator) ValidateResponseMetricsParams(params *dto.ResponseMetricsQueryParams) error {
+	return validateDateRange(params.From, params.To.Time)
+}
+
+// validateDateRange checks that an optional from date is not after an
+// optional to date.
+func validateDateRange(from, to time.Time) error {
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return newValidationError("from", "from can not be after to")
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotelValidator_ValidateRatingTimeseriesParams(t *testing.T) {
+	validator := NewHotelValidator()
+
+	tests := []struct {
+		name        string
+		params      dto.RatingTimeseriesQueryParams
+		expectedErr string
+	}{
+		{
+			name:        "monthly",
+			params:      dto.RatingTimeseriesQueryParams{Interval: dto.IntervalMonth},
+			expectedErr: "",
+		},
+		{
+			name: "weekly within range",
+			params: dto.RatingTimeseriesQueryParams{
+				Interval: dto.IntervalWeek,
+				From:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:       dto.RangeEnd{Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			expectedErr: "",
+		},
+		{
+			name:        "unknown interval",
+			params:      dto.RatingTimeseriesQueryParams{Interval: "day"},
+			expectedErr: "interval must be week or month",
+		},
+		{
+			name: "inverted range",
+			params: dto.RatingTimeseriesQueryParams{
+				Interval: dto.IntervalMonth,
+				From:     time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+				To:       dto.RangeEnd{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			expectedErr: "from can not be after to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateRatingTimeseriesParams(&tt.params)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestHotelValidator_ValidateResponseMetricsParams(t *testing.T) {
+	validator := NewHotelValidator()
+
+	assert.NoError(t, validator.ValidateResponseMetricsParams(&dto.ResponseMetricsQueryParams{ProviderID: 1}))
+	assert.EqualError(t,
+		validator.ValidateResponseMetricsParams(&dto.ResponseMetricsQueryParams{
+			From: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			To:   dto.RangeEnd{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		}),
+		"from can not be after to",
+	)
+}
+
+func TestHotelValidator_ValidateLocation(t *testing.T) {
+	validator := NewHotelValidator()
+	lat, lng := 10.7769, 106.7009
+	outOfRange := 91.0
+
+	tests := []struct {
+		name        string
+		location    models.HotelLocation
+		expectedErr string
+	}{
+		{
+			name:        "empty",
+			location:    models.HotelLocation{},
+			expectedErr: "",
+		},
+		{
+			name: "full",
+			location: models.HotelLocation{
+				Address:     "8 Dong Khoi",
+				City:        "Ho Chi Minh City",
+				CountryCode: "vn",
+				Latitude:    &lat,
+				Longitude:   &lng,
+				Timezone:    "Asia/Ho_Chi_Minh",
+			},
+			expectedErr: "",
+		},
+		{
+			name:        "invalid country code",
+			location:    models.HotelLocation{CountryCode: "VNM"},
+			expectedErr: "country_code must be an ISO 3166-1 alpha-2 code, e.g. VN",
+		},
+		{
+			name:        "latitude without longitude",
+			location:    models.HotelLocation{Latitude: &lat},
+			expectedErr: "latitude and longitude must be set together",
+		},
+		{
+			name:        "latitude out of range",
+			location:    models.HotelLocation{Latitude: &outOfRange, Longitude: &lng},
+			expectedErr: "latitude must be between -90 and 90",
+		},
+		{
+			name:        "unknown timezone",
+			location:    models.HotelLocation{Timezone: "Asia/Atlantis"},
+			expectedErr: "timezone must be an IANA time zone name, e.g. Asia/Ho_Chi_Minh",
+		},
+		{
+			name:        "local timezone",
+			location:    models.HotelLocation{Timezone: "Local"},
+			expectedErr: "timezone must be an IANA time zone name, e.g. Asia/Ho_Chi_Minh",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateLocation(&tt.location)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestHotelValidator_ValidateHotelsQueryParams(t *testing.T) {
+	validator := NewHotelValidator()
+
+	tests := []struct {
+		name        string
+		params      dto.HotelsQueryParams
+		expectedErr string
+	}{
+		{
+			name:        "near with default radius",
+			params:      dto.HotelsQueryParams{Near: &dto.GeoPoint{Lat: 10.77, Lng: 106.7}},
+			expectedErr: "",
+		},
+		{
+			name:        "radius without near",
+			params:      dto.HotelsQueryParams{RadiusKm: 5},
+			expectedErr: "radius_km requires near",
+		},
+		{
+			name:        "radius too large",
+			params:      dto.HotelsQueryParams{Near: &dto.GeoPoint{}, RadiusKm: 1000},
+			expectedErr: "radius_km must be between 0 and 500",
+		},
+		{
+			name:        "invalid country",
+			params:      dto.HotelsQueryParams{Country: "Vietnam"},
+			expectedErr: "country must be an ISO 3166-1 alpha-2 code, e.g. VN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateHotelsQueryParams(&tt.params)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
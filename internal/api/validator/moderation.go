@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+)
+
+const (
+	// MaxModerationBatchSize is the most reviews a batch moderation request
+	// can name.
+	MaxModerationBatchSize = 500
+
+	// MaxModerationReasonLength is the longest moderation reason accepted.
+	MaxModerationReasonLength = 1000
+)
+
+// ValidateModeration validates a moderation action and the decision recorded
+// with it.
+func (v *ReviewValidator) ValidateModeration(action string, body *dto.ModerationRequestBody) error {
+	if _, ok := dto.ModerationTransitions[action]; !ok {
+		return newValidationError("action", fmt.Sprintf("action must be %s, %s or %s", dto.ModerationApprove, dto.ModerationReject, dto.ModerationFlag))
+	}
+	if strings.TrimSpace(body.Moderator) == "" {
+		return newValidationError("moderator", "moderator is required")
+	}
+	if action != dto.ModerationApprove && strings.TrimSpace(body.Reason) == "" {
+		return newValidationError("reason", fmt.Sprintf("reason is required to %s a review", action))
+	}
+	if len(body.Reason) > MaxModerationReasonLength {
+		return newValidationError("reason", fmt.Sprintf("reason must be at most %d characters", MaxModerationReasonLength))
+	}
+	return nil
+}
+
+// ValidateBatchModeration validates a moderation action applied to a batch of
+// reviews.
+func (v *ReviewValidator) ValidateBatchModeration(action string, body *dto.BatchModerationRequestBody) error {
+	if len(body.IDs) == 0 {
+		return newValidationError("ids", "ids is required")
+	}
+	if len(body.IDs) > MaxModerationBatchSize {
+		return newValidationError("ids", fmt.Sprintf("ids can name at most %d reviews", MaxModerationBatchSize))
+	}
+	for _, id := range body.IDs {
+		if id == 0 {
+			return newValidationError("ids", "ids must be positive")
+		}
+	}
+	return v.ValidateModeration(action, &body.ModerationRequestBody)
+}
@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewValidator_ValidateModeration(t *testing.T) {
+	validator := NewReviewValidator(&fakeReviewReferences{})
+
+	tests := []struct {
+		name        string
+		action      string
+		body        dto.ModerationRequestBody
+		expectedErr string
+	}{
+		{
+			name:        "approve without reason",
+			action:      dto.ModerationApprove,
+			body:        dto.ModerationRequestBody{Moderator: "alex"},
+			expectedErr: "",
+		},
+		{
+			name:        "reject with reason",
+			action:      dto.ModerationReject,
+			body:        dto.ModerationRequestBody{Moderator: "alex", Reason: "hate speech"},
+			expectedErr: "",
+		},
+		{
+			name:        "unknown action",
+			action:      "delete",
+			body:        dto.ModerationRequestBody{Moderator: "alex"},
+			expectedErr: "action must be approve, reject or flag",
+		},
+		{
+			name:        "missing moderator",
+			action:      dto.ModerationApprove,
+			body:        dto.ModerationRequestBody{Moderator: " "},
+			expectedErr: "moderator is required",
+		},
+		{
+			name:        "flag without reason",
+			action:      dto.ModerationFlag,
+			body:        dto.ModerationRequestBody{Moderator: "alex"},
+			expectedErr: "reason is required to flag a review",
+		},
+		{
+			name:        "reason too long",
+			action:      dto.ModerationReject,
+			body:        dto.ModerationRequestBody{Moderator: "alex", Reason: strings.Repeat("a", MaxModerationReasonLength+1)},
+			expectedErr: "reason must be at most 1000 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateModeration(tt.action, &tt.body)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestReviewValidator_ValidateBatchModeration(t *testing.T) {
+	validator := NewReviewValidator(&fakeReviewReferences{})
+	decision := dto.ModerationRequestBody{Moderator: "alex", Reason: "spam"}
+
+	assert.NoError(t, validator.ValidateBatchModeration(dto.ModerationReject, &dto.BatchModerationRequestBody{IDs: []uint{1, 2}, ModerationRequestBody: decision}))
+	assert.EqualError(t, validator.ValidateBatchModeration(dto.ModerationReject, &dto.BatchModerationRequestBody{ModerationRequestBody: decision}), "ids is required")
+	assert.EqualError(t, validator.ValidateBatchModeration(dto.ModerationReject, &dto.BatchModerationRequestBody{IDs: []uint{1, 0}, ModerationRequestBody: decision}), "ids must be positive")
+	assert.EqualError(t,
+		validator.ValidateBatchModeration(dto.ModerationReject, &dto.BatchModerationRequestBody{IDs: make([]uint, MaxModerationBatchSize+1), ModerationRequestBody: decision}),
+		"ids can name at most 500 reviews",
+	)
+}
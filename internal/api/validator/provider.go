@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+)
+
+// MaxNameLength is the longest provider or hotel name accepted.
+const MaxNameLength = 255
+
+type ProviderValidator struct{}
+
+func NewProviderValidator() *ProviderValidator {
+	return &ProviderValidator{}
+}
+
+// ValidateProvider validates a provider to be created or updated.
+func (v *ProviderValidator) ValidateProvider(req *dto.ProviderRequestBody) error {
+	return validateName("name", req.Name)
+}
+
+// validateName checks that a name is present and not too long.
+func validateName(field, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return newValidationError(field, fmt.Sprintf("%s is required", field))
+	}
+	if len(name) > MaxNameLength {
+		return newValidationError(field, fmt.Sprintf("%s must be at most %d characters", field, MaxNameLength))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"gorm.io/gorm"
+)
+
+type ProviderHotelValidator struct {
+	refs ReviewReferences
+}
+
+func NewProviderHotelValidator(refs ReviewReferences) *ProviderHotelValidator {
+	return &ProviderHotelValidator{
+		refs: refs,
+	}
+}
+
+// ValidateCreateProviderHotel validates a provider-hotel mapping to be created.
+func (v *ProviderHotelValidator) ValidateCreateProviderHotel(req *dto.ProviderHotelRequestBody) error {
+	if req.ProviderID == 0 {
+		return newValidationError("provider_id", "provider_id is required")
+	}
+	if req.HotelID == 0 {
+		return newValidationError("hotel_id", "hotel_id is required")
+	}
+
+	if err := v.ValidateProviderHotelStats(&dto.ProviderHotelStatsBody{
+		OverallScore: req.OverallScore,
+		ReviewCount:  req.ReviewCount,
+		Grades:       req.Grades,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := v.refs.GetProviderByID(req.ProviderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return newValidationError("provider_id", "provider_id does not reference an existing provider")
+		}
+		return fmt.Errorf("failed to look up provider: %w", err)
+	}
+	if _, err := v.refs.GetHotelByID(req.HotelID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return newValidationError("hotel_id", "hotel_id does not reference an existing hotel")
+		}
+		return fmt.Errorf("failed to look up hotel: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateProviderHotelStats validates the overall score, review count and
+// grades of a provider-hotel mapping. Grades map a category to a score.
+func (v *ProviderHotelValidator) ValidateProviderHotelStats(req *dto.ProviderHotelStatsBody) error {
+	if req.OverallScore < MinRating || req.OverallScore > MaxRating {
+		return newValidationError("overall_score", fmt.Sprintf("overall_score must be between %d and %d", MinRating, MaxRating))
+	}
+	if req.ReviewCount < 0 {
+		return newValidationError("review_count", "review_count can not be negative")
+	}
+
+	if len(req.Grades) > 0 {
+		var grades map[string]float64
+		if err := json.Unmarshal(req.Grades, &grades); err != nil {
+			return newValidationError("grades", "grades must be a JSON object of numeric scores")
+		}
+		for category, score := range grades {
+			if score < MinRating || score > MaxRating {
+				return newValidationError("grades", fmt.Sprintf("grade %q must be between %d and %d", category, MinRating, MaxRating))
+			}
+		}
+	}
+
+	return nil
+}
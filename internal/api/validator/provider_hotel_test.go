@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderHotelValidator_ValidateCreateProviderHotel(t *testing.T) {
+	validator := NewProviderHotelValidator(&fakeReviewReferences{})
+
+	tests := []struct {
+		name        string
+		req         dto.ProviderHotelRequestBody
+		expectedErr string
+	}{
+		{
+			name:        "valid request",
+			req:         dto.ProviderHotelRequestBody{ProviderID: 1, HotelID: 1, OverallScore: 8.1, ReviewCount: 3, Grades: json.RawMessage(`{"Cleanliness":8.5}`)},
+			expectedErr: "",
+		},
+		{
+			name:        "missing provider_id",
+			req:         dto.ProviderHotelRequestBody{HotelID: 1},
+			expectedErr: "provider_id is required",
+		},
+		{
+			name:        "overall_score out of range",
+			req:         dto.ProviderHotelRequestBody{ProviderID: 1, HotelID: 1, OverallScore: 11},
+			expectedErr: "overall_score must be between 0 and 10",
+		},
+		{
+			name:        "negative review_count",
+			req:         dto.ProviderHotelRequestBody{ProviderID: 1, HotelID: 1, ReviewCount: -1},
+			expectedErr: "review_count can not be negative",
+		},
+		{
+			name:        "grades not numeric",
+			req:         dto.ProviderHotelRequestBody{ProviderID: 1, HotelID: 1, Grades: json.RawMessage(`{"Cleanliness":"good"}`)},
+			expectedErr: "grades must be a JSON object of numeric scores",
+		},
+		{
+			name:        "grade out of range",
+			req:         dto.ProviderHotelRequestBody{ProviderID: 1, HotelID: 1, Grades: json.RawMessage(`{"Cleanliness":12}`)},
+			expectedErr: `grade "Cleanliness" must be between 0 and 10`,
+		},
+		{
+			name:        "unknown hotel",
+			req:         dto.ProviderHotelRequestBody{ProviderID: 1, HotelID: 2},
+			expectedErr: "hotel_id does not reference an existing hotel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateCreateProviderHotel(&tt.req)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
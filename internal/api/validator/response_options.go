@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+)
+
+// ValidateResponseOptions checks that include only names relations the
+// resource has and that fields only names fields of model, a struct or a
+// pointer to one whose JSON field names make up the response.
+func ValidateResponseOptions(opts dto.ResponseOptions, model interface{}, relations []string) error {
+	for _, relation := range opts.IncludeList() {
+		if !slices.Contains(relations, relation) {
+			if len(relations) == 0 {
+				return newValidationError("include", "include is not supported for this resource")
+			}
+			return newValidationError("include", fmt.Sprintf("include must be one of %s", strings.Join(relations, ", ")))
+		}
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(model))
+	for _, field := range opts.FieldList() {
+		if !slices.Contains(known, field) {
+			return newValidationError("fields", fmt.Sprintf("unknown field %q", field))
+		}
+	}
+	return nil
+}
+
+// jsonFieldNames returns the names t's exported fields are encoded under,
+// including those of embedded structs.
+func jsonFieldNames(t reflect.Type) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var names []string
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			// The fields of an embedded struct are promoted and listed separately
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
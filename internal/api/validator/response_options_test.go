@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateResponseOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        dto.ResponseOptions
+		model       interface{}
+		relations   []string
+		expectedErr string
+	}{
+		{
+			name:        "empty",
+			opts:        dto.ResponseOptions{},
+			model:       models.Review{},
+			relations:   dto.ReviewRelations,
+			expectedErr: "",
+		},
+		{
+			name:        "include and fields",
+			opts:        dto.ResponseOptions{Include: "provider, hotel", Fields: "id,rating,review_date"},
+			model:       models.Review{},
+			relations:   dto.ReviewRelations,
+			expectedErr: "",
+		},
+		{
+			name:        "fields of embedded struct",
+			opts:        dto.ResponseOptions{Fields: "id,rank,snippet"},
+			model:       &dto.ReviewSearchResult{},
+			relations:   dto.ReviewRelations,
+			expectedErr: "",
+		},
+		{
+			name:        "unknown relation",
+			opts:        dto.ResponseOptions{Include: "provider,reviews"},
+			model:       models.ProviderHotel{},
+			relations:   dto.ProviderHotelRelations,
+			expectedErr: "include must be one of provider, hotel",
+		},
+		{
+			name:        "resource without relations",
+			opts:        dto.ResponseOptions{Include: "provider"},
+			model:       models.Hotel{},
+			relations:   nil,
+			expectedErr: "include is not supported for this resource",
+		},
+		{
+			name:        "unknown field",
+			opts:        dto.ResponseOptions{Fields: "id,hotel_name"},
+			model:       models.Hotel{},
+			relations:   nil,
+			expectedErr: `unknown field "hotel_name"`,
+		},
+		{
+			name:        "hidden field",
+			opts:        dto.ResponseOptions{Fields: "SearchVector"},
+			model:       models.Review{},
+			relations:   dto.ReviewRelations,
+			expectedErr: `unknown field "SearchVector"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResponseOptions(tt.opts, tt.model, tt.relations)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
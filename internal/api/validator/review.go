@@ -1,27 +1,191 @@
 package validator
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/models"
+	"gorm.io/gorm"
 )
 
-type ReviewValidator struct{}
+const (
+	MinRating = 0
+	MaxRating = 10
 
-func NewReviewValidator() *ReviewValidator {
-	return &ReviewValidator{}
+	// MaxSearchQueryLength is the longest full-text search query accepted.
+	MaxSearchQueryLength = 200
+)
+
+// langPattern matches ISO 639-1 codes with an optional region, e.g. "en" or "en-US".
+var langPattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+// ValidationError is returned when a request is invalid, as opposed to the
+// validator being unable to complete its checks.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func newValidationError(field, message string) *ValidationError {
+	return &ValidationError{Field: field, Message: message}
+}
+
+// ReviewReferences looks up the entities a review points at.
+type ReviewReferences interface {
+	GetProviderByID(id uint) (*models.Provider, error)
+	GetHotelByID(id uint) (*models.Hotel, error)
+}
+
+type ReviewValidator struct {
+	refs ReviewReferences
+	now  func() time.Time
+}
+
+func NewReviewValidator(refs ReviewReferences) *ReviewValidator {
+	return &ReviewValidator{
+		refs: refs,
+		now:  time.Now,
+	}
+}
+
+// ValidateCreateReview validates a review to be created. Review IDs are
+// assigned by the providers, so unlike other entities the ID is required.
+func (v *ReviewValidator) ValidateCreateReview(review *models.Review) error {
+	if review.ID == 0 {
+		return newValidationError("id", "id is required")
+	}
+	return v.ValidateReview(review)
+}
+
+// ValidateReview validates the final state of a review being created or updated.
+func (v *ReviewValidator) ValidateReview(review *models.Review) error {
+	if review.ProviderID == 0 {
+		return newValidationError("provider_id", "provider_id is required")
+	}
+	if review.HotelID == 0 {
+		return newValidationError("hotel_id", "hotel_id is required")
+	}
+	if review.Rating < MinRating || review.Rating > MaxRating {
+		return newValidationError("rating", fmt.Sprintf("rating must be between %d and %d", MinRating, MaxRating))
+	}
+	if review.Lang != "" && !langPattern.MatchString(review.Lang) {
+		return newValidationError("lang", "lang must be an ISO 639-1 language code")
+	}
+	if review.ReviewDate.IsZero() {
+		return newValidationError("review_date", "review_date is required")
+	}
+	if review.ReviewDate.After(v.now()) {
+		return newValidationError("review_date", "review_date can not be in the future")
+	}
+	if len(review.ReviewerInfo) > 0 {
+		var info map[string]interface{}
+		if err := json.Unmarshal(review.ReviewerInfo, &info); err != nil {
+			return newValidationError("reviewer_info", "reviewer_info must be a JSON object")
+		}
+	}
+
+	if _, err := v.refs.GetProviderByID(review.ProviderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return newValidationError("provider_id", "provider_id does not reference an existing provider")
+		}
+		return fmt.Errorf("failed to look up provider: %w", err)
+	}
+	if _, err := v.refs.GetHotelByID(review.HotelID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return newValidationError("hotel_id", "hotel_id does not reference an existing hotel")
+		}
+		return fmt.Errorf("failed to look up hotel: %w", err)
+	}
+
+	return nil
 }
 
-func (v *ReviewValidator) ValidateCreateReview(req *dto.ReviewRequest) error {
-	if req.ProductID == "" {
-		return errors.New("product_id is required")
+// ValidateReviewQueryParams validates the filters and sort order of a reviews
+// list request.
+func (v *ReviewValidator) ValidateReviewQueryParams(params *dto.ReviewQueryParams) error {
+	if params.Limit <= 0 {
+		return newValidationError("limit", "limit must be greater than 0")
+	}
+	if params.Offset < 0 {
+		return newValidationError("offset", "offset can not be negative")
 	}
-	if req.Rating < 0 || req.Rating > 5 {
-		return errors.New("rating must be between 0 and 5")
+	if err := validateReviewFilters(params); err != nil {
+		return err
 	}
+	if params.Pagination != "" && params.Pagination != dto.PaginationOffset && params.Pagination != dto.PaginationCursor {
+		return newValidationError("pagination", fmt.Sprintf("pagination must be %s or %s", dto.PaginationOffset, dto.PaginationCursor))
+	}
+	if params.Pagination == dto.PaginationOffset && params.Cursor != "" {
+		return newValidationError("cursor", "cursor can not be used with offset pagination")
+	}
+	if params.CursorMode() {
+		if params.Offset != 0 {
+			return newValidationError("offset", "offset can not be used with cursor pagination")
+		}
+		// Relevance is not a column, so search results can only be walked by cursor in an explicit order
+		if params.Q != "" && params.Sort == "" {
+			return newValidationError("sort", "sort is required for cursor pagination of search results")
+		}
+	}
+	return nil
+}
 
-	if req.ExternalID == "" {
-		return errors.New("external_id required")
+// ValidateReviewExportParams validates the format, filters and sort of a
+// review export.
+func (v *ReviewValidator) ValidateReviewExportParams(params *dto.ReviewExportQueryParams) error {
+	if params.Format != dto.ExportFormatNDJSON && params.Format != dto.ExportFormatCSV {
+		return newValidationError("format", fmt.Sprintf("format must be %s or %s", dto.ExportFormatNDJSON, dto.ExportFormatCSV))
+	}
+	return validateReviewFilters(&params.ReviewQueryParams)
+}
+
+// validateReviewFilters validates the filters and sort shared by the reviews
+// list, search and export.
+func validateReviewFilters(params *dto.ReviewQueryParams) error {
+	if params.MinRating != nil && (*params.MinRating < MinRating || *params.MinRating > MaxRating) {
+		return newValidationError("min_rating", fmt.Sprintf("min_rating must be between %d and %d", MinRating, MaxRating))
+	}
+	if params.MaxRating != nil && (*params.MaxRating < MinRating || *params.MaxRating > MaxRating) {
+		return newValidationError("max_rating", fmt.Sprintf("max_rating must be between %d and %d", MinRating, MaxRating))
+	}
+	if params.MinRating != nil && params.MaxRating != nil && *params.MinRating > *params.MaxRating {
+		return newValidationError("min_rating", "min_rating can not be greater than max_rating")
+	}
+	if !params.ReviewDateFrom.IsZero() && !params.ReviewDateTo.IsZero() && params.ReviewDateFrom.After(params.ReviewDateTo.Time) {
+		return newValidationError("review_date_from", "review_date_from can not be after review_date_to")
+	}
+	if params.Lang != "" && !langPattern.MatchString(params.Lang) {
+		return newValidationError("lang", "lang must be an ISO 639-1 language code")
+	}
+	if len(params.Q) > MaxSearchQueryLength {
+		return newValidationError("q", fmt.Sprintf("q must be at most %d characters", MaxSearchQueryLength))
+	}
+	if params.Sort != "" && !slices.Contains(dto.ReviewSortFields, strings.TrimPrefix(params.Sort, "-")) {
+		return newValidationError("sort", fmt.Sprintf("sort must be one of %s, optionally prefixed with -", strings.Join(dto.ReviewSortFields, ", ")))
+	}
+	for _, status := range params.StatusList() {
+		if !slices.Contains(models.ReviewStatuses, status) {
+			return newValidationError("status", fmt.Sprintf("status must be one of %s", strings.Join(models.ReviewStatuses, ", ")))
+		}
 	}
 	return nil
 }
+
+// ValidateReviewSearchParams validates a full-text search request, which
+// unlike the reviews list requires a query.
+func (v *ReviewValidator) ValidateReviewSearchParams(params *dto.ReviewQueryParams) error {
+	if strings.TrimSpace(params.Q) == "" {
+		return newValidationError("q", "q is required")
+	}
+	return v.ValidateReviewQueryParams(params)
+}
@@ -0,0 +1,25 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+)
+
+// MaxReviewResponseLength is the longest response body a hotel can post.
+const MaxReviewResponseLength = 5000
+
+// ValidateReviewResponse validates a hotel's response to a review.
+func (v *ReviewValidator) ValidateReviewResponse(body *dto.ReviewResponseRequestBody) error {
+	if err := validateName("responder_name", body.ResponderName); err != nil {
+		return err
+	}
+	if strings.TrimSpace(body.Body) == "" {
+		return newValidationError("body", "body is required")
+	}
+	if len(body.Body) > MaxReviewResponseLength {
+		return newValidationError("body", fmt.Sprintf("body must be at most %d characters", MaxReviewResponseLength))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewValidator_ValidateReviewResponse(t *testing.T) {
+	validator := NewReviewValidator(&fakeReviewReferences{})
+
+	tests := []struct {
+		name        string
+		body        dto.ReviewResponseRequestBody
+		expectedErr string
+	}{
+		{
+			name:        "valid",
+			body:        dto.ReviewResponseRequestBody{ResponderName: "Front Office Manager", Body: "Thank you for staying with us."},
+			expectedErr: "",
+		},
+		{
+			name:        "missing responder name",
+			body:        dto.ReviewResponseRequestBody{Body: "Thank you."},
+			expectedErr: "responder_name is required",
+		},
+		{
+			name:        "missing body",
+			body:        dto.ReviewResponseRequestBody{ResponderName: "Manager", Body: "  "},
+			expectedErr: "body is required",
+		},
+		{
+			name:        "body too long",
+			body:        dto.ReviewResponseRequestBody{ResponderName: "Manager", Body: strings.Repeat("a", MaxReviewResponseLength+1)},
+			expectedErr: "body must be at most 5000 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateReviewResponse(&tt.body)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
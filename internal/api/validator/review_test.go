@@ -1,68 +1,234 @@
 package validator
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/models"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
+// fakeReviewReferences knows about provider 1 and hotel 1 only.
+type fakeReviewReferences struct {
+	err error
+}
+
+func (f *fakeReviewReferences) GetProviderByID(id uint) (*models.Provider, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if id != 1 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &models.Provider{ID: id}, nil
+}
+
+func (f *fakeReviewReferences) GetHotelByID(id uint) (*models.Hotel, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if id != 1 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &models.Hotel{ID: id}, nil
+}
+
 func TestReviewValidator_ValidateCreateReview(t *testing.T) {
-	validator := NewReviewValidator()
+	validator := NewReviewValidator(&fakeReviewReferences{})
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	validator.now = func() time.Time { return now }
+
+	validReview := func() *models.Review {
+		return &models.Review{
+			ID:           123,
+			ProviderID:   1,
+			HotelID:      1,
+			Rating:       8.5,
+			Lang:         "en",
+			ReviewDate:   now.Add(-24 * time.Hour),
+			ReviewerInfo: []byte(`{"countryName":"India"}`),
+		}
+	}
 
 	tests := []struct {
 		name        string
-		req         *dto.ReviewRequest
+		mutate      func(review *models.Review)
 		expectedErr string
 	}{
 		{
-			name: "valid request",
-			req: &dto.ReviewRequest{
-				ProductID:  "123",
-				Rating:     4.5,
-				ExternalID: "ext123",
-			},
+			name:        "valid request",
+			mutate:      func(review *models.Review) {},
 			expectedErr: "",
 		},
 		{
-			name: "missing product_id",
-			req: &dto.ReviewRequest{
-				Rating:     4.5,
-				ExternalID: "ext123",
-			},
-			expectedErr: "product_id is required",
+			name:        "missing id",
+			mutate:      func(review *models.Review) { review.ID = 0 },
+			expectedErr: "id is required",
 		},
 		{
-			name: "rating less than 0",
-			req: &dto.ReviewRequest{
-				ProductID:  "123",
-				Rating:     -1,
-				ExternalID: "ext123",
-			},
-			expectedErr: "rating must be between 0 and 5",
+			name:        "missing provider_id",
+			mutate:      func(review *models.Review) { review.ProviderID = 0 },
+			expectedErr: "provider_id is required",
+		},
+		{
+			name:        "missing hotel_id",
+			mutate:      func(review *models.Review) { review.HotelID = 0 },
+			expectedErr: "hotel_id is required",
+		},
+		{
+			name:        "rating less than 0",
+			mutate:      func(review *models.Review) { review.Rating = -1 },
+			expectedErr: "rating must be between 0 and 10",
+		},
+		{
+			name:        "rating greater than 10",
+			mutate:      func(review *models.Review) { review.Rating = 11 },
+			expectedErr: "rating must be between 0 and 10",
+		},
+		{
+			name:        "invalid lang",
+			mutate:      func(review *models.Review) { review.Lang = "english" },
+			expectedErr: "lang must be an ISO 639-1 language code",
+		},
+		{
+			name:        "missing review_date",
+			mutate:      func(review *models.Review) { review.ReviewDate = time.Time{} },
+			expectedErr: "review_date is required",
+		},
+		{
+			name:        "review_date in the future",
+			mutate:      func(review *models.Review) { review.ReviewDate = now.Add(time.Hour) },
+			expectedErr: "review_date can not be in the future",
+		},
+		{
+			name:        "reviewer_info not an object",
+			mutate:      func(review *models.Review) { review.ReviewerInfo = []byte(`[1,2]`) },
+			expectedErr: "reviewer_info must be a JSON object",
+		},
+		{
+			name:        "unknown provider",
+			mutate:      func(review *models.Review) { review.ProviderID = 2 },
+			expectedErr: "provider_id does not reference an existing provider",
 		},
 		{
-			name: "rating greater than 5",
-			req: &dto.ReviewRequest{
-				ProductID:  "123",
-				Rating:     6,
-				ExternalID: "ext123",
+			name:        "unknown hotel",
+			mutate:      func(review *models.Review) { review.HotelID = 2 },
+			expectedErr: "hotel_id does not reference an existing hotel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			review := validReview()
+			tt.mutate(review)
+
+			err := validator.ValidateCreateReview(review)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+				var validationErr *ValidationError
+				assert.True(t, errors.As(err, &validationErr))
+			}
+		})
+	}
+
+	t.Run("lookup failure is not a validation error", func(t *testing.T) {
+		validator := NewReviewValidator(&fakeReviewReferences{err: errors.New("db down")})
+		validator.now = func() time.Time { return now }
+
+		err := validator.ValidateCreateReview(validReview())
+		assert.Error(t, err)
+		var validationErr *ValidationError
+		assert.False(t, errors.As(err, &validationErr))
+	})
+}
+
+func TestReviewValidator_ValidateReviewQueryParams(t *testing.T) {
+	validator := NewReviewValidator(&fakeReviewReferences{})
+	rating := func(r float64) *float64 { return &r }
+
+	tests := []struct {
+		name        string
+		params      dto.ReviewQueryParams
+		expectedErr string
+	}{
+		{
+			name: "valid filters",
+			params: dto.ReviewQueryParams{
+				Limit:          20,
+				MinRating:      rating(6),
+				MaxRating:      rating(9),
+				ReviewDateFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				ReviewDateTo:   dto.RangeEnd{Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+				Lang:           "en",
+				Sort:           "-rating",
 			},
-			expectedErr: "rating must be between 0 and 5",
+			expectedErr: "",
 		},
 		{
-			name: "missing external_id",
-			req: &dto.ReviewRequest{
-				ProductID: "123",
-				Rating:    4.5,
+			name:        "zero limit",
+			params:      dto.ReviewQueryParams{},
+			expectedErr: "limit must be greater than 0",
+		},
+		{
+			name:        "negative offset",
+			params:      dto.ReviewQueryParams{Limit: 20, Offset: -1},
+			expectedErr: "offset can not be negative",
+		},
+		{
+			name:        "min_rating out of range",
+			params:      dto.ReviewQueryParams{Limit: 20, MinRating: rating(-1)},
+			expectedErr: "min_rating must be between 0 and 10",
+		},
+		{
+			name:        "min_rating greater than max_rating",
+			params:      dto.ReviewQueryParams{Limit: 20, MinRating: rating(8), MaxRating: rating(5)},
+			expectedErr: "min_rating can not be greater than max_rating",
+		},
+		{
+			name: "inverted date range",
+			params: dto.ReviewQueryParams{
+				Limit:          20,
+				ReviewDateFrom: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+				ReviewDateTo:   dto.RangeEnd{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
 			},
-			expectedErr: "external_id required",
+			expectedErr: "review_date_from can not be after review_date_to",
+		},
+		{
+			name:        "unknown pagination mode",
+			params:      dto.ReviewQueryParams{Limit: 20, Pagination: "page"},
+			expectedErr: "pagination must be offset or cursor",
+		},
+		{
+			name:        "offset with cursor pagination",
+			params:      dto.ReviewQueryParams{Limit: 20, Offset: 20, Pagination: dto.PaginationCursor},
+			expectedErr: "offset can not be used with cursor pagination",
+		},
+		{
+			name:        "cursor with offset pagination",
+			params:      dto.ReviewQueryParams{Limit: 20, Pagination: dto.PaginationOffset, Cursor: "abc"},
+			expectedErr: "cursor can not be used with offset pagination",
+		},
+		{
+			name:        "cursor pagination of search results ordered by relevance",
+			params:      dto.ReviewQueryParams{Limit: 20, Q: "noisy", Cursor: "abc"},
+			expectedErr: "sort is required for cursor pagination of search results",
+		},
+		{
+			name:        "unknown sort field",
+			params:      dto.ReviewQueryParams{Limit: 20, Sort: "comment"},
+			expectedErr: "sort must be one of review_date, rating, created_at, optionally prefixed with -",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validator.ValidateCreateReview(tt.req)
+			err := validator.ValidateReviewQueryParams(&tt.params)
 			if tt.expectedErr == "" {
 				assert.NoError(t, err)
 			} else {
@@ -71,3 +237,33 @@ func TestReviewValidator_ValidateCreateReview(t *testing.T) {
 		})
 	}
 }
+
+func TestReviewValidator_ValidateReviewSearchParams(t *testing.T) {
+	validator := NewReviewValidator(&fakeReviewReferences{})
+
+	assert.NoError(t, validator.ValidateReviewSearchParams(&dto.ReviewQueryParams{Limit: 20, Q: "noisy"}))
+	assert.EqualError(t, validator.ValidateReviewSearchParams(&dto.ReviewQueryParams{Limit: 20, Q: "  "}), "q is required")
+	assert.EqualError(t,
+		validator.ValidateReviewSearchParams(&dto.ReviewQueryParams{Limit: 20, Q: strings.Repeat("a", MaxSearchQueryLength+1)}),
+		"q must be at most 200 characters",
+	)
+}
+
+func TestReviewValidator_ValidateReviewExportParams(t *testing.T) {
+	validator := NewReviewValidator(&fakeReviewReferences{})
+	minRating, maxRating := 8.0, 4.0
+
+	assert.NoError(t, validator.ValidateReviewExportParams(&dto.ReviewExportQueryParams{Format: dto.ExportFormatNDJSON}))
+	assert.NoError(t, validator.ValidateReviewExportParams(&dto.ReviewExportQueryParams{
+		ReviewQueryParams: dto.ReviewQueryParams{HotelID: 1, Sort: "-review_date"},
+		Format:            dto.ExportFormatCSV,
+	}))
+	assert.EqualError(t, validator.ValidateReviewExportParams(&dto.ReviewExportQueryParams{Format: "xlsx"}), "format must be ndjson or csv")
+	assert.EqualError(t,
+		validator.ValidateReviewExportParams(&dto.ReviewExportQueryParams{
+			ReviewQueryParams: dto.ReviewQueryParams{MinRating: &minRating, MaxRating: &maxRating},
+			Format:            dto.ExportFormatCSV,
+		}),
+		"min_rating can not be greater than max_rating",
+	)
+}
@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/kirananto/review-system/internal/webhook"
+)
+
+// minWebhookSecretLength is the shortest secret a subscription may be given.
+const minWebhookSecretLength = 16
+
+// webhookHostLookupTimeout bounds resolving the host of a webhook URL.
+const webhookHostLookupTimeout = 5 * time.Second
+
+type WebhookValidator struct{}
+
+func NewWebhookValidator() *WebhookValidator {
+	return &WebhookValidator{}
+}
+
+// ValidateWebhookSubscriptionRequestBody validates a webhook subscription
+// create or update request.
+func (v *WebhookValidator) ValidateWebhookSubscriptionRequestBody(body *dto.WebhookSubscriptionRequestBody) error {
+	if body.URL == "" {
+		return newValidationError("url", "url is required")
+	}
+	endpoint, err := url.Parse(body.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return newValidationError("url", "url must be an absolute http or https URL")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookHostLookupTimeout)
+	defer cancel()
+	if err := webhook.CheckHost(ctx, endpoint.Hostname()); errors.Is(err, webhook.ErrForbiddenDestination) {
+		return newValidationError("url", "url must point to a public address")
+	}
+	if len(body.EventTypes) == 0 {
+		return newValidationError("event_types", "event_types must not be empty")
+	}
+	for _, eventType := range body.EventTypes {
+		if !webhook.IsEventType(eventType) {
+			return newValidationError("event_types", "unknown event type "+eventType)
+		}
+	}
+	if body.Secret != "" && len(body.Secret) < minWebhookSecretLength {
+		return newValidationError("secret", "secret must be at least 16 characters long")
+	}
+	return nil
+}
+
+// ValidateWebhookSubscriptionsQueryParams validates the pagination and filters
+// of a webhook subscriptions list request.
+func (v *WebhookValidator) ValidateWebhookSubscriptionsQueryParams(params *dto.WebhookSubscriptionsQueryParams) error {
+	if params.Limit <= 0 {
+		return newValidationError("limit", "limit must be greater than 0")
+	}
+	if params.Offset < 0 {
+		return newValidationError("offset", "offset can not be negative")
+	}
+	if params.EventType != "" && !webhook.IsEventType(params.EventType) {
+		return newValidationError("event_type", "unknown event type "+params.EventType)
+	}
+	return nil
+}
+
+// ValidateWebhookDeliveriesQueryParams validates the pagination and filters of
+// a webhook deliveries list request.
+func (v *WebhookValidator) ValidateWebhookDeliveriesQueryParams(params *dto.WebhookDeliveriesQueryParams) error {
+	if params.Limit <= 0 {
+		return newValidationError("limit", "limit must be greater than 0")
+	}
+	if params.Offset < 0 {
+		return newValidationError("offset", "offset can not be negative")
+	}
+	if params.EventType != "" && !webhook.IsEventType(params.EventType) {
+		return newValidationError("event_type", "unknown event type "+params.EventType)
+	}
+	if params.Status != "" && !slices.Contains(models.WebhookDeliveryStatuses, params.Status) {
+		return newValidationError("status", "status must be one of pending, succeeded, dead")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookValidator_ValidateWebhookSubscriptionRequestBody(t *testing.T) {
+	validator := NewWebhookValidator()
+
+	tests := []struct {
+		name        string
+		body        dto.WebhookSubscriptionRequestBody
+		expectedErr string
+	}{
+		{
+			name:        "valid request",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "https://example.com/hooks", EventTypes: []string{"review.created", "import.failed"}},
+			expectedErr: "",
+		},
+		{
+			name:        "valid request with secret",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "http://93.184.215.14:8081/hooks", EventTypes: []string{"review.deleted"}, Secret: "0123456789abcdef"},
+			expectedErr: "",
+		},
+		{
+			name:        "metadata endpoint",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "http://169.254.169.254/latest/meta-data", EventTypes: []string{"review.created"}},
+			expectedErr: "url must point to a public address",
+		},
+		{
+			name:        "private address",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "https://10.0.3.7/hooks", EventTypes: []string{"review.created"}},
+			expectedErr: "url must point to a public address",
+		},
+		{
+			name:        "loopback ipv6 address",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "http://[::1]:8081/hooks", EventTypes: []string{"review.created"}},
+			expectedErr: "url must point to a public address",
+		},
+		{
+			name:        "host resolving to loopback",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "http://localhost:8081/hooks", EventTypes: []string{"review.created"}},
+			expectedErr: "url must point to a public address",
+		},
+		{
+			name:        "missing url",
+			body:        dto.WebhookSubscriptionRequestBody{EventTypes: []string{"review.created"}},
+			expectedErr: "url is required",
+		},
+		{
+			name:        "relative url",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "/hooks", EventTypes: []string{"review.created"}},
+			expectedErr: "url must be an absolute http or https URL",
+		},
+		{
+			name:        "unsupported scheme",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "ftp://example.com/hooks", EventTypes: []string{"review.created"}},
+			expectedErr: "url must be an absolute http or https URL",
+		},
+		{
+			name:        "no event types",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "https://example.com/hooks"},
+			expectedErr: "event_types must not be empty",
+		},
+		{
+			name:        "unknown event type",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "https://example.com/hooks", EventTypes: []string{"review.liked"}},
+			expectedErr: "unknown event type review.liked",
+		},
+		{
+			name:        "short secret",
+			body:        dto.WebhookSubscriptionRequestBody{URL: "https://example.com/hooks", EventTypes: []string{"review.created"}, Secret: "short"},
+			expectedErr: "secret must be at least 16 characters long",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateWebhookSubscriptionRequestBody(&tt.body)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestWebhookValidator_ValidateWebhookDeliveriesQueryParams(t *testing.T) {
+	validator := NewWebhookValidator()
+
+	tests := []struct {
+		name        string
+		params      dto.WebhookDeliveriesQueryParams
+		expectedErr string
+	}{
+		{
+			name:        "valid request",
+			params:      dto.WebhookDeliveriesQueryParams{Limit: 20, EventType: "import.completed", Status: "dead"},
+			expectedErr: "",
+		},
+		{
+			name:        "zero limit",
+			params:      dto.WebhookDeliveriesQueryParams{},
+			expectedErr: "limit must be greater than 0",
+		},
+		{
+			name:        "negative offset",
+			params:      dto.WebhookDeliveriesQueryParams{Limit: 20, Offset: -1},
+			expectedErr: "offset can not be negative",
+		},
+		{
+			name:        "unknown event type",
+			params:      dto.WebhookDeliveriesQueryParams{Limit: 20, EventType: "review"},
+			expectedErr: "unknown event type review",
+		},
+		{
+			name:        "unknown status",
+			params:      dto.WebhookDeliveriesQueryParams{Limit: 20, Status: "failed"},
+			expectedErr: "status must be one of pending, succeeded, dead",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateWebhookDeliveriesQueryParams(&tt.params)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -9,8 +9,31 @@ type Config struct {
 	Database struct {
 		DSN string `mapstructure:"dsn"`
 	} `mapstructure:"database"`
+	Auth struct {
+		// APIKeys is the JSON array of API keys and the scopes they grant.
+		// No key is accepted when it is empty.
+		APIKeys string `mapstructure:"api_keys"`
+	} `mapstructure:"auth"`
+	Moderation struct {
+		// RulesPath is the auto-moderation rules file. The built-in rules are
+		// used when it is empty.
+		RulesPath string `mapstructure:"rules_path"`
+	} `mapstructure:"moderation"`
+	Notifications struct {
+		// Path is the notifications file saying who is told about ingested
+		// files. Nobody is notified when it is empty.
+		Path string `mapstructure:"path"`
+	} `mapstructure:"notifications"`
+	Purge struct {
+		// RetentionDays is how long soft-deleted entities are kept before the
+		// purge job deletes them for good.
+		RetentionDays int `mapstructure:"retention_days"`
+	} `mapstructure:"purge"`
 }
 
+// DefaultPurgeRetentionDays is the retention window when none is configured.
+const DefaultPurgeRetentionDays = 30
+
 // LoadConfig loads the configuration from the given path.
 func LoadConfig(path string) (*Config, error) {
 	viper.AddConfigPath(path)
@@ -18,6 +41,11 @@ func LoadConfig(path string) (*Config, error) {
 
 	// Bind the DATABASE_DSN environment variable to the config struct
 	viper.BindEnv("database.dsn", "DATABASE_DSN")
+	viper.BindEnv("auth.api_keys", "API_KEYS")
+	viper.BindEnv("moderation.rules_path", "MODERATION_RULES_PATH")
+	viper.BindEnv("notifications.path", "NOTIFICATIONS_PATH")
+	viper.BindEnv("purge.retention_days", "PURGE_RETENTION_DAYS")
+	viper.SetDefault("purge.retention_days", DefaultPurgeRetentionDays)
 
 	if err := viper.ReadInConfig(); err != nil {
 		// If running in Lambda, we might not have a config file, which is fine.
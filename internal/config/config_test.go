@@ -24,6 +24,55 @@ func TestLoadConfig(t *testing.T) {
 		assert.Equal(t, dsn, config.Database.DSN)
 	})
 
+	t.Run("loads API keys from env", func(t *testing.T) {
+		viper.Reset()
+		apiKeys := `[{"key": "k1", "name": "client"}]`
+		os.Setenv("API_KEYS", apiKeys)
+		defer os.Unsetenv("API_KEYS")
+
+		config, err := LoadConfig(".")
+		assert.NoError(t, err)
+		assert.Equal(t, apiKeys, config.Auth.APIKeys)
+	})
+
+	t.Run("loads moderation rules path from env", func(t *testing.T) {
+		viper.Reset()
+		os.Setenv("MODERATION_RULES_PATH", "/etc/review-system/rules.json")
+		defer os.Unsetenv("MODERATION_RULES_PATH")
+
+		config, err := LoadConfig(".")
+		assert.NoError(t, err)
+		assert.Equal(t, "/etc/review-system/rules.json", config.Moderation.RulesPath)
+	})
+
+	t.Run("loads notifications path from env", func(t *testing.T) {
+		viper.Reset()
+		os.Setenv("NOTIFICATIONS_PATH", "/etc/review-system/notifications.json")
+		defer os.Unsetenv("NOTIFICATIONS_PATH")
+
+		config, err := LoadConfig(".")
+		assert.NoError(t, err)
+		assert.Equal(t, "/etc/review-system/notifications.json", config.Notifications.Path)
+	})
+
+	t.Run("loads purge retention from env", func(t *testing.T) {
+		viper.Reset()
+		os.Setenv("PURGE_RETENTION_DAYS", "90")
+		defer os.Unsetenv("PURGE_RETENTION_DAYS")
+
+		config, err := LoadConfig(".")
+		assert.NoError(t, err)
+		assert.Equal(t, 90, config.Purge.RetentionDays)
+	})
+
+	t.Run("defaults purge retention", func(t *testing.T) {
+		viper.Reset()
+
+		config, err := LoadConfig(".")
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultPurgeRetentionDays, config.Purge.RetentionDays)
+	})
+
 	t.Run("loads config from file", func(t *testing.T) {
 		viper.Reset()
 		// Create a temporary directory
@@ -14,7 +14,8 @@ type DataSource struct {
 
 // NewDataSource creates a new database connection.
 func NewDataSource(dsn string) *DataSource {
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// TranslateError maps driver errors such as unique violations to gorm errors
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		panic(fmt.Sprintf("Failed to connect to database: %v", err))
 	}
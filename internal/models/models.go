@@ -3,22 +3,45 @@ package models
 import (
 	"encoding/json"
 	"time"
+
+	"gorm.io/gorm"
 )
 
-// Provider represents a review provider (e.g., Agoda, Booking.com).
+// Provider represents a review provider (e.g., Agoda, Booking.com). Names are
+// unique among providers that aren't deleted.
 type Provider struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name" gorm:"unique;not null"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Name      string         `json:"name" gorm:"not null;uniqueIndex:idx_providers_name,where:deleted_at IS NULL"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string"`
 }
 
-// Hotel represents a hotel entity.
+// Hotel represents a hotel entity. Hotels of the same name in different cities
+// or countries are different hotels, so a name is unique per city and country
+// among hotels that aren't deleted.
 type Hotel struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	HotelName string    `json:"name" gorm:"not null"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	HotelName string `json:"name" gorm:"not null;uniqueIndex:idx_hotels_name_location,where:deleted_at IS NULL"`
+	HotelLocation
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string"`
+}
+
+// HotelLocation is where a hotel is. Every part is optional, as hotels are
+// often first seen in provider feeds that don't carry it.
+type HotelLocation struct {
+	Address string `json:"address"`
+	City    string `json:"city" gorm:"index:idx_hotels_city,expression:LOWER(city);uniqueIndex:idx_hotels_name_location"`
+	// CountryCode is the ISO 3166-1 alpha-2 code, e.g. VN.
+	CountryCode string `json:"country_code" gorm:"size:2;index;uniqueIndex:idx_hotels_name_location"`
+	// Latitude and Longitude are in decimal degrees and are set together.
+	// Geo search looks them up through a bounding box on this index.
+	Latitude  *float64 `json:"latitude" gorm:"index:idx_hotels_coordinates"`
+	Longitude *float64 `json:"longitude" gorm:"index:idx_hotels_coordinates"`
+	// Timezone is the IANA time zone name, e.g. Asia/Ho_Chi_Minh.
+	Timezone string `json:"timezone"`
 }
 
 // ProviderHotel maps a provider's hotel ID to our internal hotel ID.
@@ -31,35 +54,97 @@ type ProviderHotel struct {
 	Grades       json.RawMessage `json:"grades" gorm:"type:jsonb" swaggertype:"string"` // jsonb for Postgres
 	UpdatedAt    time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
 	CreatedAt    time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	DeletedAt    gorm.DeletedAt  `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string"`
 
-	// enforce FK + cascade to avoid orphans
-	Hotel    Hotel    `json:"-" gorm:"constraint:OnDelete:CASCADE;foreignKey:HotelID;references:ID"`
-	Provider Provider `json:"-" gorm:"constraint:OnDelete:CASCADE;foreignKey:ProviderID;references:ID"`
+	// enforce FK + cascade to avoid orphans. The related entities are only
+	// loaded when a response asks to include them.
+	Hotel    *Hotel    `json:"hotel,omitempty" gorm:"constraint:OnDelete:CASCADE;foreignKey:HotelID;references:ID"`
+	Provider *Provider `json:"provider,omitempty" gorm:"constraint:OnDelete:CASCADE;foreignKey:ProviderID;references:ID"`
 }
 
+// Moderation statuses of a review. Only published reviews are shown publicly.
+const (
+	ReviewStatusPending   = "pending"
+	ReviewStatusPublished = "published"
+	ReviewStatusRejected  = "rejected"
+	ReviewStatusFlagged   = "flagged"
+)
+
+// ReviewStatuses lists every moderation status.
+var ReviewStatuses = []string{ReviewStatusPending, ReviewStatusPublished, ReviewStatusRejected, ReviewStatusFlagged}
+
 // Review represents a single review from a provider.
 type Review struct {
 	ID           uint            `json:"id" gorm:"primaryKey;autoIncrement:false"`
-	ProviderID   uint            `json:"provider_id" gorm:"not null"`
-	HotelID      uint            `json:"hotel_id" gorm:"not null"`
-	Rating       float64         `json:"rating" gorm:"not null"`
+	ProviderID   uint            `json:"provider_id" gorm:"not null;index"`
+	HotelID      uint            `json:"hotel_id" gorm:"not null;index"`
+	Rating       float64         `json:"rating" gorm:"not null;index"`
+	Title        string          `json:"title"`
 	Comment      string          `json:"comment"`
 	Lang         string          `json:"lang" gorm:"default:'en'"`
 	ReviewDate   time.Time       `json:"review_date" gorm:"not null;index"`
 	ReviewerInfo json.RawMessage `json:"reviewer_info" gorm:"type:jsonb" swaggertype:"string"`
-	UpdatedAt    time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
-	CreatedAt    time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time       `json:"updated_at" gorm:"autoUpdateTime;index"`
+	CreatedAt    time.Time       `json:"created_at" gorm:"autoCreateTime;index"`
+	// DeletedAt is set when the review is soft-deleted, directly or along
+	// with its hotel or provider. Deleted reviews are hard-deleted by the
+	// purge job once the retention window has passed.
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string"`
+
+	// Moderation state. New reviews start pending; reviews stored before
+	// moderation existed default to published.
+	Status           string     `json:"status" gorm:"not null;default:'published';index"`
+	ModerationReason string     `json:"moderation_reason,omitempty"`
+	ModeratedBy      string     `json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+	// ModerationRules are the auto-moderation rules the review matched when
+	// it was last ingested.
+	ModerationRules []string `json:"moderation_rules,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	// SearchVector is the full-text index over the title and comment. Postgres
+	// keeps it up to date on every insert and update, so it is never written here.
+	SearchVector string `json:"-" gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('english', coalesce(title, '')), 'A') || setweight(to_tsvector('english', coalesce(comment, '')), 'B')) STORED;index:idx_reviews_search_vector,type:gin"`
+
+	Provider *Provider       `json:"provider,omitempty" gorm:"constraint:OnDelete:CASCADE;foreignKey:ProviderID;references:ID"`
+	Hotel    *Hotel          `json:"hotel,omitempty" gorm:"constraint:OnDelete:CASCADE;foreignKey:HotelID;references:ID"`
+	Response *ReviewResponse `json:"response,omitempty" gorm:"constraint:OnDelete:CASCADE;foreignKey:ReviewID;references:ID"`
+}
+
+// Sources of a review response.
+const (
+	// ReviewResponseSourceProvider responses are imported from the provider's feed.
+	ReviewResponseSourceProvider = "provider"
+	// ReviewResponseSourceAPI responses are posted by the hotel through our API.
+	ReviewResponseSourceAPI = "api"
+)
 
-	Provider Provider `json:"-" gorm:"constraint:OnDelete:CASCADE;foreignKey:ProviderID;references:ID"`
-	Hotel    Hotel    `json:"-" gorm:"constraint:OnDelete:CASCADE;foreignKey:HotelID;references:ID"`
+// ReviewResponse is the hotel management's public reply to a review. A review
+// has at most one response.
+type ReviewResponse struct {
+	ID            uint   `json:"id" gorm:"primaryKey"`
+	ReviewID      uint   `json:"review_id" gorm:"not null;uniqueIndex"`
+	HotelID       uint   `json:"hotel_id" gorm:"not null;index"`
+	ResponderName string `json:"responder_name" gorm:"not null"`
+	Body          string `json:"body"`
+	// ResponseDate is when the hotel responded. Provider feeds only give the
+	// day, and it is nil when the feed's date could not be parsed.
+	ResponseDate *time.Time `json:"response_date"`
+	// ResponseDateText is the provider's own wording of the date, e.g.
+	// "Responded 3 days ago".
+	ResponseDateText string    `json:"response_date_text,omitempty"`
+	Source           string    `json:"source" gorm:"not null"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
 }
 
 // AuditLog represents the audit log for a processed file.
 type AuditLog struct {
-	ID           uint      `json:"id" gorm:"primaryKey"`
-	FileName     string    `json:"file_name" gorm:"not null"`
-	SuccessCount int       `json:"success_count" gorm:"default:0"`
-	FailureCount int       `json:"failure_count" gorm:"default:0"`
-	TotalCount   int       `json:"total_count" gorm:"default:0"`
-	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	FileName     string `json:"file_name" gorm:"not null"`
+	SuccessCount int    `json:"success_count" gorm:"default:0"`
+	FailureCount int    `json:"failure_count" gorm:"default:0"`
+	TotalCount   int    `json:"total_count" gorm:"default:0"`
+	// RuleHits counts the reviews of the file each auto-moderation rule matched.
+	RuleHits  map[string]int `json:"rule_hits,omitempty" gorm:"type:jsonb;serializer:json"`
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
 }
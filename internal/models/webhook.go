@@ -0,0 +1,66 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription is an endpoint that is sent the events it subscribes to.
+type WebhookSubscription struct {
+	ID  uint   `json:"id" gorm:"primaryKey"`
+	URL string `json:"url" gorm:"not null"`
+	// Secret signs the deliveries. It is only returned when the subscription
+	// is created.
+	Secret      string `json:"-" gorm:"not null"`
+	Description string `json:"description"`
+	// EventTypes are the event types the subscription is sent.
+	EventTypes []string `json:"event_types" gorm:"type:jsonb;serializer:json;not null"`
+	// HotelID and ProviderID, when set, restrict review events to those of
+	// one hotel or provider. Import events concern no single hotel or
+	// provider, so only subscriptions without them are sent import events.
+	HotelID    uint      `json:"hotel_id,omitempty" gorm:"not null;default:0;index"`
+	ProviderID uint      `json:"provider_id,omitempty" gorm:"not null;default:0;index"`
+	Disabled   bool      `json:"disabled" gorm:"not null;default:false"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Statuses of a webhook delivery.
+const (
+	// WebhookDeliveryPending deliveries are waiting for their next attempt.
+	WebhookDeliveryPending = "pending"
+	// WebhookDeliverySucceeded deliveries were accepted by the endpoint.
+	WebhookDeliverySucceeded = "succeeded"
+	// WebhookDeliveryDead deliveries failed their last attempt and are only
+	// sent again when replayed.
+	WebhookDeliveryDead = "dead"
+)
+
+// WebhookDeliveryStatuses lists every delivery status.
+var WebhookDeliveryStatuses = []string{WebhookDeliveryPending, WebhookDeliverySucceeded, WebhookDeliveryDead}
+
+// WebhookDelivery is one event sent to one subscription, along with the
+// outcome of its latest attempt. Deliveries make up the delivery log.
+type WebhookDelivery struct {
+	ID             uint   `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint   `json:"subscription_id" gorm:"not null;index"`
+	EventID        string `json:"event_id" gorm:"not null;index"`
+	EventType      string `json:"event_type" gorm:"not null;index"`
+	// Payload is the body sent to the endpoint.
+	Payload  json.RawMessage `json:"payload" gorm:"type:jsonb;not null" swaggertype:"object"`
+	Status   string          `json:"status" gorm:"not null;default:'pending';index:idx_webhook_deliveries_due,priority:1"`
+	Attempts int             `json:"attempts" gorm:"not null;default:0"`
+	// NextAttemptAt is when a pending delivery is attempted next.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	// ResponseStatus is the HTTP status of the latest attempt, or 0 when the
+	// endpoint could not be reached.
+	ResponseStatus int    `json:"response_status,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	// ReplayOf is the delivery this one replays.
+	ReplayOf  *uint     `json:"replay_of,omitempty"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+
+	Subscription *WebhookSubscription `json:"-" gorm:"constraint:OnDelete:CASCADE;foreignKey:SubscriptionID;references:ID"`
+}
@@ -0,0 +1,158 @@
+// Package moderation runs configurable auto-moderation rules over reviews as
+// they are ingested.
+package moderation
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// Moderator is recorded as the moderator of reviews the rules flag.
+const Moderator = "auto-moderation"
+
+// ReloadInterval is how often a pipeline checks its rules file for changes.
+const ReloadInterval = 30 * time.Second
+
+// defaultRules are used when no rules file is configured.
+//
+//go:embed rules.json
+var defaultRules []byte
+
+// Result is the outcome of running the rules over a review.
+type Result struct {
+	// Rules are the names of every rule that fired, in rule order.
+	Rules []string
+	// FlaggedBy are the names of the rules that flagged the review.
+	FlaggedBy []string
+}
+
+// Flagged reports whether a rule flagged the review.
+func (r *Result) Flagged() bool {
+	return len(r.FlaggedBy) > 0
+}
+
+// Pipeline runs a list of rules over reviews. Rules loaded from a file are
+// reloaded when the file changes, so they can be tuned without a deploy. A
+// pipeline is safe for concurrent use.
+type Pipeline struct {
+	path           string
+	logger         *logger.Logger
+	reloadInterval time.Duration
+
+	mu        sync.Mutex
+	rules     []*rule
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// NewPipeline loads the rules file at path, or the built-in rules when path is
+// empty.
+func NewPipeline(path string, logger *logger.Logger) (*Pipeline, error) {
+	p := &Pipeline{
+		path:           path,
+		logger:         logger,
+		reloadInterval: ReloadInterval,
+	}
+
+	if path == "" {
+		rules, err := parseRules(defaultRules)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load built-in rules: %w", err)
+		}
+		p.rules = rules
+		return p, nil
+	}
+
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Reload reads the rules file again. The current rules are kept when the file
+// can not be read or is invalid.
+func (p *Pipeline) Reload() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.reload()
+}
+
+func (p *Pipeline) reload() error {
+	p.checkedAt = time.Now()
+	if p.path == "" {
+		return nil
+	}
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to read rules file: %w", err)
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to read rules file: %w", err)
+	}
+	rules, err := parseRules(data)
+	if err != nil {
+		return err
+	}
+
+	p.rules = rules
+	p.modTime = info.ModTime()
+	return nil
+}
+
+// current returns the rules to run, reloading them first when the rules file
+// has changed since it was last read.
+func (p *Pipeline) current() []*rule {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.path == "" || time.Since(p.checkedAt) < p.reloadInterval {
+		return p.rules
+	}
+
+	p.checkedAt = time.Now()
+	info, err := os.Stat(p.path)
+	if err != nil {
+		p.logger.Error(err, "Failed to check moderation rules file, keeping current rules")
+		return p.rules
+	}
+	if info.ModTime().Equal(p.modTime) {
+		return p.rules
+	}
+
+	if err := p.reload(); err != nil {
+		p.logger.Error(err, "Failed to reload moderation rules, keeping current rules")
+		return p.rules
+	}
+	p.logger.Info(fmt.Sprintf("Reloaded %d moderation rules from %s", len(p.rules), p.path))
+	return p.rules
+}
+
+// Apply runs the rules over the title and comment of a review, in order. Mask
+// rules rewrite the text in place, so later rules see the masked text. The
+// review's status is left to the caller.
+func (p *Pipeline) Apply(review *models.Review) *Result {
+	result := &Result{}
+	for _, r := range p.current() {
+		if !r.matches(review.Rating, review.Title, review.Comment) {
+			continue
+		}
+
+		result.Rules = append(result.Rules, r.Name)
+		switch r.Action {
+		case ActionFlag:
+			result.FlaggedBy = append(result.FlaggedBy, r.Name)
+		case ActionMask:
+			review.Title = r.mask(review.Title)
+			review.Comment = r.mask(review.Comment)
+		}
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package moderation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_Apply(t *testing.T) {
+	pipeline, err := NewPipeline("", logger.NewLogger(&logger.LogConfig{LogLevel: "info"}))
+	assert.NoError(t, err)
+
+	t.Run("clean_review", func(t *testing.T) {
+		review := &models.Review{Rating: 8.4, Title: "Lovely stay", Comment: "Friendly staff and a great breakfast."}
+
+		result := pipeline.Apply(review)
+
+		assert.Empty(t, result.Rules)
+		assert.False(t, result.Flagged())
+		assert.Equal(t, "Friendly staff and a great breakfast.", review.Comment)
+	})
+
+	t.Run("masks_pii", func(t *testing.T) {
+		review := &models.Review{Rating: 7, Title: "Good", Comment: "Email me at jane@example.com or call +1 415 555 0100."}
+
+		result := pipeline.Apply(review)
+
+		assert.Equal(t, []string{"email", "phone_number"}, result.Rules)
+		assert.False(t, result.Flagged())
+		assert.Equal(t, "Email me at [email removed] or call [phone removed].", review.Comment)
+	})
+
+	t.Run("flags_and_masks", func(t *testing.T) {
+		review := &models.Review{Rating: 10, Title: "Awful", Comment: "Worst place, shit service."}
+
+		result := pipeline.Apply(review)
+
+		assert.Equal(t, []string{"profanity", "high_rating_negative_text"}, result.Rules)
+		assert.Equal(t, []string{"high_rating_negative_text"}, result.FlaggedBy)
+		assert.True(t, result.Flagged())
+		assert.Equal(t, "Worst place, **** service.", review.Comment)
+	})
+}
+
+func TestPipeline_Reload(t *testing.T) {
+	log := logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRules := func(rules string, modTime time.Time) {
+		assert.NoError(t, os.WriteFile(path, []byte(rules), 0600))
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	start := time.Now().Add(-time.Hour)
+
+	writeRules(`{"rules":[{"name":"no_pets","type":"terms","action":"flag","terms":["dog"]}]}`, start)
+	pipeline, err := NewPipeline(path, log)
+	assert.NoError(t, err)
+	pipeline.reloadInterval = 0
+
+	review := func() *models.Review { return &models.Review{Comment: "They let my dog and cat stay"} }
+	assert.Equal(t, []string{"no_pets"}, pipeline.Apply(review()).Rules)
+
+	// A changed file is picked up on the next review
+	writeRules(`{"rules":[{"name":"no_cats","type":"terms","action":"flag","terms":["cat"]}]}`, start.Add(time.Minute))
+	assert.Equal(t, []string{"no_cats"}, pipeline.Apply(review()).Rules)
+
+	// An invalid file keeps the rules already loaded
+	writeRules(`{"rules":[{"name":"broken","type":"terms","action":"flag"}]}`, start.Add(2*time.Minute))
+	assert.Equal(t, []string{"no_cats"}, pipeline.Apply(review()).Rules)
+
+	t.Run("missing_file", func(t *testing.T) {
+		_, err := NewPipeline(filepath.Join(t.TempDir(), "missing.json"), log)
+		assert.Error(t, err)
+	})
+}
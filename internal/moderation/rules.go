@@ -0,0 +1,233 @@
+package moderation
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rule types.
+const (
+	// RuleTerms matches whole words or phrases, such as profanity or a
+	// blocklist, case-insensitively.
+	RuleTerms = "terms"
+	// RulePattern matches regular expressions, such as emails or phone numbers.
+	RulePattern = "pattern"
+	// RuleCaps matches titles or comments written mostly in capitals.
+	RuleCaps = "caps"
+	// RuleLinks matches reviews with more links than allowed.
+	RuleLinks = "links"
+	// RuleContradiction matches reviews whose rating is in a range while the
+	// text uses terms that contradict it, such as a 10 that says "terrible".
+	RuleContradiction = "contradiction"
+)
+
+// Rule actions.
+const (
+	// ActionFlag sends the review to the moderation queue.
+	ActionFlag = "flag"
+	// ActionMask replaces the matched text. Only rules that match text, the
+	// terms, pattern and links rules, can mask.
+	ActionMask = "mask"
+)
+
+// linkPattern matches URLs and bare www. addresses.
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://\S+|\bwww\.\S+`)
+
+// RuleFile is the format of a rules file.
+type RuleFile struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule is one auto-moderation rule as configured. Which settings apply depends
+// on the type.
+type Rule struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Action   string `json:"action"`
+	Disabled bool   `json:"disabled,omitempty"`
+
+	// Terms are the words or phrases of terms and contradiction rules.
+	Terms []string `json:"terms,omitempty"`
+	// Patterns are the regular expressions of pattern rules.
+	Patterns []string `json:"patterns,omitempty"`
+	// Replacement replaces masked text. Defaults to one * per character.
+	Replacement string `json:"replacement,omitempty"`
+
+	// MaxCapsRatio is the share of capital letters above which a caps rule
+	// fires, for texts of at least MinLetters letters.
+	MaxCapsRatio float64 `json:"max_caps_ratio,omitempty"`
+	MinLetters   int     `json:"min_letters,omitempty"`
+
+	// MaxLinks is the number of links a links rule allows.
+	MaxLinks int `json:"max_links,omitempty"`
+
+	// MinRating and MaxRating bound the ratings, inclusive, a contradiction
+	// rule applies to.
+	MinRating *float64 `json:"min_rating,omitempty"`
+	MaxRating *float64 `json:"max_rating,omitempty"`
+}
+
+// rule is a validated rule with its expression compiled.
+type rule struct {
+	Rule
+	re *regexp.Regexp
+}
+
+// parseRules parses and compiles a rules file. Disabled rules are skipped.
+func parseRules(data []byte) ([]*rule, error) {
+	var file RuleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	names := make(map[string]bool, len(file.Rules))
+	rules := make([]*rule, 0, len(file.Rules))
+	for i, config := range file.Rules {
+		if config.Name == "" {
+			return nil, fmt.Errorf("rule %d: name is required", i+1)
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("rule %s: name is used more than once", config.Name)
+		}
+		names[config.Name] = true
+
+		compiled, err := compileRule(config)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", config.Name, err)
+		}
+		if !config.Disabled {
+			rules = append(rules, compiled)
+		}
+	}
+	return rules, nil
+}
+
+func compileRule(config Rule) (*rule, error) {
+	switch config.Action {
+	case ActionFlag:
+	case ActionMask:
+		if config.Type != RuleTerms && config.Type != RulePattern && config.Type != RuleLinks {
+			return nil, fmt.Errorf("%s rules can not mask", config.Type)
+		}
+	default:
+		return nil, fmt.Errorf("action must be %s or %s", ActionFlag, ActionMask)
+	}
+
+	compiled := &rule{Rule: config}
+	switch config.Type {
+	case RuleTerms, RuleContradiction:
+		re, err := termsExpression(config.Terms)
+		if err != nil {
+			return nil, err
+		}
+		compiled.re = re
+		if config.Type == RuleContradiction && config.MinRating == nil && config.MaxRating == nil {
+			return nil, fmt.Errorf("min_rating or max_rating is required")
+		}
+	case RulePattern:
+		if len(config.Patterns) == 0 {
+			return nil, fmt.Errorf("patterns is required")
+		}
+		re, err := regexp.Compile("(?:" + strings.Join(config.Patterns, ")|(?:") + ")")
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		compiled.re = re
+	case RuleCaps:
+		if config.MaxCapsRatio <= 0 || config.MaxCapsRatio >= 1 {
+			return nil, fmt.Errorf("max_caps_ratio must be between 0 and 1")
+		}
+	case RuleLinks:
+		if config.MaxLinks < 0 {
+			return nil, fmt.Errorf("max_links must not be negative")
+		}
+		compiled.re = linkPattern
+	default:
+		return nil, fmt.Errorf("unknown type %q", config.Type)
+	}
+	return compiled, nil
+}
+
+// termsExpression matches any of the terms as whole words, ignoring case.
+func termsExpression(terms []string) (*regexp.Regexp, error) {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			quoted = append(quoted, regexp.QuoteMeta(term))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil, fmt.Errorf("terms is required")
+	}
+	return regexp.Compile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+}
+
+// matches reports whether the rule fires for a review with the given rating
+// and texts.
+func (r *rule) matches(rating float64, texts ...string) bool {
+	switch r.Type {
+	case RuleTerms, RulePattern:
+		for _, text := range texts {
+			if r.re.MatchString(text) {
+				return true
+			}
+		}
+	case RuleCaps:
+		for _, text := range texts {
+			if r.shouting(text) {
+				return true
+			}
+		}
+	case RuleLinks:
+		links := 0
+		for _, text := range texts {
+			links += len(r.re.FindAllStringIndex(text, -1))
+		}
+		return links > r.MaxLinks
+	case RuleContradiction:
+		if r.MinRating != nil && rating < *r.MinRating {
+			return false
+		}
+		if r.MaxRating != nil && rating > *r.MaxRating {
+			return false
+		}
+		for _, text := range texts {
+			if r.re.MatchString(text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shouting reports whether more than the allowed share of the letters in text
+// are capitals. Texts shorter than MinLetters letters never shout.
+func (r *rule) shouting(text string) bool {
+	var letters, upper int
+	for _, c := range text {
+		if unicode.IsLetter(c) {
+			letters++
+			if unicode.IsUpper(c) {
+				upper++
+			}
+		}
+	}
+	if letters == 0 || letters < r.MinLetters {
+		return false
+	}
+	return float64(upper)/float64(letters) > r.MaxCapsRatio
+}
+
+// mask replaces every match of the rule in text.
+func (r *rule) mask(text string) string {
+	return r.re.ReplaceAllStringFunc(text, func(match string) string {
+		if r.Replacement != "" {
+			return r.Replacement
+		}
+		return strings.Repeat("*", utf8.RuneCountInString(match))
+	})
+}
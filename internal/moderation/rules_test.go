@@ -0,0 +1,138 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       string
+		expectedErr string
+	}{
+		{
+			name:  "built-in rules",
+			rules: string(defaultRules),
+		},
+		{
+			name:        "invalid json",
+			rules:       `{"rules":`,
+			expectedErr: "failed to parse rules: unexpected end of JSON input",
+		},
+		{
+			name:        "missing name",
+			rules:       `{"rules":[{"type":"caps","action":"flag","max_caps_ratio":0.5}]}`,
+			expectedErr: "rule 1: name is required",
+		},
+		{
+			name:        "duplicate name",
+			rules:       `{"rules":[{"name":"a","type":"links","action":"flag"},{"name":"a","type":"links","action":"flag"}]}`,
+			expectedErr: "rule a: name is used more than once",
+		},
+		{
+			name:        "unknown type",
+			rules:       `{"rules":[{"name":"a","type":"sentiment","action":"flag"}]}`,
+			expectedErr: `rule a: unknown type "sentiment"`,
+		},
+		{
+			name:        "unknown action",
+			rules:       `{"rules":[{"name":"a","type":"links","action":"delete"}]}`,
+			expectedErr: "rule a: action must be flag or mask",
+		},
+		{
+			name:        "caps can not mask",
+			rules:       `{"rules":[{"name":"a","type":"caps","action":"mask","max_caps_ratio":0.5}]}`,
+			expectedErr: "rule a: caps rules can not mask",
+		},
+		{
+			name:        "terms without terms",
+			rules:       `{"rules":[{"name":"a","type":"terms","action":"flag","terms":[" "]}]}`,
+			expectedErr: "rule a: terms is required",
+		},
+		{
+			name:        "invalid pattern",
+			rules:       `{"rules":[{"name":"a","type":"pattern","action":"mask","patterns":["("]}]}`,
+			expectedErr: "rule a: invalid pattern: error parsing regexp: missing closing ): `(?:()`",
+		},
+		{
+			name:        "contradiction without rating",
+			rules:       `{"rules":[{"name":"a","type":"contradiction","action":"flag","terms":["awful"]}]}`,
+			expectedErr: "rule a: min_rating or max_rating is required",
+		},
+		{
+			name:        "caps ratio out of range",
+			rules:       `{"rules":[{"name":"a","type":"caps","action":"flag","max_caps_ratio":1.5}]}`,
+			expectedErr: "rule a: max_caps_ratio must be between 0 and 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRules([]byte(tt.rules))
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+
+	t.Run("disabled rules are skipped", func(t *testing.T) {
+		rules, err := parseRules([]byte(`{"rules":[{"name":"a","type":"links","action":"flag","disabled":true},{"name":"b","type":"links","action":"flag"}]}`))
+		assert.NoError(t, err)
+		assert.Len(t, rules, 1)
+		assert.Equal(t, "b", rules[0].Name)
+	})
+}
+
+func TestRule_Matches(t *testing.T) {
+	rules, err := parseRules(defaultRules)
+	assert.NoError(t, err)
+	byName := make(map[string]*rule)
+	for _, r := range rules {
+		byName[r.Name] = r
+	}
+
+	tests := []struct {
+		rule     string
+		rating   float64
+		text     string
+		expected bool
+	}{
+		{rule: "profanity", text: "The room was SHIT", expected: true},
+		{rule: "profanity", text: "Shitake mushrooms at breakfast", expected: false},
+		{rule: "blocklist", text: "Use promo code SUMMER for cheaper rates", expected: true},
+		{rule: "email", text: "Write to me at jane.doe@example.com", expected: true},
+		{rule: "email", text: "Staff were @ the desk all night", expected: false},
+		{rule: "phone_number", text: "Call +65 6123 4567 for a tour", expected: true},
+		{rule: "phone_number", text: "Stayed from 2024-01-05 for 3 nights", expected: false},
+		{rule: "excessive_caps", text: "WORST HOTEL EVER DO NOT STAY HERE", expected: true},
+		{rule: "excessive_caps", text: "Great stay near MRT and KLCC", expected: false},
+		{rule: "excessive_caps", text: "OK", expected: false},
+		{rule: "excessive_links", text: "See https://a.example and www.b.example", expected: true},
+		{rule: "excessive_links", text: "Photos at https://a.example", expected: false},
+		{rule: "high_rating_negative_text", rating: 9.6, text: "Terrible service, never again", expected: true},
+		{rule: "high_rating_negative_text", rating: 4, text: "Terrible service, never again", expected: false},
+		{rule: "low_rating_positive_text", rating: 2, text: "Excellent location and amazing staff", expected: true},
+		{rule: "low_rating_positive_text", rating: 9, text: "Excellent location and amazing staff", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			assert.Equal(t, tt.expected, byName[tt.rule].matches(tt.rating, tt.text), tt.text)
+		})
+	}
+}
+
+func TestRule_Mask(t *testing.T) {
+	rules, err := parseRules([]byte(`{"rules":[
+		{"name":"swear","type":"terms","action":"mask","terms":["damn"]},
+		{"name":"email","type":"pattern","action":"mask","patterns":["\\S+@\\S+"],"replacement":"[email removed]"}
+	]}`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "A **** good view, **** it", rules[0].mask("A damn good view, DAMN it"))
+	assert.Equal(t, "Mail [email removed] now", rules[1].mask("Mail me@example.com now"))
+}
@@ -0,0 +1,186 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Sink types.
+const (
+	// SinkSlack posts to a Slack-compatible incoming webhook.
+	SinkSlack = "slack"
+	// SinkEmail sends an email through an SMTP server.
+	SinkEmail = "email"
+	// SinkHTTP posts the summary as JSON.
+	SinkHTTP = "http"
+)
+
+// ConfigFile is the format of a notifications file.
+type ConfigFile struct {
+	// Sinks are the places summaries can be sent, by name.
+	Sinks map[string]SinkConfig `json:"sinks"`
+	// Threshold is when a file that is still being processed has failed
+	// enough to notify about it right away.
+	Threshold Threshold `json:"threshold"`
+	// Routes decide which sinks are sent which summaries. A summary is sent
+	// once to every sink of every route it matches.
+	Routes []Route `json:"routes"`
+}
+
+// SinkConfig is one sink as configured. Which settings apply depends on the
+// type.
+type SinkConfig struct {
+	Type string `json:"type"`
+
+	// URL is where slack and http sinks post.
+	URL string `json:"url,omitempty"`
+	// Headers are added to the requests of http sinks, such as an
+	// Authorization header.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Host and Port are the SMTP server of email sinks. Port defaults to 587.
+	Host string `json:"host,omitempty"`
+	Port int    `json:"port,omitempty"`
+	// Username and the password in the PasswordEnv environment variable log
+	// in to the SMTP server, when set.
+	Username    string   `json:"username,omitempty"`
+	PasswordEnv string   `json:"password_env,omitempty"`
+	From        string   `json:"from,omitempty"`
+	To          []string `json:"to,omitempty"`
+}
+
+// DefaultMinLines is how many lines must be processed before MaxFailureRate
+// applies when MinLines is not set, so a failed first line is not a 100%
+// failure rate.
+const DefaultMinLines = 20
+
+// Threshold is how many failures a file may have before a
+// StatusThresholdCrossed summary is sent. Unset limits do not apply.
+type Threshold struct {
+	// MaxFailures is the number of failed lines that crosses the threshold.
+	MaxFailures int `json:"max_failures,omitempty"`
+	// MaxFailureRate is the share of failed lines that crosses the
+	// threshold, once at least MinLines lines were processed. MinLines
+	// defaults to DefaultMinLines.
+	MaxFailureRate float64 `json:"max_failure_rate,omitempty"`
+	MinLines       int     `json:"min_lines,omitempty"`
+}
+
+// crossed reports whether failed of total lines cross the threshold.
+func (t *Threshold) crossed(failed, total int) bool {
+	if t.MaxFailures > 0 && failed >= t.MaxFailures {
+		return true
+	}
+	minLines := t.MinLines
+	if minLines == 0 {
+		minLines = DefaultMinLines
+	}
+	return t.MaxFailureRate > 0 && total >= minLines &&
+		float64(failed)/float64(total) >= t.MaxFailureRate
+}
+
+// Route sends the summaries of some providers' files to some sinks.
+type Route struct {
+	Name string `json:"name"`
+	// Providers are the provider names whose files the route matches,
+	// ignoring case. A route without providers matches every file.
+	Providers []string `json:"providers,omitempty"`
+	// On are the statuses of the summaries the route matches. A route
+	// without statuses matches every summary.
+	On    []string `json:"on,omitempty"`
+	Sinks []string `json:"sinks"`
+}
+
+// matches reports whether the route matches a summary.
+func (r *Route) matches(summary *Summary) bool {
+	if len(r.On) > 0 && !slices.Contains(r.On, summary.Status) {
+		return false
+	}
+	if len(r.Providers) == 0 {
+		return true
+	}
+	for _, provider := range summary.Providers {
+		if slices.ContainsFunc(r.Providers, func(name string) bool { return strings.EqualFold(name, provider) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadConfig reads and validates a notifications file.
+func LoadConfig(path string) (*ConfigFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notifications file: %w", err)
+	}
+	return parseConfig(data)
+}
+
+func parseConfig(data []byte) (*ConfigFile, error) {
+	var config ConfigFile
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse notifications: %w", err)
+	}
+
+	for name, sink := range config.Sinks {
+		if err := validateSink(&sink); err != nil {
+			return nil, fmt.Errorf("sink %s: %w", name, err)
+		}
+	}
+
+	threshold := config.Threshold
+	if threshold.MaxFailures < 0 || threshold.MinLines < 0 {
+		return nil, fmt.Errorf("threshold: max_failures and min_lines must not be negative")
+	}
+	if threshold.MaxFailureRate < 0 || threshold.MaxFailureRate > 1 {
+		return nil, fmt.Errorf("threshold: max_failure_rate must be between 0 and 1")
+	}
+
+	for i, route := range config.Routes {
+		name := route.Name
+		if name == "" {
+			name = fmt.Sprint(i + 1)
+		}
+		if len(route.Sinks) == 0 {
+			return nil, fmt.Errorf("route %s: sinks is required", name)
+		}
+		for _, sink := range route.Sinks {
+			if _, ok := config.Sinks[sink]; !ok {
+				return nil, fmt.Errorf("route %s: unknown sink %q", name, sink)
+			}
+		}
+		for _, status := range route.On {
+			if !slices.Contains(Statuses, status) {
+				return nil, fmt.Errorf("route %s: unknown status %q", name, status)
+			}
+		}
+	}
+	return &config, nil
+}
+
+func validateSink(sink *SinkConfig) error {
+	switch sink.Type {
+	case SinkSlack, SinkHTTP:
+		endpoint, err := url.Parse(sink.URL)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return fmt.Errorf("url must be an absolute http or https URL")
+		}
+	case SinkEmail:
+		if sink.Host == "" {
+			return fmt.Errorf("host is required")
+		}
+		if sink.From == "" {
+			return fmt.Errorf("from is required")
+		}
+		if len(sink.To) == 0 {
+			return fmt.Errorf("to is required")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", sink.Type)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		expectedErr string
+	}{
+		{
+			name: "valid config",
+			config: `{
+				"sinks": {
+					"ops": {"type": "slack", "url": "https://hooks.slack.com/services/T0/B0/x"},
+					"data": {"type": "email", "host": "smtp.example.com", "from": "imports@example.com", "to": ["data@example.com"]},
+					"pager": {"type": "http", "url": "https://pager.example.com/events", "headers": {"Authorization": "Token x"}}
+				},
+				"threshold": {"max_failures": 100, "max_failure_rate": 0.2, "min_lines": 50},
+				"routes": [
+					{"name": "agoda", "providers": ["Agoda"], "sinks": ["ops", "data"]},
+					{"name": "failures", "on": ["failed", "threshold_crossed"], "sinks": ["pager"]}
+				]
+			}`,
+			expectedErr: "",
+		},
+		{
+			name:        "invalid JSON",
+			config:      `{"sinks": `,
+			expectedErr: "failed to parse notifications: unexpected end of JSON input",
+		},
+		{
+			name:        "unknown sink type",
+			config:      `{"sinks": {"ops": {"type": "pigeon"}}}`,
+			expectedErr: `sink ops: unknown type "pigeon"`,
+		},
+		{
+			name:        "slack sink without url",
+			config:      `{"sinks": {"ops": {"type": "slack"}}}`,
+			expectedErr: "sink ops: url must be an absolute http or https URL",
+		},
+		{
+			name:        "email sink without recipients",
+			config:      `{"sinks": {"data": {"type": "email", "host": "smtp.example.com", "from": "imports@example.com"}}}`,
+			expectedErr: "sink data: to is required",
+		},
+		{
+			name:        "failure rate above 1",
+			config:      `{"threshold": {"max_failure_rate": 2}}`,
+			expectedErr: "threshold: max_failure_rate must be between 0 and 1",
+		},
+		{
+			name:        "route to unknown sink",
+			config:      `{"routes": [{"name": "agoda", "sinks": ["ops"]}]}`,
+			expectedErr: `route agoda: unknown sink "ops"`,
+		},
+		{
+			name:        "route without sinks",
+			config:      `{"routes": [{"providers": ["Agoda"]}]}`,
+			expectedErr: "route 1: sinks is required",
+		},
+		{
+			name:        "route on unknown status",
+			config:      `{"sinks": {"ops": {"type": "slack", "url": "https://hooks.slack.com/x"}}, "routes": [{"name": "agoda", "on": ["done"], "sinks": ["ops"]}]}`,
+			expectedErr: `route agoda: unknown status "done"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig([]byte(tt.config))
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRoute_Matches(t *testing.T) {
+	summary := &Summary{Status: StatusCompleted, Providers: []string{"Agoda", "Booking.com"}}
+
+	assert.True(t, (&Route{}).matches(summary))
+	assert.True(t, (&Route{Providers: []string{"agoda"}}).matches(summary))
+	assert.False(t, (&Route{Providers: []string{"Expedia"}}).matches(summary))
+	assert.True(t, (&Route{On: []string{StatusCompleted}}).matches(summary))
+	assert.False(t, (&Route{On: []string{StatusFailed, StatusThresholdCrossed}}).matches(summary))
+}
+
+func TestThreshold_Crossed(t *testing.T) {
+	threshold := &Threshold{MaxFailures: 100, MaxFailureRate: 0.5, MinLines: 10}
+
+	assert.False(t, threshold.crossed(4, 5), "rate only applies from min_lines lines")
+	assert.True(t, threshold.crossed(5, 10))
+	assert.False(t, threshold.crossed(99, 1000))
+	assert.True(t, threshold.crossed(100, 1000))
+	assert.False(t, (&Threshold{}).crossed(1000, 1000), "no limits are set")
+
+	rateOnly := &Threshold{MaxFailureRate: 0.5}
+	assert.False(t, rateOnly.crossed(1, 1), "min_lines defaults to DefaultMinLines")
+	assert.True(t, rateOnly.crossed(DefaultMinLines/2, DefaultMinLines))
+}
@@ -0,0 +1,195 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kirananto/review-system/internal/logger"
+)
+
+// Notifier sends the summaries of ingested files to the sinks their routes
+// name. A nil Notifier starts nil runs, which notify nobody.
+type Notifier struct {
+	routes    []Route
+	sinks     map[string]Sink
+	threshold Threshold
+	logger    *logger.Logger
+	now       func() time.Time
+}
+
+// NewNotifier loads the notifications file at path. It returns nil when path
+// is empty, as nobody is to be notified.
+func NewNotifier(path string, logger *logger.Logger) (*Notifier, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sinks := make(map[string]Sink, len(config.Sinks))
+	for name, sink := range config.Sinks {
+		sinks[name] = newSink(sink)
+	}
+	return newNotifier(config, sinks, logger), nil
+}
+
+func newNotifier(config *ConfigFile, sinks map[string]Sink, logger *logger.Logger) *Notifier {
+	return &Notifier{
+		routes:    config.Routes,
+		sinks:     sinks,
+		threshold: config.Threshold,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Start starts tracking the ingestion of a file.
+func (n *Notifier) Start(fileName string) *Run {
+	if n == nil {
+		return nil
+	}
+	return &Run{
+		notifier:  n,
+		fileName:  fileName,
+		startedAt: n.now(),
+		errors:    make(map[string]int),
+	}
+}
+
+// notify sends a summary to the sinks of the routes it matches, each sink
+// once. Failures are logged rather than returned, so they never fail the
+// ingestion.
+func (n *Notifier) notify(ctx context.Context, summary *Summary) {
+	var names []string
+	for _, route := range n.routes {
+		if !route.matches(summary) {
+			continue
+		}
+		for _, name := range route.Sinks {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := n.sinks[name].Send(ctx, summary); err != nil {
+				n.logger.Error(err, fmt.Sprintf("Failed to notify %s about %s", name, summary.FileName))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Run tracks the ingestion of one file. It is not safe for concurrent use.
+type Run struct {
+	notifier     *Notifier
+	fileName     string
+	startedAt    time.Time
+	providers    []string
+	successCount int
+	failureCount int
+	errors       map[string]int
+	// alerted is whether the failure threshold was crossed already.
+	alerted bool
+}
+
+// Record records the outcome of a line. provider is the provider the line
+// names, if it could be told. The StatusThresholdCrossed summary is sent once
+// the failures cross the threshold.
+func (r *Run) Record(ctx context.Context, provider string, err error) {
+	if r == nil {
+		return
+	}
+
+	if provider != "" && !slices.Contains(r.providers, provider) {
+		r.providers = append(r.providers, provider)
+	}
+	if err == nil {
+		r.successCount++
+		return
+	}
+
+	r.failureCount++
+	r.errors[errorReason(err)]++
+	if !r.alerted && r.notifier.threshold.crossed(r.failureCount, r.successCount+r.failureCount) {
+		r.alerted = true
+		r.notifier.notify(ctx, r.summary(StatusThresholdCrossed, nil))
+	}
+}
+
+// Finish sends the summary of the file. err is why the file could not be read
+// to the end, if it could not; the file has also failed when none of its lines
+// could be imported.
+func (r *Run) Finish(ctx context.Context, err error) {
+	if r == nil {
+		return
+	}
+
+	status := StatusCompleted
+	if err != nil || (r.failureCount > 0 && r.successCount == 0) {
+		status = StatusFailed
+	}
+	r.notifier.notify(ctx, r.summary(status, err))
+}
+
+func (r *Run) summary(status string, err error) *Summary {
+	summary := &Summary{
+		FileName:     r.fileName,
+		Status:       status,
+		Providers:    slices.Clone(r.providers),
+		SuccessCount: r.successCount,
+		FailureCount: r.failureCount,
+		TotalCount:   r.successCount + r.failureCount,
+		StartedAt:    r.startedAt,
+		Duration:     r.notifier.now().Sub(r.startedAt),
+		TopErrors:    topErrors(r.errors, maxErrorReasons),
+	}
+	if err != nil {
+		summary.Error = err.Error()
+	}
+	return summary
+}
+
+// maxReasonLength bounds the length of an error reason.
+const maxReasonLength = 200
+
+// errorReason is what a line's error is counted as: the first line of its
+// message, at most maxReasonLength bytes of it.
+func errorReason(err error) string {
+	reason, _, _ := strings.Cut(err.Error(), "\n")
+	if len(reason) > maxReasonLength {
+		reason = strings.ToValidUTF8(reason[:maxReasonLength], "") + "..."
+	}
+	return reason
+}
+
+// topErrors returns up to n of the most frequent reasons, most frequent first.
+func topErrors(counts map[string]int, n int) []ErrorReason {
+	reasons := make([]ErrorReason, 0, len(counts))
+	for reason, count := range counts {
+		reasons = append(reasons, ErrorReason{Reason: reason, Count: count})
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if reasons[i].Count != reasons[j].Count {
+			return reasons[i].Count > reasons[j].Count
+		}
+		return reasons[i].Reason < reasons[j].Reason
+	})
+	if len(reasons) > n {
+		reasons = reasons[:n]
+	}
+	return reasons
+}
@@ -0,0 +1,368 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSink keeps the summaries it is sent.
+type recordingSink struct {
+	mu        sync.Mutex
+	summaries []*Summary
+}
+
+func (s *recordingSink) Send(ctx context.Context, summary *Summary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summaries = append(s.summaries, summary)
+	return nil
+}
+
+func (s *recordingSink) statuses() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]string, len(s.summaries))
+	for i, summary := range s.summaries {
+		statuses[i] = summary.Status
+	}
+	return statuses
+}
+
+var testLogger = logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+
+func TestNotifier_Routes(t *testing.T) {
+	ops, data, pager := &recordingSink{}, &recordingSink{}, &recordingSink{}
+	notifier := newNotifier(&ConfigFile{
+		Threshold: Threshold{MaxFailures: 2},
+		Routes: []Route{
+			{Name: "agoda", Providers: []string{"Agoda"}, Sinks: []string{"ops", "data"}},
+			{Name: "expedia", Providers: []string{"Expedia"}, Sinks: []string{"data"}},
+			{Name: "everything", Sinks: []string{"ops"}},
+			{Name: "failures", On: []string{StatusFailed, StatusThresholdCrossed}, Sinks: []string{"pager"}},
+		},
+	}, map[string]Sink{"ops": ops, "data": data, "pager": pager}, testLogger)
+
+	run := notifier.Start("agoda.jl")
+	run.Record(context.Background(), "Agoda", nil)
+	run.Record(context.Background(), "Agoda", errors.New("invalid review: hotelId is required"))
+	run.Record(context.Background(), "", errors.New("invalid review: failed to parse JSON"))
+	run.Record(context.Background(), "Agoda", errors.New("invalid review: hotelId is required"))
+	run.Finish(context.Background(), nil)
+
+	// ops is in two matching routes, but hears about everything once
+	assert.Equal(t, []string{StatusThresholdCrossed, StatusCompleted}, ops.statuses())
+	assert.Equal(t, []string{StatusThresholdCrossed, StatusCompleted}, data.statuses())
+	assert.Equal(t, []string{StatusThresholdCrossed}, pager.statuses())
+
+	alert := pager.summaries[0]
+	assert.Equal(t, 1, alert.SuccessCount)
+	assert.Equal(t, 2, alert.FailureCount)
+	assert.Equal(t, 3, alert.TotalCount)
+
+	summary := ops.summaries[1]
+	assert.Equal(t, "agoda.jl", summary.FileName)
+	assert.Equal(t, []string{"Agoda"}, summary.Providers)
+	assert.Equal(t, 1, summary.SuccessCount)
+	assert.Equal(t, 3, summary.FailureCount)
+	assert.Equal(t, 4, summary.TotalCount)
+	assert.Equal(t, []ErrorReason{
+		{Reason: "invalid review: hotelId is required", Count: 2},
+		{Reason: "invalid review: failed to parse JSON", Count: 1},
+	}, summary.TopErrors)
+}
+
+func TestRun_Record(t *testing.T) {
+	t.Run("failure rate without min lines", func(t *testing.T) {
+		sink := &recordingSink{}
+		notifier := newNotifier(&ConfigFile{
+			Threshold: Threshold{MaxFailureRate: 0.5},
+			Routes:    []Route{{Sinks: []string{"ops"}}},
+		}, map[string]Sink{"ops": sink}, testLogger)
+
+		run := notifier.Start("agoda.jl")
+		run.Record(context.Background(), "Agoda", errors.New("invalid review: hotelId is required"))
+		assert.Empty(t, sink.statuses(), "a failed first line does not cross the threshold")
+
+		for range DefaultMinLines - 2 {
+			run.Record(context.Background(), "Agoda", errors.New("invalid review: hotelId is required"))
+		}
+		assert.Empty(t, sink.statuses())
+
+		run.Record(context.Background(), "Agoda", errors.New("invalid review: hotelId is required"))
+		require.Equal(t, []string{StatusThresholdCrossed}, sink.statuses())
+		assert.Equal(t, DefaultMinLines, sink.summaries[0].TotalCount)
+	})
+}
+
+func TestRun_Finish(t *testing.T) {
+	t.Run("file where every line failed", func(t *testing.T) {
+		sink := &recordingSink{}
+		notifier := newNotifier(&ConfigFile{Routes: []Route{{Sinks: []string{"ops"}}}}, map[string]Sink{"ops": sink}, testLogger)
+
+		run := notifier.Start("broken.jl")
+		run.Record(context.Background(), "", errors.New("invalid review: failed to parse JSON"))
+		run.Finish(context.Background(), nil)
+
+		assert.Equal(t, []string{StatusFailed}, sink.statuses())
+	})
+
+	t.Run("file that could not be read", func(t *testing.T) {
+		sink := &recordingSink{}
+		notifier := newNotifier(&ConfigFile{Routes: []Route{{Sinks: []string{"ops"}}}}, map[string]Sink{"ops": sink}, testLogger)
+
+		run := notifier.Start("truncated.jl")
+		run.Record(context.Background(), "Agoda", nil)
+		run.Finish(context.Background(), errors.New("bufio.Scanner: token too long"))
+
+		require.Equal(t, []string{StatusFailed}, sink.statuses())
+		assert.Equal(t, "bufio.Scanner: token too long", sink.summaries[0].Error)
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		sink := &recordingSink{}
+		notifier := newNotifier(&ConfigFile{Routes: []Route{{Sinks: []string{"ops"}}}}, map[string]Sink{"ops": sink}, testLogger)
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		notifier.now = func() time.Time { return now }
+
+		run := notifier.Start("reviews.jl")
+		now = now.Add(90 * time.Second)
+		run.Finish(context.Background(), nil)
+
+		require.Len(t, sink.summaries, 1)
+		assert.Equal(t, 90*time.Second, sink.summaries[0].Duration)
+	})
+
+	t.Run("nil notifier", func(t *testing.T) {
+		var notifier *Notifier
+		run := notifier.Start("reviews.jl")
+		run.Record(context.Background(), "Agoda", nil)
+		run.Finish(context.Background(), nil)
+		assert.Nil(t, run)
+	})
+}
+
+func TestTopErrors(t *testing.T) {
+	counts := map[string]int{"a": 1, "b": 5, "c": 3, "d": 3, "e": 2, "f": 1}
+
+	assert.Equal(t, []ErrorReason{
+		{Reason: "b", Count: 5},
+		{Reason: "c", Count: 3},
+		{Reason: "d", Count: 3},
+	}, topErrors(counts, 3))
+}
+
+func testSummary() *Summary {
+	return &Summary{
+		FileName:     "reviews.jl",
+		Status:       StatusCompleted,
+		Providers:    []string{"Agoda"},
+		SuccessCount: 98,
+		FailureCount: 2,
+		TotalCount:   100,
+		Duration:     1500 * time.Millisecond,
+		TopErrors:    []ErrorReason{{Reason: "invalid review: hotelId is required", Count: 2}},
+	}
+}
+
+func TestSummary_Text(t *testing.T) {
+	assert.Equal(t, "Import of reviews.jl completed\n\n"+
+		"Processed 100 lines in 1.5s: 98 imported, 2 failed (2.0%).\n"+
+		"Providers: Agoda\n"+
+		"Top errors:\n"+
+		"- 2x invalid review: hotelId is required\n", testSummary().Text())
+}
+
+func TestSlackSink_Send(t *testing.T) {
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	t.Run("summary", func(t *testing.T) {
+		sink := newSink(SinkConfig{Type: SinkSlack, URL: server.URL})
+		err := sink.Send(context.Background(), testSummary())
+
+		assert.NoError(t, err)
+		assert.Equal(t, testSummary().Text(), body["text"])
+	})
+
+	t.Run("markup is escaped", func(t *testing.T) {
+		summary := testSummary()
+		summary.FileName = "<!channel> & <https://example.com|reviews>.jl"
+
+		sink := newSink(SinkConfig{Type: SinkSlack, URL: server.URL})
+		err := sink.Send(context.Background(), summary)
+
+		assert.NoError(t, err)
+		assert.Contains(t, body["text"], "Import of &lt;!channel&gt; &amp; &lt;https://example.com|reviews&gt;.jl completed")
+		assert.NotContains(t, body["text"], "<")
+	})
+}
+
+func TestHTTPSink_Send(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var summary map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Token secret", r.Header.Get("Authorization"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&summary))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		sink := newSink(SinkConfig{Type: SinkHTTP, URL: server.URL, Headers: map[string]string{"Authorization": "Token secret"}})
+		err := sink.Send(context.Background(), testSummary())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "reviews.jl", summary["file_name"])
+		assert.Equal(t, StatusCompleted, summary["status"])
+		assert.Equal(t, float64(2), summary["failure_count"])
+		assert.Equal(t, 1.5, summary["duration_seconds"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"reason": "invalid review: hotelId is required", "count": float64(2)}}, summary["top_errors"])
+	})
+
+	t.Run("endpoint error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		sink := newSink(SinkConfig{Type: SinkHTTP, URL: server.URL})
+		err := sink.Send(context.Background(), testSummary())
+
+		assert.EqualError(t, err, "endpoint answered 503: unavailable")
+	})
+}
+
+// smtpServer is a minimal SMTP server that keeps the envelope and message of
+// the one email it accepts.
+type smtpServer struct {
+	listener net.Listener
+	done     chan struct{}
+	from     string
+	to       []string
+	message  string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &smtpServer{listener: listener, done: make(chan struct{})}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *smtpServer) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+	reply("220 localhost ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			s.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			s.to = append(s.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 go ahead")
+			var message strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				message.WriteString(line)
+			}
+			s.message = message.String()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func TestEmailSink_Send(t *testing.T) {
+	server := newSMTPServer(t)
+	host, port, err := net.SplitHostPort(server.listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	sink := newSink(SinkConfig{
+		Type: SinkEmail,
+		Host: host,
+		Port: portNumber,
+		From: "imports@example.com",
+		To:   []string{"data@example.com", "ops@example.com"},
+	})
+	err = sink.Send(context.Background(), testSummary())
+	require.NoError(t, err)
+	<-server.done
+
+	assert.Equal(t, "imports@example.com", server.from)
+	assert.Equal(t, []string{"data@example.com", "ops@example.com"}, server.to)
+	assert.Contains(t, server.message, "To: data@example.com, ops@example.com\r\n")
+	assert.Contains(t, server.message, "Subject: Import of reviews.jl completed\r\n")
+	assert.Contains(t, server.message, "Processed 100 lines in 1.5s: 98 imported, 2 failed (2.0%).\r\n")
+}
+
+// Summaries are sent to every sink even when one of them fails.
+func TestNotifier_SinkFailure(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	sink := &recordingSink{}
+	notifier := newNotifier(&ConfigFile{Routes: []Route{{Sinks: []string{"failing", "ops"}}}}, map[string]Sink{
+		"failing": newSink(SinkConfig{Type: SinkHTTP, URL: failing.URL}),
+		"ops":     sink,
+	}, testLogger)
+
+	notifier.Start("reviews.jl").Finish(context.Background(), nil)
+
+	assert.Equal(t, []string{StatusCompleted}, sink.statuses())
+}
@@ -0,0 +1,111 @@
+// Package notify tells people how the ingestion of a review file went. A
+// summary of each file is sent to chat, email or HTTP sinks when it has been
+// processed, or early when too many of its lines fail, and routing rules
+// decide which sinks hear about the files of which providers.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Statuses of a summary.
+const (
+	// StatusCompleted files were processed, although some lines may have
+	// failed.
+	StatusCompleted = "completed"
+	// StatusFailed files could not be read to the end, or none of their lines
+	// could be imported.
+	StatusFailed = "failed"
+	// StatusThresholdCrossed summaries are sent while a file is still being
+	// processed, once its failures cross the failure threshold.
+	StatusThresholdCrossed = "threshold_crossed"
+)
+
+// Statuses lists every summary status.
+var Statuses = []string{StatusCompleted, StatusFailed, StatusThresholdCrossed}
+
+// maxErrorReasons is how many of the most frequent error reasons a summary
+// lists.
+const maxErrorReasons = 5
+
+// ErrorReason is why lines failed, and how many did.
+type ErrorReason struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// Summary is what a notification says about a file.
+type Summary struct {
+	FileName string `json:"file_name"`
+	Status   string `json:"status"`
+	// Providers are the providers of the file's reviews, in the order they
+	// were first seen.
+	Providers    []string  `json:"providers"`
+	SuccessCount int       `json:"success_count"`
+	FailureCount int       `json:"failure_count"`
+	TotalCount   int       `json:"total_count"`
+	StartedAt    time.Time `json:"started_at"`
+	// Duration is how long the file took so far. HTTP sinks send it in
+	// seconds.
+	Duration time.Duration `json:"-"`
+	// TopErrors are the most frequent reasons lines failed, most frequent
+	// first.
+	TopErrors []ErrorReason `json:"top_errors"`
+	// Error is why a failed file could not be read to the end.
+	Error string `json:"error,omitempty"`
+}
+
+// Sink delivers summaries.
+type Sink interface {
+	Send(ctx context.Context, summary *Summary) error
+}
+
+// Subject is a one line description of a summary.
+func (s *Summary) Subject() string {
+	switch s.Status {
+	case StatusFailed:
+		return fmt.Sprintf("Import of %s failed", s.FileName)
+	case StatusThresholdCrossed:
+		return fmt.Sprintf("Import of %s crossed the failure threshold", s.FileName)
+	default:
+		return fmt.Sprintf("Import of %s completed", s.FileName)
+	}
+}
+
+// Text describes a summary in plain text, for chat messages and emails.
+func (s *Summary) Text() string {
+	var b strings.Builder
+	b.WriteString(s.Subject())
+	b.WriteString("\n\n")
+
+	verb := "Processed"
+	if s.Status == StatusThresholdCrossed {
+		verb = "So far processed"
+	}
+	fmt.Fprintf(&b, "%s %d lines in %s: %d imported, %d failed (%.1f%%).\n",
+		verb, s.TotalCount, s.Duration.Round(time.Millisecond), s.SuccessCount, s.FailureCount, s.failureRate()*100)
+	if len(s.Providers) > 0 {
+		fmt.Fprintf(&b, "Providers: %s\n", strings.Join(s.Providers, ", "))
+	}
+	if s.Error != "" {
+		fmt.Fprintf(&b, "Error: %s\n", s.Error)
+	}
+	if len(s.TopErrors) > 0 {
+		b.WriteString("Top errors:\n")
+		for _, reason := range s.TopErrors {
+			fmt.Fprintf(&b, "- %dx %s\n", reason.Count, reason.Reason)
+		}
+	}
+	return b.String()
+}
+
+// failureRate is the share of the lines that failed.
+func (s *Summary) failureRate() float64 {
+	if s.TotalCount == 0 {
+		return 0
+	}
+	return float64(s.FailureCount) / float64(s.TotalCount)
+}
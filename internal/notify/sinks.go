@@ -0,0 +1,198 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SendTimeout is how long a sink has to take a summary.
+const SendTimeout = 10 * time.Second
+
+// maxErrorBody is how much of a failed response's body is kept as the error.
+const maxErrorBody = 512
+
+// newSink builds the sink a configuration describes.
+func newSink(config SinkConfig) Sink {
+	switch config.Type {
+	case SinkSlack:
+		return &SlackSink{URL: config.URL, client: newHTTPClient()}
+	case SinkEmail:
+		port := config.Port
+		if port == 0 {
+			port = 587
+		}
+		return &EmailSink{
+			Addr:     net.JoinHostPort(config.Host, strconv.Itoa(port)),
+			Username: config.Username,
+			Password: os.Getenv(config.PasswordEnv),
+			From:     config.From,
+			To:       config.To,
+		}
+	default:
+		return &HTTPSink{URL: config.URL, Headers: config.Headers, client: newHTTPClient()}
+	}
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: SendTimeout}
+}
+
+// SlackSink posts summaries to a Slack-compatible incoming webhook, which
+// Mattermost, Rocket.Chat and others accept as well.
+type SlackSink struct {
+	URL    string
+	client *http.Client
+}
+
+// slackEscaper escapes the characters Slack reads as markup, so file names
+// and errors can not mention channels or make links.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (s *SlackSink) Send(ctx context.Context, summary *Summary) error {
+	body, err := json.Marshal(map[string]string{"text": slackEscaper.Replace(summary.Text())})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	return post(ctx, s.client, s.URL, nil, body)
+}
+
+// HTTPSink posts summaries as JSON.
+type HTTPSink struct {
+	URL     string
+	Headers map[string]string
+	client  *http.Client
+}
+
+func (s *HTTPSink) Send(ctx context.Context, summary *Summary) error {
+	body, err := json.Marshal(&httpSummary{
+		Summary:         summary,
+		DurationSeconds: summary.Duration.Seconds(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+	return post(ctx, s.client, s.URL, s.Headers, body)
+}
+
+// httpSummary is the body HTTPSink posts.
+type httpSummary struct {
+	*Summary
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// post posts a JSON body. Any status but 2xx is an error.
+func post(ctx context.Context, client *http.Client, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		return fmt.Errorf("endpoint answered %d: %s", res.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// EmailSink emails summaries through an SMTP server. The connection is
+// upgraded with STARTTLS when the server offers it.
+type EmailSink struct {
+	// Addr is the host:port of the SMTP server.
+	Addr string
+	// Username and Password log in to the server, unless Username is empty.
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (s *EmailSink) Send(ctx context.Context, summary *Summary) error {
+	deadline := time.Now().Add(SendTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	dialer := &net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	host, _, _ := net.SplitHostPort(s.Addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(nil); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.Username, s.Password, host)); err != nil {
+			return fmt.Errorf("failed to log in to SMTP server: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.From); err != nil {
+		return err
+	}
+	for _, to := range s.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.message(summary)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message returns the email of a summary.
+func (s *EmailSink) message(summary *Summary) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", summary.Subject()))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(summary.Text(), "\n", "\r\n"))
+	return b.Bytes()
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/kirananto/review-system/internal/rpc/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func hotelMessage(hotel *models.Hotel) *pb.Hotel {
+	return &pb.Hotel{
+		Id:          uint64(hotel.ID),
+		Name:        hotel.HotelName,
+		Address:     hotel.Address,
+		City:        hotel.City,
+		CountryCode: hotel.CountryCode,
+		Latitude:    hotel.Latitude,
+		Longitude:   hotel.Longitude,
+		Timezone:    hotel.Timezone,
+		CreatedAt:   timestamppb.New(hotel.CreatedAt),
+		UpdatedAt:   timestamppb.New(hotel.UpdatedAt),
+		DeletedAt:   deletedAt(hotel.DeletedAt),
+	}
+}
+
+func providerMessage(provider *models.Provider) *pb.Provider {
+	return &pb.Provider{
+		Id:        uint64(provider.ID),
+		Name:      provider.Name,
+		CreatedAt: timestamppb.New(provider.CreatedAt),
+		UpdatedAt: timestamppb.New(provider.UpdatedAt),
+		DeletedAt: deletedAt(provider.DeletedAt),
+	}
+}
+
+func reviewMessage(review *models.Review) *pb.Review {
+	message := &pb.Review{
+		Id:               uint64(review.ID),
+		ProviderId:       uint64(review.ProviderID),
+		HotelId:          uint64(review.HotelID),
+		Rating:           review.Rating,
+		Title:            review.Title,
+		Comment:          review.Comment,
+		Lang:             review.Lang,
+		ReviewDate:       timestamppb.New(review.ReviewDate),
+		ReviewerInfoJson: string(review.ReviewerInfo),
+		Status:           review.Status,
+		ModerationReason: review.ModerationReason,
+		ModeratedBy:      review.ModeratedBy,
+		ModeratedAt:      optionalTimestamp(review.ModeratedAt),
+		ModerationRules:  review.ModerationRules,
+		CreatedAt:        timestamppb.New(review.CreatedAt),
+		UpdatedAt:        timestamppb.New(review.UpdatedAt),
+		DeletedAt:        deletedAt(review.DeletedAt),
+	}
+	if response := review.Response; response != nil {
+		message.Response = &pb.ReviewResponse{
+			Id:               uint64(response.ID),
+			ReviewId:         uint64(response.ReviewID),
+			HotelId:          uint64(response.HotelID),
+			ResponderName:    response.ResponderName,
+			Body:             response.Body,
+			ResponseDate:     optionalTimestamp(response.ResponseDate),
+			ResponseDateText: response.ResponseDateText,
+			Source:           response.Source,
+			CreatedAt:        timestamppb.New(response.CreatedAt),
+			UpdatedAt:        timestamppb.New(response.UpdatedAt),
+		}
+	}
+	return message
+}
+
+// optionalTimestamp returns the timestamp of t, or nil.
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// deletedAt returns when an entity was soft-deleted, or nil.
+func deletedAt(deletedAt gorm.DeletedAt) *timestamppb.Timestamp {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return timestamppb.New(deletedAt.Time)
+}
+
+// optionalTime returns the time of a timestamp, or the zero time.
+func optionalTime(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	return timestamp.AsTime()
+}
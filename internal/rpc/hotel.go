@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/service"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/rpc/pb"
+)
+
+type hotelServer struct {
+	pb.UnimplementedHotelServiceServer
+	hotels service.HotelService
+	logger *logger.Logger
+}
+
+func (s *hotelServer) ListHotels(ctx context.Context, req *pb.ListHotelsRequest) (*pb.ListHotelsResponse, error) {
+	limit, offset, err := pageBounds(ctx, req.GetLimit(), req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIncludeDeleted(ctx, req.GetIncludeDeleted()); err != nil {
+		return nil, err
+	}
+
+	queryParams := &dto.HotelsQueryParams{
+		Limit:          limit,
+		Offset:         offset,
+		Name:           req.GetName(),
+		ProviderID:     uint(req.GetProviderId()),
+		City:           req.GetCity(),
+		Country:        req.GetCountry(),
+		RadiusKm:       req.GetRadiusKm(),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	}
+	if near := req.GetNear(); near != nil {
+		queryParams.Near = &dto.GeoPoint{Lat: near.GetLatitude(), Lng: near.GetLongitude()}
+	}
+
+	hotels, total, errorDetails := s.hotels.GetHotelsList(queryParams)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, s.logger, errorDetails)
+	}
+
+	res := &pb.ListHotelsResponse{Hotels: make([]*pb.Hotel, len(hotels)), Total: int32(total)}
+	for i, hotel := range hotels {
+		res.Hotels[i] = hotelMessage(hotel)
+	}
+	return res, nil
+}
+
+func (s *hotelServer) GetHotel(ctx context.Context, req *pb.GetHotelRequest) (*pb.GetHotelResponse, error) {
+	id, err := parseID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	hotel, errorDetails := s.hotels.GetHotelByID(id)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, s.logger, errorDetails)
+	}
+	return &pb.GetHotelResponse{Hotel: hotelMessage(hotel)}, nil
+}
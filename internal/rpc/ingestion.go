@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/rpc/pb"
+)
+
+func (s *reviewServer) SubmitIngestionJob(ctx context.Context, req *pb.SubmitIngestionJobRequest) (*pb.SubmitIngestionJobResponse, error) {
+	if !middleware.IsAdmin(ctx) {
+		return nil, newError(ctx, http.StatusForbidden, "Ingestion jobs require the admin scope")
+	}
+
+	var (
+		reader   io.ReadCloser
+		fileName string
+	)
+	switch source := req.GetSource().(type) {
+	case *pb.SubmitIngestionJobRequest_S3Object:
+		bucket, key := source.S3Object.GetBucket(), source.S3Object.GetKey()
+		if bucket == "" || key == "" {
+			return nil, validationError(ctx, "s3_object", "s3_object requires a bucket and a key")
+		}
+
+		// The object is opened up front, so a missing file fails the call
+		// rather than the job
+		object, err := s.objects.GetObject(ctx, bucket, key)
+		if err != nil {
+			var noSuchKey *types.NoSuchKey
+			if errors.As(err, &noSuchKey) {
+				return nil, newError(ctx, http.StatusNotFound, fmt.Sprintf("S3 object %s/%s not found", bucket, key))
+			}
+			return nil, serviceError(ctx, s.logger, &response.ErrorDetails{
+				Code:    http.StatusInternalServerError,
+				Message: "Internal server error",
+				Error:   fmt.Errorf("failed to get S3 object %s/%s: %w", bucket, key, err),
+			})
+		}
+		reader, fileName = object, key
+	case *pb.SubmitIngestionJobRequest_InlineFile:
+		fileName = source.InlineFile.GetFileName()
+		if fileName == "" {
+			return nil, validationError(ctx, "inline_file.file_name", "file_name is required")
+		}
+		if len(source.InlineFile.GetContent()) == 0 {
+			return nil, validationError(ctx, "inline_file.content", "content is required")
+		}
+		reader = io.NopCloser(bytes.NewReader(source.InlineFile.GetContent()))
+	default:
+		return nil, validationError(ctx, "source", "either s3_object or inline_file is required")
+	}
+
+	job, err := s.jobs.submit(ctx, reader, fileName)
+	if err != nil {
+		reader.Close()
+		if errors.Is(err, errIngestionQueueFull) {
+			return nil, newError(ctx, http.StatusTooManyRequests, "Too many ingestion jobs are queued, try again later")
+		}
+		return nil, serviceError(ctx, s.logger, &response.ErrorDetails{
+			Code:    http.StatusServiceUnavailable,
+			Message: "The server is shutting down, try again later",
+			Error:   err,
+		})
+	}
+
+	return &pb.SubmitIngestionJobResponse{JobId: job.JobId, FileName: job.FileName}, nil
+}
+
+func (s *reviewServer) GetIngestionJob(ctx context.Context, req *pb.GetIngestionJobRequest) (*pb.GetIngestionJobResponse, error) {
+	if !middleware.IsAdmin(ctx) {
+		return nil, newError(ctx, http.StatusForbidden, "Ingestion jobs require the admin scope")
+	}
+	if req.GetJobId() == "" {
+		return nil, validationError(ctx, "job_id", "job_id is required")
+	}
+
+	job, ok := s.jobs.get(req.GetJobId())
+	if !ok {
+		return nil, newError(ctx, http.StatusNotFound, fmt.Sprintf("Ingestion job %s not found", req.GetJobId()))
+	}
+	return &pb.GetIngestionJobResponse{Job: job}, nil
+}
+
+func newJobID() string {
+	b := make([]byte, 16)
+	// crypto/rand never fails on supported platforms
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys read from calls. gRPC metadata keys are lower case.
+var (
+	authorizationKey = "authorization"
+	traceIDKey       = strings.ToLower(response.TraceHeader)
+)
+
+// traceUnary gives every call a trace ID, as the Trace middleware does for
+// HTTP requests, and sends it back in the response header.
+func traceUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = withTraceID(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(traceIDKey, response.TraceID(ctx)))
+	return handler(ctx, req)
+}
+
+func traceStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := withTraceID(stream.Context())
+	_ = stream.SetHeader(metadata.Pairs(traceIDKey, response.TraceID(ctx)))
+	return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+}
+
+// withTraceID attaches the trace ID the caller sent, or a new one, to ctx.
+func withTraceID(ctx context.Context) context.Context {
+	return response.WithTraceID(ctx, middleware.ResolveTraceID(firstMetadata(ctx, traceIDKey)))
+}
+
+// logUnary logs every call with its status code and duration. The causes of
+// server errors are logged where they happen.
+func logUnary(log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		logCall(ctx, log, info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+func logStream(log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		logCall(stream.Context(), log, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logCall(ctx context.Context, log *logger.Logger, method string, start time.Time, err error) {
+	log.Info(fmt.Sprintf("gRPC %s %s in %s (trace ID %s)", method, status.Code(err), time.Since(start), response.TraceID(ctx)))
+}
+
+// authUnary checks every call for a valid API key, sent as "Bearer <key>" in
+// the authorization metadata, and attaches the principal it identifies as the
+// Auth middleware does for HTTP requests.
+func authUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func authStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+}
+
+func authenticate(ctx context.Context) (context.Context, error) {
+	authorization := firstMetadata(ctx, authorizationKey)
+	if authorization == "" {
+		return nil, status.Error(codes.Unauthenticated, "Authorization metadata is required")
+	}
+
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Invalid authorization metadata format")
+	}
+
+	principal, ok := middleware.Authenticate(token)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Invalid API key")
+	}
+	return middleware.WithPrincipal(ctx, principal), nil
+}
+
+// firstMetadata returns the first value of a metadata key of the call, or "".
+func firstMetadata(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// contextStream is a server stream whose context the interceptors replaced.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,205 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/rpc/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Limits of the ingestion jobs a server runs.
+const (
+	// IngestionWorkers is how many ingestion jobs run at once.
+	IngestionWorkers = 2
+	// MaxQueuedIngestionJobs is how many jobs can wait for a worker. Jobs
+	// submitted while the queue is full are refused.
+	MaxQueuedIngestionJobs = 32
+	// MaxFinishedIngestionJobs is how many finished jobs are remembered for
+	// GetIngestionJob. The oldest are forgotten first.
+	MaxFinishedIngestionJobs = 1000
+)
+
+// Reasons a job can not be submitted.
+var (
+	errIngestionQueueFull = errors.New("the ingestion queue is full")
+	errIngestionClosed    = errors.New("the server is shutting down")
+)
+
+// IngestionJobs runs the ingestion jobs submitted over gRPC on a fixed number
+// of workers, and keeps their state for GetIngestionJob.
+type IngestionJobs struct {
+	reviews service.ReviewService
+	logger  *logger.Logger
+	queue   chan *ingestionJob
+	workers sync.WaitGroup
+
+	mu   sync.Mutex
+	jobs map[string]*ingestionJob
+	// finished lists the IDs of the finished jobs in the order they finished.
+	finished []string
+	closed   bool
+}
+
+// ingestionJob is a submitted review file. Its state is guarded by the mutex
+// of IngestionJobs.
+type ingestionJob struct {
+	id       string
+	fileName string
+	// ctx carries the trace ID of the call that submitted the job.
+	ctx    context.Context
+	reader io.ReadCloser
+
+	state       pb.IngestionJob_State
+	err         error
+	submittedAt time.Time
+	startedAt   time.Time
+	finishedAt  time.Time
+}
+
+// NewIngestionJobs starts the workers that run the ingestion jobs with
+// reviews. Shutdown stops them.
+func NewIngestionJobs(reviews service.ReviewService, log *logger.Logger) *IngestionJobs {
+	j := &IngestionJobs{
+		reviews: reviews,
+		logger:  log,
+		queue:   make(chan *ingestionJob, MaxQueuedIngestionJobs),
+		jobs:    map[string]*ingestionJob{},
+	}
+	for range IngestionWorkers {
+		j.workers.Add(1)
+		go j.work()
+	}
+	return j
+}
+
+// submit queues a review file for ingestion. The job outlives the call that
+// submitted it, but keeps its trace ID for the logs.
+func (j *IngestionJobs) submit(ctx context.Context, reader io.ReadCloser, fileName string) (*pb.IngestionJob, error) {
+	job := &ingestionJob{
+		id:          newJobID(),
+		fileName:    fileName,
+		ctx:         context.WithoutCancel(ctx),
+		reader:      reader,
+		state:       pb.IngestionJob_STATE_QUEUED,
+		submittedAt: time.Now(),
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return nil, errIngestionClosed
+	}
+	select {
+	case j.queue <- job:
+	default:
+		return nil, errIngestionQueueFull
+	}
+	j.jobs[job.id] = job
+	return job.message(), nil
+}
+
+// get returns the state of a job, or false for unknown jobs.
+func (j *IngestionJobs) get(id string) (*pb.IngestionJob, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job, ok := j.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	return job.message(), true
+}
+
+// Shutdown stops taking jobs and waits until the queued and running ones have
+// finished, or until ctx is done.
+func (j *IngestionJobs) Shutdown(ctx context.Context) error {
+	j.mu.Lock()
+	if !j.closed {
+		j.closed = true
+		close(j.queue)
+	}
+	j.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		j.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("ingestion jobs still running: %w", ctx.Err())
+	}
+}
+
+func (j *IngestionJobs) work() {
+	defer j.workers.Done()
+	for job := range j.queue {
+		j.run(job)
+	}
+}
+
+// run ingests the review file of a job. Its outcome is recorded in the audit
+// log by ProcessReviews, and failures are logged with the job ID.
+func (j *IngestionJobs) run(job *ingestionJob) {
+	defer job.reader.Close()
+
+	j.mu.Lock()
+	job.state, job.startedAt = pb.IngestionJob_STATE_RUNNING, time.Now()
+	j.mu.Unlock()
+
+	j.logger.Info(fmt.Sprintf("Ingestion job %s started for %s (trace ID %s)", job.id, job.fileName, response.TraceID(job.ctx)))
+	err := j.reviews.ProcessReviews(job.ctx, job.reader, job.fileName)
+	if err != nil {
+		j.logger.Error(err, fmt.Sprintf("Ingestion job %s failed for %s", job.id, job.fileName))
+	} else {
+		j.logger.Info(fmt.Sprintf("Ingestion job %s finished for %s", job.id, job.fileName))
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job.state, job.err, job.finishedAt = pb.IngestionJob_STATE_SUCCEEDED, err, time.Now()
+	if err != nil {
+		job.state = pb.IngestionJob_STATE_FAILED
+	}
+	job.ctx, job.reader = nil, nil
+
+	j.finished = append(j.finished, job.id)
+	if len(j.finished) > MaxFinishedIngestionJobs {
+		delete(j.jobs, j.finished[0])
+		j.finished = j.finished[1:]
+	}
+}
+
+// message returns the state of the job as a message. The mutex of
+// IngestionJobs must be held.
+func (job *ingestionJob) message() *pb.IngestionJob {
+	message := &pb.IngestionJob{
+		JobId:       job.id,
+		FileName:    job.fileName,
+		State:       job.state,
+		SubmittedAt: timestamppb.New(job.submittedAt),
+		StartedAt:   optionalTimestamp(nonZeroTime(job.startedAt)),
+		FinishedAt:  optionalTimestamp(nonZeroTime(job.finishedAt)),
+	}
+	if job.err != nil {
+		message.Error = job.err.Error()
+	}
+	return message
+}
+
+// nonZeroTime returns t, or nil when it is zero.
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
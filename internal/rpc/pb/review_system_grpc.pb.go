@@ -0,0 +1,601 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: reviewsystem/v1/review_system.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HotelService_ListHotels_FullMethodName = "/reviewsystem.v1.HotelService/ListHotels"
+	HotelService_GetHotel_FullMethodName   = "/reviewsystem.v1.HotelService/GetHotel"
+)
+
+// HotelServiceClient is the client API for HotelService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HotelService reads hotels.
+type HotelServiceClient interface {
+	// ListHotels returns a page of hotels matching the request's filters.
+	ListHotels(ctx context.Context, in *ListHotelsRequest, opts ...grpc.CallOption) (*ListHotelsResponse, error)
+	// GetHotel returns one hotel. It fails with NOT_FOUND for unknown IDs.
+	GetHotel(ctx context.Context, in *GetHotelRequest, opts ...grpc.CallOption) (*GetHotelResponse, error)
+}
+
+type hotelServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHotelServiceClient(cc grpc.ClientConnInterface) HotelServiceClient {
+	return &hotelServiceClient{cc}
+}
+
+func (c *hotelServiceClient) ListHotels(ctx context.Context, in *ListHotelsRequest, opts ...grpc.CallOption) (*ListHotelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHotelsResponse)
+	err := c.cc.Invoke(ctx, HotelService_ListHotels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hotelServiceClient) GetHotel(ctx context.Context, in *GetHotelRequest, opts ...grpc.CallOption) (*GetHotelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHotelResponse)
+	err := c.cc.Invoke(ctx, HotelService_GetHotel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HotelServiceServer is the server API for HotelService service.
+// All implementations must embed UnimplementedHotelServiceServer
+// for forward compatibility.
+//
+// HotelService reads hotels.
+type HotelServiceServer interface {
+	// ListHotels returns a page of hotels matching the request's filters.
+	ListHotels(context.Context, *ListHotelsRequest) (*ListHotelsResponse, error)
+	// GetHotel returns one hotel. It fails with NOT_FOUND for unknown IDs.
+	GetHotel(context.Context, *GetHotelRequest) (*GetHotelResponse, error)
+	mustEmbedUnimplementedHotelServiceServer()
+}
+
+// UnimplementedHotelServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHotelServiceServer struct{}
+
+func (UnimplementedHotelServiceServer) ListHotels(context.Context, *ListHotelsRequest) (*ListHotelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHotels not implemented")
+}
+func (UnimplementedHotelServiceServer) GetHotel(context.Context, *GetHotelRequest) (*GetHotelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHotel not implemented")
+}
+func (UnimplementedHotelServiceServer) mustEmbedUnimplementedHotelServiceServer() {}
+func (UnimplementedHotelServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeHotelServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HotelServiceServer will
+// result in compilation errors.
+type UnsafeHotelServiceServer interface {
+	mustEmbedUnimplementedHotelServiceServer()
+}
+
+func RegisterHotelServiceServer(s grpc.ServiceRegistrar, srv HotelServiceServer) {
+	// If the following call pancis, it indicates UnimplementedHotelServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HotelService_ServiceDesc, srv)
+}
+
+func _HotelService_ListHotels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHotelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HotelServiceServer).ListHotels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HotelService_ListHotels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HotelServiceServer).ListHotels(ctx, req.(*ListHotelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HotelService_GetHotel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHotelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HotelServiceServer).GetHotel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HotelService_GetHotel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HotelServiceServer).GetHotel(ctx, req.(*GetHotelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HotelService_ServiceDesc is the grpc.ServiceDesc for HotelService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HotelService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reviewsystem.v1.HotelService",
+	HandlerType: (*HotelServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListHotels",
+			Handler:    _HotelService_ListHotels_Handler,
+		},
+		{
+			MethodName: "GetHotel",
+			Handler:    _HotelService_GetHotel_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reviewsystem/v1/review_system.proto",
+}
+
+const (
+	ProviderService_ListProviders_FullMethodName = "/reviewsystem.v1.ProviderService/ListProviders"
+	ProviderService_GetProvider_FullMethodName   = "/reviewsystem.v1.ProviderService/GetProvider"
+)
+
+// ProviderServiceClient is the client API for ProviderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ProviderService reads review providers.
+type ProviderServiceClient interface {
+	// ListProviders returns a page of providers matching the request's filters.
+	ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error)
+	// GetProvider returns one provider. It fails with NOT_FOUND for unknown IDs.
+	GetProvider(ctx context.Context, in *GetProviderRequest, opts ...grpc.CallOption) (*GetProviderResponse, error)
+}
+
+type providerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProviderServiceClient(cc grpc.ClientConnInterface) ProviderServiceClient {
+	return &providerServiceClient{cc}
+}
+
+func (c *providerServiceClient) ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProvidersResponse)
+	err := c.cc.Invoke(ctx, ProviderService_ListProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerServiceClient) GetProvider(ctx context.Context, in *GetProviderRequest, opts ...grpc.CallOption) (*GetProviderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProviderResponse)
+	err := c.cc.Invoke(ctx, ProviderService_GetProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProviderServiceServer is the server API for ProviderService service.
+// All implementations must embed UnimplementedProviderServiceServer
+// for forward compatibility.
+//
+// ProviderService reads review providers.
+type ProviderServiceServer interface {
+	// ListProviders returns a page of providers matching the request's filters.
+	ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error)
+	// GetProvider returns one provider. It fails with NOT_FOUND for unknown IDs.
+	GetProvider(context.Context, *GetProviderRequest) (*GetProviderResponse, error)
+	mustEmbedUnimplementedProviderServiceServer()
+}
+
+// UnimplementedProviderServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProviderServiceServer struct{}
+
+func (UnimplementedProviderServiceServer) ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProviders not implemented")
+}
+func (UnimplementedProviderServiceServer) GetProvider(context.Context, *GetProviderRequest) (*GetProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProvider not implemented")
+}
+func (UnimplementedProviderServiceServer) mustEmbedUnimplementedProviderServiceServer() {}
+func (UnimplementedProviderServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeProviderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProviderServiceServer will
+// result in compilation errors.
+type UnsafeProviderServiceServer interface {
+	mustEmbedUnimplementedProviderServiceServer()
+}
+
+func RegisterProviderServiceServer(s grpc.ServiceRegistrar, srv ProviderServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProviderServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProviderService_ServiceDesc, srv)
+}
+
+func _ProviderService_ListProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).ListProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_ListProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).ListProviders(ctx, req.(*ListProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProviderService_GetProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).GetProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_GetProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).GetProvider(ctx, req.(*GetProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProviderService_ServiceDesc is the grpc.ServiceDesc for ProviderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProviderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reviewsystem.v1.ProviderService",
+	HandlerType: (*ProviderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListProviders",
+			Handler:    _ProviderService_ListProviders_Handler,
+		},
+		{
+			MethodName: "GetProvider",
+			Handler:    _ProviderService_GetProvider_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reviewsystem/v1/review_system.proto",
+}
+
+const (
+	ReviewService_ListReviews_FullMethodName        = "/reviewsystem.v1.ReviewService/ListReviews"
+	ReviewService_GetReview_FullMethodName          = "/reviewsystem.v1.ReviewService/GetReview"
+	ReviewService_StreamReviews_FullMethodName      = "/reviewsystem.v1.ReviewService/StreamReviews"
+	ReviewService_SubmitIngestionJob_FullMethodName = "/reviewsystem.v1.ReviewService/SubmitIngestionJob"
+	ReviewService_GetIngestionJob_FullMethodName    = "/reviewsystem.v1.ReviewService/GetIngestionJob"
+)
+
+// ReviewServiceClient is the client API for ReviewService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReviewService reads reviews and submits review files for ingestion.
+type ReviewServiceClient interface {
+	// ListReviews returns a page of reviews matching the request's filter.
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error)
+	// GetReview returns one review with its response. It fails with NOT_FOUND
+	// for unknown IDs, and for unpublished reviews without the admin scope.
+	GetReview(ctx context.Context, in *GetReviewRequest, opts ...grpc.CallOption) (*GetReviewResponse, error)
+	// StreamReviews streams every review matching the request's filter, in the
+	// filter's sort order, without paging.
+	StreamReviews(ctx context.Context, in *StreamReviewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamReviewsResponse], error)
+	// SubmitIngestionJob queues a review file for ingestion and returns at once.
+	// The outcome is recorded in the audit log under the job's file name. It
+	// fails with RESOURCE_EXHAUSTED while the queue is full, and with
+	// UNAVAILABLE while the server shuts down. Requires the admin scope.
+	SubmitIngestionJob(ctx context.Context, in *SubmitIngestionJobRequest, opts ...grpc.CallOption) (*SubmitIngestionJobResponse, error)
+	// GetIngestionJob returns the state of a job submitted to this server. Jobs
+	// are kept in memory, so it fails with NOT_FOUND for jobs of another server
+	// or from before a restart. Requires the admin scope.
+	GetIngestionJob(ctx context.Context, in *GetIngestionJobRequest, opts ...grpc.CallOption) (*GetIngestionJobResponse, error)
+}
+
+type reviewServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReviewServiceClient(cc grpc.ClientConnInterface) ReviewServiceClient {
+	return &reviewServiceClient{cc}
+}
+
+func (c *reviewServiceClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewsResponse)
+	err := c.cc.Invoke(ctx, ReviewService_ListReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewServiceClient) GetReview(ctx context.Context, in *GetReviewRequest, opts ...grpc.CallOption) (*GetReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReviewResponse)
+	err := c.cc.Invoke(ctx, ReviewService_GetReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewServiceClient) StreamReviews(ctx context.Context, in *StreamReviewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamReviewsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReviewService_ServiceDesc.Streams[0], ReviewService_StreamReviews_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamReviewsRequest, StreamReviewsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReviewService_StreamReviewsClient = grpc.ServerStreamingClient[StreamReviewsResponse]
+
+func (c *reviewServiceClient) SubmitIngestionJob(ctx context.Context, in *SubmitIngestionJobRequest, opts ...grpc.CallOption) (*SubmitIngestionJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitIngestionJobResponse)
+	err := c.cc.Invoke(ctx, ReviewService_SubmitIngestionJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewServiceClient) GetIngestionJob(ctx context.Context, in *GetIngestionJobRequest, opts ...grpc.CallOption) (*GetIngestionJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetIngestionJobResponse)
+	err := c.cc.Invoke(ctx, ReviewService_GetIngestionJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReviewServiceServer is the server API for ReviewService service.
+// All implementations must embed UnimplementedReviewServiceServer
+// for forward compatibility.
+//
+// ReviewService reads reviews and submits review files for ingestion.
+type ReviewServiceServer interface {
+	// ListReviews returns a page of reviews matching the request's filter.
+	ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error)
+	// GetReview returns one review with its response. It fails with NOT_FOUND
+	// for unknown IDs, and for unpublished reviews without the admin scope.
+	GetReview(context.Context, *GetReviewRequest) (*GetReviewResponse, error)
+	// StreamReviews streams every review matching the request's filter, in the
+	// filter's sort order, without paging.
+	StreamReviews(*StreamReviewsRequest, grpc.ServerStreamingServer[StreamReviewsResponse]) error
+	// SubmitIngestionJob queues a review file for ingestion and returns at once.
+	// The outcome is recorded in the audit log under the job's file name. It
+	// fails with RESOURCE_EXHAUSTED while the queue is full, and with
+	// UNAVAILABLE while the server shuts down. Requires the admin scope.
+	SubmitIngestionJob(context.Context, *SubmitIngestionJobRequest) (*SubmitIngestionJobResponse, error)
+	// GetIngestionJob returns the state of a job submitted to this server. Jobs
+	// are kept in memory, so it fails with NOT_FOUND for jobs of another server
+	// or from before a restart. Requires the admin scope.
+	GetIngestionJob(context.Context, *GetIngestionJobRequest) (*GetIngestionJobResponse, error)
+	mustEmbedUnimplementedReviewServiceServer()
+}
+
+// UnimplementedReviewServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReviewServiceServer struct{}
+
+func (UnimplementedReviewServiceServer) ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviews not implemented")
+}
+func (UnimplementedReviewServiceServer) GetReview(context.Context, *GetReviewRequest) (*GetReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReview not implemented")
+}
+func (UnimplementedReviewServiceServer) StreamReviews(*StreamReviewsRequest, grpc.ServerStreamingServer[StreamReviewsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamReviews not implemented")
+}
+func (UnimplementedReviewServiceServer) SubmitIngestionJob(context.Context, *SubmitIngestionJobRequest) (*SubmitIngestionJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitIngestionJob not implemented")
+}
+func (UnimplementedReviewServiceServer) GetIngestionJob(context.Context, *GetIngestionJobRequest) (*GetIngestionJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIngestionJob not implemented")
+}
+func (UnimplementedReviewServiceServer) mustEmbedUnimplementedReviewServiceServer() {}
+func (UnimplementedReviewServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeReviewServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReviewServiceServer will
+// result in compilation errors.
+type UnsafeReviewServiceServer interface {
+	mustEmbedUnimplementedReviewServiceServer()
+}
+
+func RegisterReviewServiceServer(s grpc.ServiceRegistrar, srv ReviewServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReviewServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReviewService_ServiceDesc, srv)
+}
+
+func _ReviewService_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewServiceServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewService_ListReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewServiceServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReviewService_GetReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewServiceServer).GetReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewService_GetReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewServiceServer).GetReview(ctx, req.(*GetReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReviewService_StreamReviews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamReviewsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReviewServiceServer).StreamReviews(m, &grpc.GenericServerStream[StreamReviewsRequest, StreamReviewsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReviewService_StreamReviewsServer = grpc.ServerStreamingServer[StreamReviewsResponse]
+
+func _ReviewService_SubmitIngestionJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitIngestionJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewServiceServer).SubmitIngestionJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewService_SubmitIngestionJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewServiceServer).SubmitIngestionJob(ctx, req.(*SubmitIngestionJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReviewService_GetIngestionJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIngestionJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewServiceServer).GetIngestionJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReviewService_GetIngestionJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewServiceServer).GetIngestionJob(ctx, req.(*GetIngestionJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReviewService_ServiceDesc is the grpc.ServiceDesc for ReviewService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReviewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reviewsystem.v1.ReviewService",
+	HandlerType: (*ReviewServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListReviews",
+			Handler:    _ReviewService_ListReviews_Handler,
+		},
+		{
+			MethodName: "GetReview",
+			Handler:    _ReviewService_GetReview_Handler,
+		},
+		{
+			MethodName: "SubmitIngestionJob",
+			Handler:    _ReviewService_SubmitIngestionJob_Handler,
+		},
+		{
+			MethodName: "GetIngestionJob",
+			Handler:    _ReviewService_GetIngestionJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamReviews",
+			Handler:       _ReviewService_StreamReviews_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "reviewsystem/v1/review_system.proto",
+}
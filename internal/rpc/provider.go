@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/service"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/rpc/pb"
+)
+
+type providerServer struct {
+	pb.UnimplementedProviderServiceServer
+	providers service.ProviderService
+	logger    *logger.Logger
+}
+
+func (s *providerServer) ListProviders(ctx context.Context, req *pb.ListProvidersRequest) (*pb.ListProvidersResponse, error) {
+	limit, offset, err := pageBounds(ctx, req.GetLimit(), req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIncludeDeleted(ctx, req.GetIncludeDeleted()); err != nil {
+		return nil, err
+	}
+
+	providers, total, errorDetails := s.providers.GetProvidersList(&dto.ProvidersQueryParams{
+		Limit:          limit,
+		Offset:         offset,
+		Name:           req.GetName(),
+		HotelID:        uint(req.GetHotelId()),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	})
+	if errorDetails != nil {
+		return nil, serviceError(ctx, s.logger, errorDetails)
+	}
+
+	res := &pb.ListProvidersResponse{Providers: make([]*pb.Provider, len(providers)), Total: int32(total)}
+	for i, provider := range providers {
+		res.Providers[i] = providerMessage(provider)
+	}
+	return res, nil
+}
+
+func (s *providerServer) GetProvider(ctx context.Context, req *pb.GetProviderRequest) (*pb.GetProviderResponse, error) {
+	id, err := parseID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	provider, errorDetails := s.providers.GetProviderByID(id)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, s.logger, errorDetails)
+	}
+	return &pb.GetProviderResponse{Provider: providerMessage(provider)}, nil
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/service"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/kirananto/review-system/internal/rpc/pb"
+	"github.com/kirananto/review-system/internal/s3"
+)
+
+type reviewServer struct {
+	pb.UnimplementedReviewServiceServer
+	reviews service.ReviewService
+	objects s3.S3Service
+	jobs    *IngestionJobs
+	logger  *logger.Logger
+}
+
+func (s *reviewServer) ListReviews(ctx context.Context, req *pb.ListReviewsRequest) (*pb.ListReviewsResponse, error) {
+	limit, offset, err := pageBounds(ctx, req.GetLimit(), req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := reviewQueryParams(ctx, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	queryParams.Limit, queryParams.Offset = limit, offset
+	// Responses are preloaded, as a review has at most one
+	queryParams.Include = dto.RelationResponse
+
+	reviews, total, errorDetails := s.reviews.GetReviewsList(queryParams)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, s.logger, errorDetails)
+	}
+
+	res := &pb.ListReviewsResponse{Reviews: make([]*pb.Review, len(reviews)), Total: int32(total)}
+	for i, review := range reviews {
+		res.Reviews[i] = reviewMessage(review)
+	}
+	return res, nil
+}
+
+func (s *reviewServer) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	id, err := parseID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	review, errorDetails := s.reviews.GetReviewByID(id, dto.RelationResponse)
+	if errorDetails != nil {
+		return nil, serviceError(ctx, s.logger, errorDetails)
+	}
+
+	// Unpublished reviews do not exist as far as the public is concerned
+	if review.Status != models.ReviewStatusPublished && !middleware.IsAdmin(ctx) {
+		return nil, newError(ctx, http.StatusNotFound, "Review not found")
+	}
+	return &pb.GetReviewResponse{Review: reviewMessage(review)}, nil
+}
+
+func (s *reviewServer) StreamReviews(req *pb.StreamReviewsRequest, stream pb.ReviewService_StreamReviewsServer) error {
+	ctx := stream.Context()
+	queryParams, err := reviewQueryParams(ctx, req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	// Reviews are sent as they are read, the same way the REST export writes
+	// them; the format only names that export's encoding.
+	exportParams := &dto.ReviewExportQueryParams{ReviewQueryParams: *queryParams, Format: dto.ExportFormatNDJSON}
+	errorDetails := s.reviews.ExportReviews(ctx, exportParams, func(review *models.Review) error {
+		return stream.Send(&pb.StreamReviewsResponse{Review: reviewMessage(review)})
+	})
+	if errorDetails != nil {
+		// The client went away mid-stream, so there is nobody to tell
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return serviceError(ctx, s.logger, errorDetails)
+	}
+	return nil
+}
+
+// reviewQueryParams returns the query of a review filter, refusing to list
+// unpublished or deleted reviews to callers without the admin scope.
+func reviewQueryParams(ctx context.Context, filter *pb.ReviewFilter) (*dto.ReviewQueryParams, error) {
+	if filter == nil {
+		filter = &pb.ReviewFilter{}
+	}
+	queryParams := &dto.ReviewQueryParams{
+		HotelID:        uint(filter.GetHotelId()),
+		ProviderID:     uint(filter.GetProviderId()),
+		MinRating:      filter.MinRating,
+		MaxRating:      filter.MaxRating,
+		ReviewDateFrom: optionalTime(filter.GetReviewDateFrom()),
+		ReviewDateTo:   dto.RangeEnd{Time: optionalTime(filter.GetReviewDateTo())},
+		Lang:           filter.GetLang(),
+		TravelerType:   filter.GetTravelerType(),
+		Country:        filter.GetCountry(),
+		HasComment:     filter.HasComment,
+		Q:              filter.GetQ(),
+		Status:         strings.Join(filter.GetStatus(), ","),
+		Sort:           filter.GetSort(),
+		IncludeDeleted: filter.GetIncludeDeleted(),
+	}
+
+	if !middleware.IsAdmin(ctx) {
+		for _, status := range queryParams.StatusList() {
+			if status != models.ReviewStatusPublished {
+				return nil, newError(ctx, http.StatusForbidden, "Only published reviews are visible without the admin scope")
+			}
+		}
+	}
+	if err := checkIncludeDeleted(ctx, queryParams.IncludeDeleted); err != nil {
+		return nil, err
+	}
+	return queryParams, nil
+}
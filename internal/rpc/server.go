@@ -0,0 +1,80 @@
+// Package rpc serves the hotel, provider and review services over gRPC, for
+// other backend services. It reads through the same services as the REST API
+// and authenticates with the same API keys.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/service"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/rpc/pb"
+	"github.com/kirananto/review-system/internal/s3"
+	"google.golang.org/grpc"
+)
+
+// Page sizes of the list calls.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Services are the services the gRPC services are implemented on.
+type Services struct {
+	Hotels    service.HotelService
+	Providers service.ProviderService
+	Reviews   service.ReviewService
+	// Objects fetches the review files of ingestion jobs submitted as S3
+	// objects.
+	Objects s3.S3Service
+	// IngestionJobs runs the submitted ingestion jobs.
+	IngestionJobs *IngestionJobs
+}
+
+// NewServer returns a gRPC server with the hotel, provider and review services
+// registered. Every call is traced, logged and authenticated.
+func NewServer(services Services, log *logger.Logger) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(traceUnary, logUnary(log), authUnary),
+		grpc.ChainStreamInterceptor(traceStream, logStream(log), authStream),
+	)
+	pb.RegisterHotelServiceServer(server, &hotelServer{hotels: services.Hotels, logger: log})
+	pb.RegisterProviderServiceServer(server, &providerServer{providers: services.Providers, logger: log})
+	pb.RegisterReviewServiceServer(server, &reviewServer{reviews: services.Reviews, objects: services.Objects, jobs: services.IngestionJobs, logger: log})
+	return server
+}
+
+// pageBounds returns the limit and offset of a list call, defaulting the
+// limit to DefaultPageSize.
+func pageBounds(ctx context.Context, limit, offset int32) (int, int, error) {
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	if limit < 1 || limit > MaxPageSize {
+		return 0, 0, validationError(ctx, "limit", fmt.Sprintf("limit must be between 1 and %d", MaxPageSize))
+	}
+	if offset < 0 {
+		return 0, 0, validationError(ctx, "offset", "offset can not be negative")
+	}
+	return int(limit), int(offset), nil
+}
+
+// checkIncludeDeleted refuses to list soft-deleted entities to callers without
+// the admin scope, as the REST API does.
+func checkIncludeDeleted(ctx context.Context, includeDeleted bool) error {
+	if !includeDeleted || middleware.IsAdmin(ctx) {
+		return nil
+	}
+	return newError(ctx, http.StatusForbidden, "Deleted entities are only visible with the admin scope")
+}
+
+// parseID returns the ID of a get call, which must be set.
+func parseID(ctx context.Context, id uint64) (uint, error) {
+	if id == 0 {
+		return 0, validationError(ctx, "id", "id must be a positive integer")
+	}
+	return uint(id), nil
+}
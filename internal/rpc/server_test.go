@@ -0,0 +1,655 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kirananto/review-system/internal/api/dto"
+	"github.com/kirananto/review-system/internal/api/middleware"
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/api/service/mock"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/kirananto/review-system/internal/rpc"
+	"github.com/kirananto/review-system/internal/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type mockServices struct {
+	hotels    *mock.MockHotelService
+	providers *mock.MockProviderService
+	reviews   *mock.MockReviewService
+	objects   *mockS3Service
+	jobs      *rpc.IngestionJobs
+}
+
+// mockS3Service serves the objects of ingestion jobs from memory.
+type mockS3Service struct {
+	objects map[string]string
+}
+
+func (m *mockS3Service) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	content, ok := m.objects[bucket+"/"+key]
+	if !ok {
+		return nil, errors.New("access denied")
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+// testLogger is shared by the tests, as NewLogger configures zerolog globally
+// while ingestion jobs of earlier tests may still be logging.
+var testLogger = logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+
+// newTestConn serves the gRPC API over an in-memory listener and returns a
+// client connection to it.
+func newTestConn(t *testing.T, ctrl *gomock.Controller) (*grpc.ClientConn, *mockServices) {
+	mocks := &mockServices{
+		hotels:    mock.NewMockHotelService(ctrl),
+		providers: mock.NewMockProviderService(ctrl),
+		reviews:   mock.NewMockReviewService(ctrl),
+		objects:   &mockS3Service{objects: map[string]string{}},
+	}
+	mocks.jobs = rpc.NewIngestionJobs(mocks.reviews, testLogger)
+	server := rpc.NewServer(rpc.Services{
+		Hotels:        mocks.hotels,
+		Providers:     mocks.providers,
+		Reviews:       mocks.reviews,
+		Objects:       mocks.objects,
+		IngestionJobs: mocks.jobs,
+	}, testLogger)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() {
+		server.Stop()
+		_ = mocks.jobs.Shutdown(context.Background())
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, mocks
+}
+
+// testAPIKeys are the API keys the tests call with.
+var testAPIKeys = []middleware.APIKey{
+	{Key: "secret", Name: "client"},
+	{Key: "admin-secret", Name: "admin", Scopes: []string{middleware.ScopeAdmin}},
+	{Key: "hotel-secret", Name: "hotel", Scopes: []string{middleware.ScopeRespond}, HotelID: 1},
+}
+
+func TestMain(m *testing.M) {
+	middleware.SetAPIKeys(testAPIKeys)
+	os.Exit(m.Run())
+}
+
+// withAPIKey returns a context that calls as apiKey.
+func withAPIKey(apiKey string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+apiKey)
+}
+
+func TestAuth(t *testing.T) {
+	t.Run("missing_api_key", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewHotelServiceClient(conn).GetHotel(context.Background(), &pb.GetHotelRequest{Id: 1})
+
+		// Assert
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("invalid_api_key", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewHotelServiceClient(conn).GetHotel(withAPIKey("wrong"), &pb.GetHotelRequest{Id: 1})
+
+		// Assert
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, "Invalid API key", status.Convert(err).Message())
+	})
+
+	t.Run("streams_require_api_key", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		stream, err := pb.NewReviewServiceClient(conn).StreamReviews(context.Background(), &pb.StreamReviewsRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+
+		// Assert
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestTraceID(t *testing.T) {
+	t.Run("echoes_the_callers_trace_id", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		mocks.hotels.EXPECT().GetHotelByID(uint(1)).Return(&models.Hotel{ID: 1}, nil)
+		ctx := metadata.AppendToOutgoingContext(withAPIKey("secret"), "x-request-id", "trace-123")
+
+		// Act
+		var header metadata.MD
+		_, err := pb.NewHotelServiceClient(conn).GetHotel(ctx, &pb.GetHotelRequest{Id: 1}, grpc.Header(&header))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"trace-123"}, header.Get("x-request-id"))
+	})
+
+	t.Run("errors_carry_the_trace_id", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		mocks.hotels.EXPECT().GetHotelByID(uint(7)).Return(nil, &response.ErrorDetails{Code: http.StatusNotFound, Message: "Hotel not found"})
+		ctx := metadata.AppendToOutgoingContext(withAPIKey("secret"), "x-request-id", "trace-456")
+
+		// Act
+		_, err := pb.NewHotelServiceClient(conn).GetHotel(ctx, &pb.GetHotelRequest{Id: 7})
+
+		// Assert
+		st := status.Convert(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "Hotel not found", st.Message())
+		require.Len(t, st.Details(), 1)
+		info := st.Details()[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, response.CodeNotFound, info.Reason)
+		assert.Equal(t, "trace-456", info.Metadata["trace_id"])
+	})
+}
+
+func TestHotelService(t *testing.T) {
+	t.Run("list_hotels", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		lat, lng := 10.77, 106.7
+		mocks.hotels.EXPECT().GetHotelsList(&dto.HotelsQueryParams{
+			Limit:    20,
+			Offset:   40,
+			City:     "Hanoi",
+			Near:     &dto.GeoPoint{Lat: 10.77, Lng: 106.7},
+			RadiusKm: 5,
+		}).Return([]*models.Hotel{{ID: 1, HotelName: "Hotel A", HotelLocation: models.HotelLocation{City: "Hanoi", Latitude: &lat, Longitude: &lng}}}, 41, nil)
+
+		// Act
+		res, err := pb.NewHotelServiceClient(conn).ListHotels(withAPIKey("secret"), &pb.ListHotelsRequest{
+			Offset:   40,
+			City:     "Hanoi",
+			Near:     &pb.GeoPoint{Latitude: 10.77, Longitude: 106.7},
+			RadiusKm: 5,
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int32(41), res.Total)
+		require.Len(t, res.Hotels, 1)
+		assert.Equal(t, "Hotel A", res.Hotels[0].Name)
+		assert.Equal(t, 10.77, res.Hotels[0].GetLatitude())
+		assert.Nil(t, res.Hotels[0].DeletedAt)
+	})
+
+	t.Run("list_hotels_rejects_large_pages", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewHotelServiceClient(conn).ListHotels(withAPIKey("secret"), &pb.ListHotelsRequest{Limit: 101})
+
+		// Assert
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		require.Len(t, st.Details(), 2)
+		badRequest := st.Details()[1].(*errdetails.BadRequest)
+		assert.Equal(t, "limit", badRequest.FieldViolations[0].Field)
+	})
+
+	t.Run("deleted_hotels_require_admin", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewHotelServiceClient(conn).ListHotels(withAPIKey("secret"), &pb.ListHotelsRequest{IncludeDeleted: true})
+
+		// Assert
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("server_errors_hide_the_cause", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		mocks.hotels.EXPECT().GetHotelByID(uint(1)).Return(nil, &response.ErrorDetails{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error:   errors.New("connection refused"),
+		})
+
+		// Act
+		_, err := pb.NewHotelServiceClient(conn).GetHotel(withAPIKey("secret"), &pb.GetHotelRequest{Id: 1})
+
+		// Assert
+		st := status.Convert(err)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, "Internal server error", st.Message())
+	})
+}
+
+func TestProviderService(t *testing.T) {
+	t.Run("list_providers", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		mocks.providers.EXPECT().GetProvidersList(&dto.ProvidersQueryParams{Limit: 10, HotelID: 3}).
+			Return([]*models.Provider{{ID: 2, Name: "Agoda"}}, 1, nil)
+
+		// Act
+		res, err := pb.NewProviderServiceClient(conn).ListProviders(withAPIKey("secret"), &pb.ListProvidersRequest{Limit: 10, HotelId: 3})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), res.Total)
+		assert.Equal(t, "Agoda", res.Providers[0].Name)
+	})
+
+	t.Run("get_provider_requires_id", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewProviderServiceClient(conn).GetProvider(withAPIKey("secret"), &pb.GetProviderRequest{})
+
+		// Assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestReviewService(t *testing.T) {
+	reviewDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("list_reviews", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		minRating := 7.5
+		mocks.reviews.EXPECT().GetReviewsList(&dto.ReviewQueryParams{
+			Limit:           20,
+			HotelID:         1,
+			MinRating:       &minRating,
+			ReviewDateFrom:  reviewDate,
+			Sort:            "-rating",
+			ResponseOptions: dto.ResponseOptions{Include: dto.RelationResponse},
+		}).Return([]*models.Review{{
+			ID:         5,
+			HotelID:    1,
+			Rating:     8,
+			ReviewDate: reviewDate,
+			Status:     models.ReviewStatusPublished,
+			Response:   &models.ReviewResponse{ID: 9, ReviewID: 5, Body: "Thank you"},
+		}}, 1, nil)
+
+		// Act
+		res, err := pb.NewReviewServiceClient(conn).ListReviews(withAPIKey("secret"), &pb.ListReviewsRequest{
+			Filter: &pb.ReviewFilter{
+				HotelId:        1,
+				MinRating:      &minRating,
+				ReviewDateFrom: timestamppb.New(reviewDate),
+				Sort:           "-rating",
+			},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, res.Reviews, 1)
+		assert.Equal(t, uint64(5), res.Reviews[0].Id)
+		assert.Equal(t, reviewDate, res.Reviews[0].ReviewDate.AsTime())
+		assert.Equal(t, "Thank you", res.Reviews[0].Response.Body)
+	})
+
+	t.Run("unpublished_statuses_require_admin", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewReviewServiceClient(conn).ListReviews(withAPIKey("secret"), &pb.ListReviewsRequest{
+			Filter: &pb.ReviewFilter{Status: []string{models.ReviewStatusPending}},
+		})
+
+		// Assert
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("unpublished_review_is_not_found_without_admin", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		mocks.reviews.EXPECT().GetReviewByID(uint(5), dto.RelationResponse).
+			Return(&models.Review{ID: 5, Status: models.ReviewStatusPending}, nil).Times(2)
+		client := pb.NewReviewServiceClient(conn)
+
+		// Act
+		_, clientErr := client.GetReview(withAPIKey("secret"), &pb.GetReviewRequest{Id: 5})
+		res, adminErr := client.GetReview(withAPIKey("admin-secret"), &pb.GetReviewRequest{Id: 5})
+
+		// Assert
+		assert.Equal(t, codes.NotFound, status.Code(clientErr))
+		require.NoError(t, adminErr)
+		assert.Equal(t, models.ReviewStatusPending, res.Review.Status)
+	})
+
+	t.Run("stream_reviews", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		mocks.reviews.EXPECT().ExportReviews(gomock.Any(), &dto.ReviewExportQueryParams{
+			ReviewQueryParams: dto.ReviewQueryParams{ProviderID: 2},
+			Format:            dto.ExportFormatNDJSON,
+		}, gomock.Any()).DoAndReturn(func(_ context.Context, _ *dto.ReviewExportQueryParams, write func(*models.Review) error) *response.ErrorDetails {
+			for _, id := range []uint{1, 2, 3} {
+				if err := write(&models.Review{ID: id, ProviderID: 2}); err != nil {
+					return &response.ErrorDetails{Code: http.StatusInternalServerError, Message: "Internal server error", Error: err}
+				}
+			}
+			return nil
+		})
+
+		// Act
+		stream, err := pb.NewReviewServiceClient(conn).StreamReviews(withAPIKey("secret"), &pb.StreamReviewsRequest{
+			Filter: &pb.ReviewFilter{ProviderId: 2},
+		})
+		require.NoError(t, err)
+		var ids []uint64
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			ids = append(ids, res.Review.Id)
+		}
+
+		// Assert
+		assert.Equal(t, []uint64{1, 2, 3}, ids)
+	})
+
+	t.Run("stream_reviews_validation_error", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		mocks.reviews.EXPECT().ExportReviews(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(response.ValidationErrorDetails("lang", "lang must be an ISO 639-1 language code", nil))
+
+		// Act
+		stream, err := pb.NewReviewServiceClient(conn).StreamReviews(withAPIKey("secret"), &pb.StreamReviewsRequest{
+			Filter: &pb.ReviewFilter{Lang: "english"},
+		})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+
+		// Assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubmitIngestionJob(t *testing.T) {
+	t.Run("requires_admin", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewReviewServiceClient(conn).SubmitIngestionJob(withAPIKey("secret"), &pb.SubmitIngestionJobRequest{
+			Source: &pb.SubmitIngestionJobRequest_InlineFile{InlineFile: &pb.InlineFile{FileName: "reviews.jl", Content: []byte("{}")}},
+		})
+
+		// Assert
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("requires_a_source", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewReviewServiceClient(conn).SubmitIngestionJob(withAPIKey("admin-secret"), &pb.SubmitIngestionJobRequest{})
+
+		// Assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("inline_file", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		processed := make(chan string, 1)
+		mocks.reviews.EXPECT().ProcessReviews(gomock.Any(), gomock.Any(), "reviews.jl").
+			DoAndReturn(func(_ context.Context, reader io.Reader, _ string) error {
+				content, _ := io.ReadAll(reader)
+				processed <- string(content)
+				return nil
+			})
+
+		// Act
+		res, err := pb.NewReviewServiceClient(conn).SubmitIngestionJob(withAPIKey("admin-secret"), &pb.SubmitIngestionJobRequest{
+			Source: &pb.SubmitIngestionJobRequest_InlineFile{InlineFile: &pb.InlineFile{FileName: "reviews.jl", Content: []byte(`{"hotelId":1}`)}},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.JobId)
+		assert.Equal(t, "reviews.jl", res.FileName)
+		select {
+		case content := <-processed:
+			assert.Equal(t, `{"hotelId":1}`, content)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the ingestion job did not run")
+		}
+	})
+
+	t.Run("s3_object", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		mocks.objects.objects["reviews/2025/03/01.jl"] = `{"hotelId":2}`
+		processed := make(chan string, 1)
+		mocks.reviews.EXPECT().ProcessReviews(gomock.Any(), gomock.Any(), "2025/03/01.jl").
+			DoAndReturn(func(_ context.Context, reader io.Reader, _ string) error {
+				content, _ := io.ReadAll(reader)
+				processed <- string(content)
+				return nil
+			})
+
+		// Act
+		res, err := pb.NewReviewServiceClient(conn).SubmitIngestionJob(withAPIKey("admin-secret"), &pb.SubmitIngestionJobRequest{
+			Source: &pb.SubmitIngestionJobRequest_S3Object{S3Object: &pb.S3Object{Bucket: "reviews", Key: "2025/03/01.jl"}},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "2025/03/01.jl", res.FileName)
+		select {
+		case content := <-processed:
+			assert.Equal(t, `{"hotelId":2}`, content)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the ingestion job did not run")
+		}
+	})
+
+	t.Run("s3_object_fetch_error", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewReviewServiceClient(conn).SubmitIngestionJob(withAPIKey("admin-secret"), &pb.SubmitIngestionJobRequest{
+			Source: &pb.SubmitIngestionJobRequest_S3Object{S3Object: &pb.S3Object{Bucket: "reviews", Key: "missing.jl"}},
+		})
+
+		// Assert
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGetIngestionJob(t *testing.T) {
+	inlineFile := &pb.SubmitIngestionJobRequest{
+		Source: &pb.SubmitIngestionJobRequest_InlineFile{InlineFile: &pb.InlineFile{FileName: "reviews.jl", Content: []byte("{}")}},
+	}
+
+	t.Run("requires_admin", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewReviewServiceClient(conn).GetIngestionJob(withAPIKey("secret"), &pb.GetIngestionJobRequest{JobId: "1"})
+
+		// Assert
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, _ := newTestConn(t, ctrl)
+
+		// Act
+		_, err := pb.NewReviewServiceClient(conn).GetIngestionJob(withAPIKey("admin-secret"), &pb.GetIngestionJobRequest{JobId: "unknown"})
+
+		// Assert
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("finished_jobs", func(t *testing.T) {
+		for name, processErr := range map[string]error{"succeeded": nil, "failed": errors.New("the file is not JSON lines")} {
+			t.Run(name, func(t *testing.T) {
+				// Arrange
+				ctrl := gomock.NewController(t)
+				conn, mocks := newTestConn(t, ctrl)
+				mocks.reviews.EXPECT().ProcessReviews(gomock.Any(), gomock.Any(), "reviews.jl").Return(processErr)
+				client := pb.NewReviewServiceClient(conn)
+
+				// Act
+				submitted, err := client.SubmitIngestionJob(withAPIKey("admin-secret"), inlineFile)
+				require.NoError(t, err)
+				var job *pb.IngestionJob
+				require.Eventually(t, func() bool {
+					res, err := client.GetIngestionJob(withAPIKey("admin-secret"), &pb.GetIngestionJobRequest{JobId: submitted.JobId})
+					require.NoError(t, err)
+					job = res.Job
+					return job.FinishedAt != nil
+				}, 5*time.Second, 10*time.Millisecond)
+
+				// Assert
+				assert.Equal(t, submitted.JobId, job.JobId)
+				assert.Equal(t, "reviews.jl", job.FileName)
+				assert.NotNil(t, job.SubmittedAt)
+				assert.NotNil(t, job.StartedAt)
+				if processErr != nil {
+					assert.Equal(t, pb.IngestionJob_STATE_FAILED, job.State)
+					assert.Equal(t, processErr.Error(), job.Error)
+				} else {
+					assert.Equal(t, pb.IngestionJob_STATE_SUCCEEDED, job.State)
+					assert.Empty(t, job.Error)
+				}
+			})
+		}
+	})
+
+	t.Run("queue_full", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		started := make(chan struct{}, rpc.IngestionWorkers+rpc.MaxQueuedIngestionJobs)
+		release := make(chan struct{})
+		mocks.reviews.EXPECT().ProcessReviews(gomock.Any(), gomock.Any(), "reviews.jl").
+			DoAndReturn(func(context.Context, io.Reader, string) error {
+				started <- struct{}{}
+				<-release
+				return nil
+			}).Times(rpc.IngestionWorkers + rpc.MaxQueuedIngestionJobs)
+		defer close(release)
+		client := pb.NewReviewServiceClient(conn)
+
+		// Keep every worker busy, then fill the queue
+		for range rpc.IngestionWorkers {
+			_, err := client.SubmitIngestionJob(withAPIKey("admin-secret"), inlineFile)
+			require.NoError(t, err)
+			<-started
+		}
+		var queued string
+		for range rpc.MaxQueuedIngestionJobs {
+			res, err := client.SubmitIngestionJob(withAPIKey("admin-secret"), inlineFile)
+			require.NoError(t, err)
+			queued = res.JobId
+		}
+		// Act
+		_, err := client.SubmitIngestionJob(withAPIKey("admin-secret"), inlineFile)
+		res, getErr := client.GetIngestionJob(withAPIKey("admin-secret"), &pb.GetIngestionJobRequest{JobId: queued})
+
+		// Assert
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		require.NoError(t, getErr)
+		assert.Equal(t, pb.IngestionJob_STATE_QUEUED, res.Job.State)
+	})
+
+	t.Run("shutdown_waits_for_jobs", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		conn, mocks := newTestConn(t, ctrl)
+		finished := false
+		mocks.reviews.EXPECT().ProcessReviews(gomock.Any(), gomock.Any(), "reviews.jl").
+			DoAndReturn(func(context.Context, io.Reader, string) error {
+				time.Sleep(50 * time.Millisecond)
+				finished = true
+				return nil
+			})
+		client := pb.NewReviewServiceClient(conn)
+		_, err := client.SubmitIngestionJob(withAPIKey("admin-secret"), inlineFile)
+		require.NoError(t, err)
+
+		// Act
+		shutdownErr := mocks.jobs.Shutdown(context.Background())
+		_, submitErr := client.SubmitIngestionJob(withAPIKey("admin-secret"), inlineFile)
+
+		// Assert
+		require.NoError(t, shutdownErr)
+		assert.True(t, finished)
+		assert.Equal(t, codes.Unavailable, status.Code(submitErr))
+	})
+}
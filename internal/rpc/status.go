@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kirananto/review-system/internal/api/response"
+	"github.com/kirananto/review-system/internal/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the domain of the ErrorInfo details of failed calls.
+const errorDomain = "review-system"
+
+// statusCodes map the HTTP statuses services fail with to gRPC codes.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+}
+
+// statusCode returns the gRPC code of an HTTP status.
+func statusCode(httpStatus int) codes.Code {
+	if code, ok := statusCodes[httpStatus]; ok {
+		return code
+	}
+	return codes.Unknown
+}
+
+// serviceError returns the status of a call a service failed. Like a problem
+// response of the REST API, it carries the error code and trace ID as an
+// ErrorInfo and the fields that failed validation as a BadRequest. The cause
+// of a server error is only logged.
+func serviceError(ctx context.Context, log *logger.Logger, errorDetails *response.ErrorDetails) error {
+	problem := response.NewProblem(errorDetails.Code, errorDetails.ErrorCode, errorDetails.Message)
+	problem.TraceID = response.TraceID(ctx)
+	if errorDetails.Code >= http.StatusInternalServerError {
+		log.Error(errorDetails.Error, fmt.Sprintf("gRPC call failed with %d (trace ID %s)", errorDetails.Code, problem.TraceID))
+	}
+
+	st := status.New(statusCode(problem.Status), problem.Detail)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   problem.Code,
+		Domain:   errorDomain,
+		Metadata: map[string]string{"trace_id": problem.TraceID},
+	}}
+	if len(errorDetails.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range errorDetails.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// validationError returns the status of a request field that failed validation.
+func validationError(ctx context.Context, field, message string) error {
+	return serviceError(ctx, nil, response.ValidationErrorDetails(field, message, nil))
+}
+
+// newError returns the status of a failure with the generic code of httpStatus.
+func newError(ctx context.Context, httpStatus int, detail string) error {
+	return serviceError(ctx, nil, &response.ErrorDetails{Code: httpStatus, Message: detail})
+}
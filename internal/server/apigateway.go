@@ -0,0 +1,383 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/kirananto/review-system/internal/api/response"
+)
+
+type contextKey int
+
+const (
+	apiGatewayRequestContextKey contextKey = iota
+	apiGatewayV2RequestContextKey
+	functionURLRequestContextKey
+)
+
+// APIGatewayRequestContext returns the API Gateway REST API request context
+// attached to a request served through the Lambda adapter.
+func APIGatewayRequestContext(ctx context.Context) (events.APIGatewayProxyRequestContext, bool) {
+	reqCtx, ok := ctx.Value(apiGatewayRequestContextKey).(events.APIGatewayProxyRequestContext)
+	return reqCtx, ok
+}
+
+// APIGatewayV2RequestContext returns the API Gateway HTTP API request context
+// attached to a request served through the Lambda adapter.
+func APIGatewayV2RequestContext(ctx context.Context) (events.APIGatewayV2HTTPRequestContext, bool) {
+	reqCtx, ok := ctx.Value(apiGatewayV2RequestContextKey).(events.APIGatewayV2HTTPRequestContext)
+	return reqCtx, ok
+}
+
+// FunctionURLRequestContext returns the Lambda function URL request context
+// attached to a request served through the Lambda adapter.
+func FunctionURLRequestContext(ctx context.Context) (events.LambdaFunctionURLRequestContext, bool) {
+	reqCtx, ok := ctx.Value(functionURLRequestContextKey).(events.LambdaFunctionURLRequestContext)
+	return reqCtx, ok
+}
+
+// ResponseWriter captures the response for Lambda
+type ResponseWriter struct {
+	header      http.Header
+	Body        bytes.Buffer
+	StatusCode  int
+	wroteHeader bool
+}
+
+func NewResponseWriter() *ResponseWriter {
+	return &ResponseWriter{
+		header:     http.Header{},
+		StatusCode: http.StatusOK,
+	}
+}
+
+func (w *ResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *ResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.Body.Write(b)
+}
+
+func (w *ResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.StatusCode = statusCode
+	w.wroteHeader = true
+}
+
+// encodedBody returns the body as API Gateway expects it: plain text for
+// textual content, base64 for everything else.
+func (w *ResponseWriter) encodedBody() (string, bool) {
+	if isTextResponse(w.header, w.Body.Bytes()) {
+		return w.Body.String(), false
+	}
+	return base64.StdEncoding.EncodeToString(w.Body.Bytes()), true
+}
+
+// isTextResponse reports whether a response body can be returned to API
+// Gateway as-is. Encoded (e.g. gzip) bodies are always binary.
+func isTextResponse(header http.Header, body []byte) bool {
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		return utf8.Valid(body)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return utf8.Valid(body)
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"),
+		mediaType == "application/json",
+		mediaType == "application/x-ndjson",
+		mediaType == "application/xml",
+		mediaType == "application/javascript",
+		mediaType == "application/x-www-form-urlencoded",
+		mediaType == "application/yaml":
+		return true
+	}
+	return false
+}
+
+// httpRequestParts is the payload-format independent description of an
+// incoming HTTP request.
+type httpRequestParts struct {
+	method          string
+	path            string
+	rawQuery        string
+	header          http.Header
+	body            string
+	isBase64Encoded bool
+	host            string
+	sourceIP        string
+	// requestID is the API Gateway or Lambda request ID, used as the trace ID
+	// unless the client sent one
+	requestID string
+}
+
+// newHTTPRequest builds the http.Request handed to the router. The Lambda
+// context is passed through so handlers see the invocation deadline.
+func newHTTPRequest(ctx context.Context, parts httpRequestParts) (*http.Request, error) {
+	body := []byte(parts.body)
+	if parts.isBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(parts.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 body: %w", err)
+		}
+		body = decoded
+	}
+
+	host := parts.header.Get("Host")
+	if host == "" {
+		host = parts.host
+	}
+	if host == "" {
+		host = "localhost"
+	}
+
+	// Like requests served by net/http locally, the URL carries only the path
+	// and query; the host is kept on the request itself.
+	u := &url.URL{
+		Path:     parts.path,
+		RawQuery: parts.rawQuery,
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, parts.method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	httpReq.Header = parts.header
+	httpReq.Host = host
+	httpReq.RequestURI = u.RequestURI()
+	httpReq.ContentLength = int64(len(body))
+	if parts.sourceIP != "" {
+		httpReq.RemoteAddr = parts.sourceIP
+	}
+	if parts.requestID != "" && httpReq.Header.Get(response.TraceHeader) == "" {
+		httpReq.Header.Set(response.TraceHeader, parts.requestID)
+	}
+	if len(body) > 0 && httpReq.Header.Get("Content-Length") == "" {
+		httpReq.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	return httpReq, nil
+}
+
+// serve runs the request through the router and captures the response.
+func (s *Server) serve(httpReq *http.Request) *ResponseWriter {
+	w := NewResponseWriter()
+	s.Router.ServeHTTP(w, httpReq)
+	return w
+}
+
+// handleAPIGatewayRequest handles API Gateway REST API (payload format 1.0) proxy requests.
+func (s *Server) handleAPIGatewayRequest(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	httpReq, err := newAPIGatewayHTTPRequest(ctx, req)
+	if err != nil {
+		s.Logger.Error(err, fmt.Sprintf("Error converting API Gateway request: %v", err))
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusBadRequest,
+			Headers:    map[string]string{"Content-Type": response.ProblemContentType},
+			Body:       badRequestBody(req.RequestContext.RequestID),
+		}, nil
+	}
+
+	w := s.serve(httpReq)
+	body, isBase64Encoded := w.encodedBody()
+
+	headers := make(map[string]string, len(w.header))
+	for key, values := range w.header {
+		headers[key] = values[len(values)-1]
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode:        w.StatusCode,
+		Headers:           headers,
+		MultiValueHeaders: w.header,
+		Body:              body,
+		IsBase64Encoded:   isBase64Encoded,
+	}, nil
+}
+
+// newAPIGatewayHTTPRequest converts a payload format 1.0 request. The
+// multi-value maps are preferred since the single value ones keep only the
+// last value of repeated headers and query parameters.
+func newAPIGatewayHTTPRequest(ctx context.Context, req events.APIGatewayProxyRequest) (*http.Request, error) {
+	header := http.Header{}
+	for key, value := range req.Headers {
+		header.Set(key, value)
+	}
+	for key, values := range req.MultiValueHeaders {
+		header.Del(key)
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+
+	query := url.Values{}
+	for key, value := range req.QueryStringParameters {
+		query.Set(key, value)
+	}
+	for key, values := range req.MultiValueQueryStringParameters {
+		query[key] = values
+	}
+
+	ctx = context.WithValue(ctx, apiGatewayRequestContextKey, req.RequestContext)
+
+	return newHTTPRequest(ctx, httpRequestParts{
+		method:          req.HTTPMethod,
+		path:            req.Path,
+		rawQuery:        query.Encode(),
+		header:          header,
+		body:            req.Body,
+		isBase64Encoded: req.IsBase64Encoded,
+		host:            req.RequestContext.DomainName,
+		sourceIP:        req.RequestContext.Identity.SourceIP,
+		requestID:       req.RequestContext.RequestID,
+	})
+}
+
+// handleAPIGatewayV2Request handles API Gateway HTTP API (payload format 2.0) requests.
+func (s *Server) handleAPIGatewayV2Request(ctx context.Context, req events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	ctx = context.WithValue(ctx, apiGatewayV2RequestContextKey, req.RequestContext)
+
+	httpReq, err := newHTTPRequest(ctx, httpRequestParts{
+		method:          req.RequestContext.HTTP.Method,
+		path:            v2Path(req.RawPath),
+		rawQuery:        req.RawQueryString,
+		header:          v2Header(req.Headers, req.Cookies),
+		body:            req.Body,
+		isBase64Encoded: req.IsBase64Encoded,
+		host:            req.RequestContext.DomainName,
+		sourceIP:        req.RequestContext.HTTP.SourceIP,
+		requestID:       req.RequestContext.RequestID,
+	})
+	if err != nil {
+		s.Logger.Error(err, fmt.Sprintf("Error converting API Gateway v2 request: %v", err))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusBadRequest,
+			Headers:    map[string]string{"Content-Type": response.ProblemContentType},
+			Body:       badRequestBody(req.RequestContext.RequestID),
+		}, nil
+	}
+
+	w := s.serve(httpReq)
+	headers, cookies := v2ResponseHeaders(w.header)
+	body, isBase64Encoded := w.encodedBody()
+
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode:      w.StatusCode,
+		Headers:         headers,
+		Cookies:         cookies,
+		Body:            body,
+		IsBase64Encoded: isBase64Encoded,
+	}, nil
+}
+
+// handleFunctionURLRequest handles Lambda function URL requests, which use the
+// same payload format as HTTP APIs.
+func (s *Server) handleFunctionURLRequest(ctx context.Context, req events.LambdaFunctionURLRequest) (events.LambdaFunctionURLResponse, error) {
+	ctx = context.WithValue(ctx, functionURLRequestContextKey, req.RequestContext)
+
+	httpReq, err := newHTTPRequest(ctx, httpRequestParts{
+		method:          req.RequestContext.HTTP.Method,
+		path:            v2Path(req.RawPath),
+		rawQuery:        req.RawQueryString,
+		header:          v2Header(req.Headers, req.Cookies),
+		body:            req.Body,
+		isBase64Encoded: req.IsBase64Encoded,
+		host:            req.RequestContext.DomainName,
+		sourceIP:        req.RequestContext.HTTP.SourceIP,
+		requestID:       req.RequestContext.RequestID,
+	})
+	if err != nil {
+		s.Logger.Error(err, fmt.Sprintf("Error converting function URL request: %v", err))
+		return events.LambdaFunctionURLResponse{
+			StatusCode: http.StatusBadRequest,
+			Headers:    map[string]string{"Content-Type": response.ProblemContentType},
+			Body:       badRequestBody(req.RequestContext.RequestID),
+		}, nil
+	}
+
+	w := s.serve(httpReq)
+	headers, cookies := v2ResponseHeaders(w.header)
+	body, isBase64Encoded := w.encodedBody()
+
+	return events.LambdaFunctionURLResponse{
+		StatusCode:      w.StatusCode,
+		Headers:         headers,
+		Cookies:         cookies,
+		Body:            body,
+		IsBase64Encoded: isBase64Encoded,
+	}, nil
+}
+
+// badRequestBody is the problem returned for requests the adapter can not
+// convert, such as ones with an invalid base64 body.
+func badRequestBody(requestID string) string {
+	problem := response.NewProblem(http.StatusBadRequest, response.CodeMalformedRequest, "The request could not be read")
+	problem.TraceID = requestID
+	body, _ := json.Marshal(problem)
+	return string(body)
+}
+
+// v2Path unescapes the raw path of a payload format 2.0 request, which unlike
+// format 1.0 is sent exactly as the client encoded it.
+func v2Path(rawPath string) string {
+	path, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return rawPath
+	}
+	return path
+}
+
+// v2Header converts payload format 2.0 headers, where repeated headers are
+// joined with commas and cookies are sent separately.
+func v2Header(headers map[string]string, cookies []string) http.Header {
+	header := http.Header{}
+	for key, value := range headers {
+		header.Set(key, value)
+	}
+	if len(cookies) > 0 {
+		header.Set("Cookie", strings.Join(cookies, "; "))
+	}
+	return header
+}
+
+// v2ResponseHeaders joins repeated response headers with commas, except
+// Set-Cookie which payload format 2.0 returns as a separate list.
+func v2ResponseHeaders(header http.Header) (map[string]string, []string) {
+	headers := make(map[string]string, len(header))
+	var cookies []string
+	for key, values := range header {
+		if key == "Set-Cookie" {
+			cookies = append(cookies, values...)
+			continue
+		}
+		headers[key] = strings.Join(values, ",")
+	}
+	return headers, cookies
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// echoRequest is what the echo handler reports back about the request it received.
+type echoRequest struct {
+	Method     string              `json:"method"`
+	Path       string              `json:"path"`
+	Query      map[string][]string `json:"query"`
+	Header     map[string][]string `json:"header"`
+	Body       string              `json:"body"`
+	RemoteAddr string              `json:"remote_addr"`
+	RequestID  string              `json:"request_id"`
+}
+
+func newAdapterTestServer() *Server {
+	srv := newTestServer(&mockS3Service{})
+	router := mux.NewRouter()
+
+	router.HandleFunc("/echo/{name}", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var requestID string
+		if reqCtx, ok := APIGatewayRequestContext(r.Context()); ok {
+			requestID = reqCtx.RequestID
+		} else if reqCtx, ok := APIGatewayV2RequestContext(r.Context()); ok {
+			requestID = reqCtx.RequestID
+		} else if reqCtx, ok := FunctionURLRequestContext(r.Context()); ok {
+			requestID = reqCtx.RequestID
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(echoRequest{
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Query:      r.URL.Query(),
+			Header:     r.Header,
+			Body:       string(body),
+			RemoteAddr: r.RemoteAddr,
+			RequestID:  requestID,
+		})
+	})
+
+	router.HandleFunc("/binary", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte{0xff, 0x00, 0xfe})
+	})
+
+	srv.Router = router
+	return srv
+}
+
+func TestServer_HandleAPIGatewayRequest(t *testing.T) {
+	srv := newAdapterTestServer()
+
+	t.Run("multi-value query and headers, base64 body", func(t *testing.T) {
+		req := events.APIGatewayProxyRequest{
+			HTTPMethod:                      "POST",
+			Path:                            "/echo/test",
+			QueryStringParameters:           map[string]string{"tag": "b"},
+			MultiValueQueryStringParameters: map[string][]string{"tag": {"a", "b"}},
+			Headers:                         map[string]string{"X-Custom": "two"},
+			MultiValueHeaders:               map[string][]string{"X-Custom": {"one", "two"}},
+			Body:                            base64.StdEncoding.EncodeToString([]byte(`{"hello":"world"}`)),
+			IsBase64Encoded:                 true,
+			RequestContext: events.APIGatewayProxyRequestContext{
+				RequestID: "v1-request",
+				Identity:  events.APIGatewayRequestIdentity{SourceIP: "10.0.0.1"},
+			},
+		}
+
+		resp, err := srv.handleAPIGatewayRequest(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.False(t, resp.IsBase64Encoded)
+		assert.Equal(t, "application/json", resp.Headers["Content-Type"])
+		assert.Equal(t, []string{"a=1", "b=2"}, resp.MultiValueHeaders["Set-Cookie"])
+
+		var echo echoRequest
+		assert.NoError(t, json.Unmarshal([]byte(resp.Body), &echo))
+		assert.Equal(t, "POST", echo.Method)
+		assert.Equal(t, "/echo/test", echo.Path)
+		assert.Equal(t, []string{"a", "b"}, echo.Query["tag"])
+		assert.Equal(t, []string{"one", "two"}, echo.Header["X-Custom"])
+		assert.Equal(t, `{"hello":"world"}`, echo.Body)
+		assert.Equal(t, "10.0.0.1", echo.RemoteAddr)
+		assert.Equal(t, "v1-request", echo.RequestID)
+		assert.Equal(t, []string{"v1-request"}, echo.Header["X-Request-Id"])
+	})
+
+	t.Run("binary response", func(t *testing.T) {
+		resp, err := srv.handleAPIGatewayRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Path:       "/binary",
+		})
+		assert.NoError(t, err)
+		assert.True(t, resp.IsBase64Encoded)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte{0xff, 0x00, 0xfe}), resp.Body)
+	})
+
+	t.Run("invalid base64 body", func(t *testing.T) {
+		resp, err := srv.handleAPIGatewayRequest(context.TODO(), events.APIGatewayProxyRequest{
+			HTTPMethod:      "POST",
+			Path:            "/echo/test",
+			Body:            "%%%",
+			IsBase64Encoded: true,
+			RequestContext:  events.APIGatewayProxyRequestContext{RequestID: "bad-request"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "application/problem+json", resp.Headers["Content-Type"])
+		assert.Contains(t, resp.Body, `"trace_id":"bad-request"`)
+	})
+}
+
+func TestServer_HandleAPIGatewayV2Request(t *testing.T) {
+	srv := newAdapterTestServer()
+
+	req := events.APIGatewayV2HTTPRequest{
+		Version:        "2.0",
+		RawPath:        "/echo/hello%20world",
+		RawQueryString: "tag=a&tag=b",
+		Cookies:        []string{"session=abc", "theme=dark"},
+		Headers:        map[string]string{"x-custom": "one,two"},
+		Body:           "plain body",
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			RequestID: "v2-request",
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method:   "PUT",
+				SourceIP: "10.0.0.2",
+			},
+		},
+	}
+
+	resp, err := srv.handleAPIGatewayV2Request(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, []string{"a=1", "b=2"}, resp.Cookies)
+	assert.NotContains(t, resp.Headers, "Set-Cookie")
+
+	var echo echoRequest
+	assert.NoError(t, json.Unmarshal([]byte(resp.Body), &echo))
+	assert.Equal(t, "PUT", echo.Method)
+	assert.Equal(t, "/echo/hello world", echo.Path)
+	assert.Equal(t, []string{"a", "b"}, echo.Query["tag"])
+	assert.Equal(t, []string{"session=abc; theme=dark"}, echo.Header["Cookie"])
+	assert.Equal(t, "plain body", echo.Body)
+	assert.Equal(t, "10.0.0.2", echo.RemoteAddr)
+	assert.Equal(t, "v2-request", echo.RequestID)
+}
+
+func TestServer_Handle_HTTPPayloadFormats(t *testing.T) {
+	srv := newAdapterTestServer()
+
+	tests := []struct {
+		name      string
+		event     string
+		requestID string
+	}{
+		{
+			name:      "rest api",
+			event:     `{"httpMethod":"GET","path":"/echo/x","requestContext":{"requestId":"rest"}}`,
+			requestID: "rest",
+		},
+		{
+			name:      "http api",
+			event:     `{"version":"2.0","rawPath":"/echo/x","requestContext":{"requestId":"http","domainName":"abc.execute-api.ap-south-1.amazonaws.com","http":{"method":"GET"}}}`,
+			requestID: "http",
+		},
+		{
+			name:      "function url",
+			event:     `{"version":"2.0","rawPath":"/echo/x","requestContext":{"requestId":"url","domainName":"abc.lambda-url.ap-south-1.on.aws","http":{"method":"GET"}}}`,
+			requestID: "url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := srv.handle(context.TODO(), []byte(tt.event))
+			assert.NoError(t, err)
+
+			var body string
+			switch r := resp.(type) {
+			case events.APIGatewayProxyResponse:
+				body = r.Body
+			case events.APIGatewayV2HTTPResponse:
+				body = r.Body
+			case events.LambdaFunctionURLResponse:
+				body = r.Body
+			default:
+				t.Fatalf("unexpected response type %T", resp)
+			}
+
+			var echo echoRequest
+			assert.NoError(t, json.Unmarshal([]byte(body), &echo))
+			assert.Equal(t, tt.requestID, echo.RequestID)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/kirananto/review-system/internal/api/repository"
+	"github.com/kirananto/review-system/internal/api/service"
+)
+
+const (
+	eventSourceSQS     = "aws:sqs"
+	eventSourceS3      = "aws:s3"
+	eventSourceSNS     = "aws:sns"
+	eventSourceKinesis = "aws:kinesis"
+
+	eventBridgeSourceS3          = "aws.s3"
+	eventBridgeObjectCreatedType = "Object Created"
+
+	eventBridgeSourceSchedule = "aws.events"
+	eventBridgeScheduledType  = "Scheduled Event"
+
+	// jobDeliverWebhooks is the job input of the schedule that delivers the
+	// due webhooks.
+	jobDeliverWebhooks = "deliver-webhooks"
+)
+
+// eventProbe holds just enough of an incoming Lambda payload to decide which
+// handler it should be routed to.
+type eventProbe struct {
+	// Matches both "eventSource" (SQS, S3) and "EventSource" (SNS), since
+	// encoding/json matches keys case-insensitively.
+	Records []struct {
+		EventSource string `json:"eventSource"`
+	} `json:"Records"`
+	RequestContext struct {
+		RequestID  string `json:"requestId"`
+		DomainName string `json:"domainName"`
+		HTTP       struct {
+			Method string `json:"method"`
+		} `json:"http"`
+	} `json:"requestContext"`
+	Version    string `json:"version"`
+	DetailType string `json:"detail-type"`
+	Source     string `json:"source"`
+	// Job is set by schedules whose input names the job to run, as they are
+	// sent that input instead of the scheduled event.
+	Job string `json:"job"`
+}
+
+// recordsSource returns the event source of the first record, if any.
+func (p *eventProbe) recordsSource() string {
+	if len(p.Records) == 0 {
+		return ""
+	}
+	return p.Records[0].EventSource
+}
+
+// isHTTPAPIRequest reports whether the payload uses the 2.0 format shared by
+// API Gateway HTTP APIs and Lambda function URLs.
+func (p *eventProbe) isHTTPAPIRequest() bool {
+	return p.Version == "2.0" && p.RequestContext.HTTP.Method != ""
+}
+
+// isFunctionURLRequest reports whether the payload comes from a Lambda function URL.
+func (p *eventProbe) isFunctionURLRequest() bool {
+	return p.isHTTPAPIRequest() && strings.Contains(p.RequestContext.DomainName, ".lambda-url.")
+}
+
+// isObjectCreated reports whether the payload is an EventBridge S3 "Object Created" event.
+func (p *eventProbe) isObjectCreated() bool {
+	return p.Source == eventBridgeSourceS3 && p.DetailType == eventBridgeObjectCreatedType
+}
+
+// isScheduledEvent reports whether the payload is an EventBridge "Scheduled
+// Event", which runs the purge job.
+func (p *eventProbe) isScheduledEvent() bool {
+	return p.Source == eventBridgeSourceSchedule && p.DetailType == eventBridgeScheduledType
+}
+
+// s3ObjectRef identifies a single S3 object to ingest.
+type s3ObjectRef struct {
+	Bucket string
+	Key    string
+}
+
+// objectCreatedDetail is the "detail" of an EventBridge S3 "Object Created" event.
+type objectCreatedDetail struct {
+	Bucket struct {
+		Name string `json:"name"`
+	} `json:"bucket"`
+	Object struct {
+		Key string `json:"key"`
+	} `json:"object"`
+}
+
+// notificationEnvelope covers every shape an object-created notification can
+// arrive in: a plain S3 notification, an SNS envelope whose Message is an S3
+// notification, or an EventBridge "Object Created" event.
+type notificationEnvelope struct {
+	Records []events.S3EventRecord `json:"Records"`
+
+	// SNS envelope, as delivered to SQS without raw message delivery
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+
+	// EventBridge event
+	DetailType string          `json:"detail-type"`
+	Source     string          `json:"source"`
+	Detail     json.RawMessage `json:"detail"`
+}
+
+// parseS3Objects extracts the S3 objects referenced by a notification payload.
+// Payloads that carry no objects, like the s3:TestEvent S3 sends when a
+// notification is configured, yield an empty slice.
+func parseS3Objects(payload []byte) ([]s3ObjectRef, error) {
+	var envelope notificationEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+
+	if envelope.Type == "Notification" && envelope.Message != "" {
+		return parseS3Objects([]byte(envelope.Message))
+	}
+
+	if envelope.Source == eventBridgeSourceS3 {
+		if envelope.DetailType != eventBridgeObjectCreatedType {
+			return nil, fmt.Errorf("unsupported EventBridge detail type: %s", envelope.DetailType)
+		}
+		var detail objectCreatedDetail
+		if err := json.Unmarshal(envelope.Detail, &detail); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal EventBridge detail: %w", err)
+		}
+		return []s3ObjectRef{{Bucket: detail.Bucket.Name, Key: detail.Object.Key}}, nil
+	}
+
+	objects := make([]s3ObjectRef, 0, len(envelope.Records))
+	for _, record := range envelope.Records {
+		objects = append(objects, s3ObjectRef{
+			Bucket: record.S3.Bucket.Name,
+			Key:    record.S3.Object.URLDecodedKey,
+		})
+	}
+	return objects, nil
+}
+
+// ingestNotification ingests every S3 object referenced by a notification
+// payload. It returns an error if any of them could not be fetched or processed.
+func (s *Server) ingestNotification(ctx context.Context, payload []byte) error {
+	objects, err := parseS3Objects(payload)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		if err := s.processS3Object(ctx, object.Bucket, object.Key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleS3Event handles S3 notifications delivered directly to the function.
+// Errors are returned so Lambda retries the asynchronous invocation.
+func (s *Server) handleS3Event(ctx context.Context, event json.RawMessage) (interface{}, error) {
+	if err := s.ingestNotification(ctx, event); err != nil {
+		s.Logger.Error(err, fmt.Sprintf("Error processing S3 event: %v", err))
+		return nil, err
+	}
+	return nil, nil
+}
+
+// handleEventBridgeEvent handles EventBridge S3 "Object Created" events.
+func (s *Server) handleEventBridgeEvent(ctx context.Context, event json.RawMessage) (interface{}, error) {
+	if err := s.ingestNotification(ctx, event); err != nil {
+		s.Logger.Error(err, fmt.Sprintf("Error processing EventBridge event: %v", err))
+		return nil, err
+	}
+	return nil, nil
+}
+
+// handleSNSEvent handles SNS notifications whose message is an S3 notification.
+func (s *Server) handleSNSEvent(ctx context.Context, snsEvent events.SNSEvent) (interface{}, error) {
+	for _, record := range snsEvent.Records {
+		s.Logger.Info(fmt.Sprintf("Processing SNS message: %s", record.SNS.MessageID))
+
+		if err := s.ingestNotification(ctx, []byte(record.SNS.Message)); err != nil {
+			s.Logger.Error(err, fmt.Sprintf("Error processing SNS message %s: %v", record.SNS.MessageID, err))
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// handleScheduledEvent purges the entities soft-deleted before the retention
+// window. Errors are returned so the failed run shows up in Lambda's metrics;
+// the next run picks up whatever was left.
+func (s *Server) handleScheduledEvent() (interface{}, error) {
+	purge := service.NewPurgeService(repository.NewReviewRepository(s.DataSource), s.Logger)
+	result, err := purge.Purge(s.Config.PurgeRetention)
+	if err != nil {
+		s.Logger.Error(err, fmt.Sprintf("Error running scheduled purge: %v", err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// handleDeliverWebhooks delivers the webhooks that are due. Errors are
+// returned so the failed run shows up in Lambda's metrics; the deliveries are
+// attempted again by the next run.
+func (s *Server) handleDeliverWebhooks(ctx context.Context) (interface{}, error) {
+	result, err := s.newDispatcher().DeliverDue(ctx)
+	if err != nil {
+		s.Logger.Error(err, fmt.Sprintf("Error delivering webhooks: %v", err))
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const eventsFixtureDir = "../../test/data/events"
+
+func readEventFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(eventsFixtureDir, name))
+	assert.NoError(t, err)
+	return data
+}
+
+func TestParseS3Objects(t *testing.T) {
+	expected := []s3ObjectRef{{Bucket: "review-data-bucket-767398070115", Key: "reviews.jl"}}
+
+	t.Run("s3 notification", func(t *testing.T) {
+		objects, err := parseS3Objects(readEventFixture(t, "event.json"))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, objects)
+	})
+
+	t.Run("eventbridge object created", func(t *testing.T) {
+		objects, err := parseS3Objects(readEventFixture(t, "eventbridge.json"))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, objects)
+	})
+
+	t.Run("sns envelope", func(t *testing.T) {
+		objects, err := parseS3Objects([]byte(`{"Type":"Notification","Message":"{\"Records\":[{\"s3\":{\"bucket\":{\"name\":\"bucket\"},\"object\":{\"key\":\"my+reviews.jl\"}}}]}"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, []s3ObjectRef{{Bucket: "bucket", Key: "my reviews.jl"}}, objects)
+	})
+
+	t.Run("s3 test event", func(t *testing.T) {
+		objects, err := parseS3Objects([]byte(`{"Service":"Amazon S3","Event":"s3:TestEvent"}`))
+		assert.NoError(t, err)
+		assert.Empty(t, objects)
+	})
+
+	t.Run("unsupported eventbridge detail type", func(t *testing.T) {
+		_, err := parseS3Objects([]byte(`{"source":"aws.s3","detail-type":"Object Deleted","detail":{}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := parseS3Objects([]byte(`not json`))
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_Handle_ObjectCreatedEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		sqs     bool
+	}{
+		{name: "direct s3", fixture: "event.json"},
+		{name: "sns", fixture: "sns.json"},
+		{name: "eventbridge", fixture: "eventbridge.json"},
+		{name: "sqs wrapped s3", fixture: "sqs.json", sqs: true},
+		{name: "sqs wrapped sns", fixture: "sqs_sns.json", sqs: true},
+		{name: "sqs wrapped eventbridge", fixture: "sqs_eventbridge.json", sqs: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []s3ObjectRef
+			srv := newTestServer(&mockS3Service{
+				GetObjectFunc: func(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+					requested = append(requested, s3ObjectRef{Bucket: bucket, Key: key})
+					return nil, errors.New("s3 error")
+				},
+			})
+
+			resp, err := srv.handle(context.TODO(), readEventFixture(t, tt.fixture))
+			if tt.sqs {
+				// SQS reports the failure per message instead of failing the invocation
+				assert.NoError(t, err)
+				sqsResp, ok := resp.(events.SQSEventResponse)
+				assert.True(t, ok)
+				assert.Len(t, sqsResp.BatchItemFailures, 1)
+			} else {
+				assert.Error(t, err)
+			}
+
+			assert.Equal(t, []s3ObjectRef{{Bucket: "review-data-bucket-767398070115", Key: "reviews.jl"}}, requested)
+		})
+	}
+
+	t.Run("unsupported event", func(t *testing.T) {
+		srv := newTestServer(&mockS3Service{})
+		_, err := srv.handle(context.TODO(), []byte(`{"foo":"bar"}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestEventProbe_IsScheduledEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected bool
+	}{
+		{name: "scheduled", fixture: "scheduled.json", expected: true},
+		{name: "object created", fixture: "eventbridge.json", expected: false},
+		{name: "s3", fixture: "event.json", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var probe eventProbe
+			assert.NoError(t, json.Unmarshal(readEventFixture(t, tt.fixture), &probe))
+			assert.Equal(t, tt.expected, probe.isScheduledEvent())
+		})
+	}
+}
+
+func TestEventProbe_Job(t *testing.T) {
+	var probe eventProbe
+	assert.NoError(t, json.Unmarshal([]byte(`{"job":"deliver-webhooks"}`), &probe))
+	assert.Equal(t, jobDeliverWebhooks, probe.Job)
+	assert.False(t, probe.isScheduledEvent())
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/kirananto/review-system/internal/api/service"
+)
+
+// handleKinesisEvent ingests each Kinesis record as a single review line.
+//
+// Records that can never be ingested (bad JSON, failed validation) are logged
+// and skipped so they don't block the shard. On any other failure processing
+// stops and that record's sequence number is reported, so Lambda checkpoints
+// the shard right before it and retries from there.
+func (s *Server) handleKinesisEvent(ctx context.Context, kinesisEvent events.KinesisEvent) (events.KinesisEventResponse, error) {
+	log := s.Logger
+	reviewService := s.newReviewService()
+	batchItemFailures := []events.KinesisBatchItemFailure{}
+	var successCount, skippedCount int
+
+	for _, record := range kinesisEvent.Records {
+		sequenceNumber := record.Kinesis.SequenceNumber
+
+		err := reviewService.ProcessReview(ctx, record.Kinesis.Data)
+		if err == nil {
+			successCount++
+			continue
+		}
+
+		if errors.Is(err, service.ErrInvalidReview) {
+			log.Error(err, fmt.Sprintf("Skipping invalid Kinesis record %s: %v. Data: %s", sequenceNumber, err, string(record.Kinesis.Data)))
+			skippedCount++
+			continue
+		}
+
+		log.Error(err, fmt.Sprintf("Error processing Kinesis record %s: %v", sequenceNumber, err))
+		batchItemFailures = append(batchItemFailures, events.KinesisBatchItemFailure{
+			ItemIdentifier: sequenceNumber,
+		})
+		break
+	}
+
+	log.Info(fmt.Sprintf("Processed Kinesis batch, Success: %d, Skipped: %d, Total: %d", successCount, skippedCount, len(kinesisEvent.Records)))
+
+	return events.KinesisEventResponse{BatchItemFailures: batchItemFailures}, nil
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/kirananto/review-system/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// unreachableDataSource returns a data source whose database refuses every
+// connection, so storing anything fails.
+func unreachableDataSource(t *testing.T) *db.DataSource {
+	gormDB, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=1"), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               gormlogger.Discard,
+	})
+	require.NoError(t, err)
+	return &db.DataSource{Db: gormDB}
+}
+
+func TestServer_HandleKinesisEvent(t *testing.T) {
+	t.Run("skips invalid records", func(t *testing.T) {
+		srv := newTestServer(&mockS3Service{})
+		srv.DataSource = &db.DataSource{}
+
+		kinesisEvent := events.KinesisEvent{
+			Records: []events.KinesisEventRecord{
+				{Kinesis: events.KinesisRecord{SequenceNumber: "1", Data: []byte("not json")}},
+				{Kinesis: events.KinesisRecord{SequenceNumber: "2", Data: []byte(`{"hotelId":1,"platform":"Agoda"}`)}},
+			},
+		}
+
+		resp, err := srv.handleKinesisEvent(context.TODO(), kinesisEvent)
+		assert.NoError(t, err)
+		assert.Empty(t, resp.BatchItemFailures)
+	})
+
+	t.Run("reports the record that failed", func(t *testing.T) {
+		srv := newTestServer(&mockS3Service{})
+		srv.DataSource = unreachableDataSource(t)
+
+		review := `{"hotelId":1,"platform":"Agoda","hotelName":"Test Hotel","comment":{"hotelReviewId":1,"rating":8,"reviewDate":"2025-01-01T00:00:00Z","reviewTitle":"Great"}}`
+		kinesisEvent := events.KinesisEvent{
+			Records: []events.KinesisEventRecord{
+				{Kinesis: events.KinesisRecord{SequenceNumber: "1", Data: []byte("not json")}},
+				{Kinesis: events.KinesisRecord{SequenceNumber: "2", Data: []byte(review)}},
+				{Kinesis: events.KinesisRecord{SequenceNumber: "3", Data: []byte(review)}},
+			},
+		}
+
+		resp, err := srv.handleKinesisEvent(context.TODO(), kinesisEvent)
+		assert.NoError(t, err)
+		// Processing stops at the failed record, so the shard is retried from it
+		assert.Equal(t, []events.KinesisBatchItemFailure{{ItemIdentifier: "2"}}, resp.BatchItemFailures)
+	})
+
+	t.Run("routes kinesis events", func(t *testing.T) {
+		srv := newTestServer(&mockS3Service{})
+		srv.DataSource = &db.DataSource{}
+
+		data := base64.StdEncoding.EncodeToString([]byte("not json"))
+		event := []byte(`{"Records":[{"eventSource":"aws:kinesis","kinesis":{"sequenceNumber":"1","data":"` + data + `"}}]}`)
+
+		resp, err := srv.handle(context.TODO(), event)
+		assert.NoError(t, err)
+		kinesisResp, ok := resp.(events.KinesisEventResponse)
+		assert.True(t, ok)
+		assert.Empty(t, kinesisResp.BatchItemFailures)
+	})
+}
@@ -4,21 +4,29 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"net"
 	"net/http"
-	"net/url"
-	"strings"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/gorilla/mux"
 	"github.com/kirananto/review-system/internal/api"
+	"github.com/kirananto/review-system/internal/api/middleware"
 	"github.com/kirananto/review-system/internal/api/repository"
 	"github.com/kirananto/review-system/internal/api/service"
 	"github.com/kirananto/review-system/internal/db"
 	"github.com/kirananto/review-system/internal/logger"
 	models "github.com/kirananto/review-system/internal/models"
+	"github.com/kirananto/review-system/internal/moderation"
+	"github.com/kirananto/review-system/internal/notify"
+	"github.com/kirananto/review-system/internal/rpc"
 	"github.com/kirananto/review-system/internal/s3"
+	"github.com/kirananto/review-system/internal/webhook"
+	"google.golang.org/grpc"
 )
 
 type Server struct {
@@ -27,51 +35,51 @@ type Server struct {
 	DataSource *db.DataSource
 	Router     *mux.Router
 	S3Service  s3.S3Service
+	// Moderation holds the auto-moderation rules ingestion runs. It outlives
+	// single events so rule changes are picked up by warm Lambdas.
+	Moderation *moderation.Pipeline
+	// Notifier sends the summaries of ingested files, unless it is nil.
+	Notifier *notify.Notifier
 }
 type ServerConfig struct {
 	DatabaseDSN string
-	RunMode     string // "local" or "lambda"
-	Port        string // e.g., ":8000"
-	LogConfig   logger.LogConfig
+	// APIKeys is the JSON array of API keys the REST and gRPC APIs accept.
+	APIKeys   string
+	RunMode   string // "local" or "lambda"
+	Port      string // e.g., ":8000"
+	GRPCPort  string // e.g., ":9000"; the gRPC API is not served when empty
+	LogConfig logger.LogConfig
+	// ModerationRulesPath is the auto-moderation rules file; empty uses the
+	// built-in rules.
+	ModerationRulesPath string
+	// NotificationsPath is the ingestion notifications file; nobody is
+	// notified when it is empty.
+	NotificationsPath string
+	// PurgeRetention is how long soft-deleted entities are kept before the
+	// scheduled purge deletes them for good.
+	PurgeRetention time.Duration
 }
 
-// ResponseWriter captures the response for Lambda
-type ResponseWriter struct {
-	Headers    map[string]string
-	Body       strings.Builder
-	StatusCode int
-}
+func NewServer(cfg *ServerConfig) (*Server, error) {
+	log := logger.NewLogger(&cfg.LogConfig)
 
-func NewResponseWriter() *ResponseWriter {
-	return &ResponseWriter{
-		Headers:    make(map[string]string),
-		StatusCode: http.StatusOK,
+	apiKeys, err := middleware.ParseAPIKeys(cfg.APIKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API keys: %w", err)
 	}
-}
-
-func (w *ResponseWriter) Header() http.Header {
-	header := http.Header{}
-	for k, v := range w.Headers {
-		header.Set(k, v)
+	if len(apiKeys) == 0 {
+		log.Warn(nil, "No API keys are configured, so every API request will be refused")
 	}
-	return header
-}
-
-func (w *ResponseWriter) Write(b []byte) (int, error) {
-	return w.Body.Write(b)
-}
-
-func (w *ResponseWriter) WriteHeader(statusCode int) {
-	w.StatusCode = statusCode
-}
-
-func NewServer(cfg *ServerConfig) (*Server, error) {
-	log := logger.NewLogger(&cfg.LogConfig)
+	middleware.SetAPIKeys(apiKeys)
 
 	dataSource := db.NewDataSource(cfg.DatabaseDSN)
 
 	//TODO: Move Auto-Migration to CI/CD instead of running on every start
-	dataSource.Db.AutoMigrate(&models.Provider{}, &models.Hotel{}, &models.Review{}, &models.ProviderHotel{}, &models.AuditLog{})
+	dataSource.Db.AutoMigrate(&models.Provider{}, &models.Hotel{}, &models.Review{}, &models.ReviewResponse{}, &models.ProviderHotel{}, &models.AuditLog{}, &models.WebhookSubscription{}, &models.WebhookDelivery{})
+	// Hotel names used to be unique on their own
+	if dataSource.Db.Migrator().HasIndex(&models.Hotel{}, "idx_hotels_name") {
+		dataSource.Db.Migrator().DropIndex(&models.Hotel{}, "idx_hotels_name")
+	}
 
 	router := api.SetUpRoutes(dataSource, log)
 
@@ -81,21 +89,40 @@ func NewServer(cfg *ServerConfig) (*Server, error) {
 		return nil, fmt.Errorf("failed to initialize S3 service: %w", err)
 	}
 
+	moderationRules, err := moderation.NewPipeline(cfg.ModerationRulesPath, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load moderation rules: %w", err)
+	}
+
+	notifier, err := notify.NewNotifier(cfg.NotificationsPath, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notifications: %w", err)
+	}
+
 	server := &Server{
 		Config:     cfg,
 		Logger:     log,
 		DataSource: dataSource,
 		S3Service:  s3Service,
 		Router:     router,
+		Moderation: moderationRules,
+		Notifier:   notifier,
 	}
 
 	return server, nil
 }
 
+// webhookDeliveryInterval is how often a local server delivers the due
+// webhooks. Lambdas are woken up by a schedule instead.
+const webhookDeliveryInterval = 15 * time.Second
+
+// shutdownTimeout is how long a local server waits for in-flight requests and
+// ingestion jobs when it is stopped.
+const shutdownTimeout = 30 * time.Second
+
 func (s *Server) Start() error {
 	if s.Config.RunMode == "local" {
-		s.Logger.Info(fmt.Sprintf("Starting local server on %s\n", s.Config.Port))
-		return http.ListenAndServe(s.Config.Port, s.Router)
+		return s.startLocal()
 	}
 
 	s.Logger.Info("Starting Lambda handler\n")
@@ -103,111 +130,196 @@ func (s *Server) Start() error {
 	return nil
 }
 
-func (s *Server) handle(ctx context.Context, event json.RawMessage) (interface{}, error) {
-	// First, try to unmarshal as an API Gateway request
-	var apiReq events.APIGatewayProxyRequest
-	if err := json.Unmarshal(event, &apiReq); err == nil && apiReq.RequestContext.RequestID != "" {
-		return s.handleAPIGatewayRequest(ctx, apiReq)
+// startLocal serves the REST API, and the gRPC API when it has a port, until
+// one of them fails or the process is interrupted. On an interrupt, it stops
+// taking requests and waits for the in-flight ones and the ingestion jobs.
+func (s *Server) startLocal() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go s.newDispatcher().Run(ctx, webhookDeliveryInterval)
+
+	errs := make(chan error, 2)
+	var (
+		rpcServer *grpc.Server
+		jobs      *rpc.IngestionJobs
+	)
+	if s.Config.GRPCPort != "" {
+		listener, err := net.Listen("tcp", s.Config.GRPCPort)
+		if err != nil {
+			return fmt.Errorf("failed to listen for gRPC on %s: %w", s.Config.GRPCPort, err)
+		}
+		// Jobs are ingested with the same moderation rules as the other
+		// ingestion paths
+		jobs = rpc.NewIngestionJobs(s.newReviewService(), s.Logger)
+		rpcServer = s.newRPCServer(jobs)
+		s.Logger.Info(fmt.Sprintf("Starting gRPC server on %s\n", s.Config.GRPCPort))
+		go func() { errs <- rpcServer.Serve(listener) }()
 	}
 
-	// If that fails, try to unmarshal as an SQS event
-	var sqsEvent events.SQSEvent
-	if err := json.Unmarshal(event, &sqsEvent); err == nil && len(sqsEvent.Records) > 0 {
-		return s.handleSQSEvent(ctx, sqsEvent)
+	httpServer := &http.Server{Addr: s.Config.Port, Handler: s.Router}
+	s.Logger.Info(fmt.Sprintf("Starting local server on %s\n", s.Config.Port))
+	go func() { errs <- httpServer.ListenAndServe() }()
+
+	var serveErr error
+	select {
+	case serveErr = <-errs:
+	case <-ctx.Done():
+		s.Logger.Info("Shutting down local server\n")
 	}
 
-	return nil, fmt.Errorf("unsupported event type")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		s.Logger.Error(err, "Failed to shut down the local server")
+	}
+	if rpcServer != nil {
+		rpcServer.GracefulStop()
+		if err := jobs.Shutdown(shutdownCtx); err != nil {
+			s.Logger.Error(err, "Failed to finish the ingestion jobs")
+		}
+	}
+	return serveErr
 }
 
-// handleAPIGatewayRequest handles API Gateway proxy requests.
-func (s *Server) handleAPIGatewayRequest(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	httpReq, err := s.convertToHTTPRequest(req)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Body:       fmt.Sprintf("Error converting request: %v", err),
-		}, nil
+func (s *Server) handle(ctx context.Context, event json.RawMessage) (interface{}, error) {
+	var probe eventProbe
+	if err := json.Unmarshal(event, &probe); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	w := NewResponseWriter()
-	s.Router.ServeHTTP(w, httpReq)
+	// HTTP requests carry a request context
+	if probe.RequestContext.RequestID != "" {
+		switch {
+		case probe.isFunctionURLRequest():
+			var urlReq events.LambdaFunctionURLRequest
+			if err := json.Unmarshal(event, &urlReq); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal function URL request: %w", err)
+			}
+			return s.handleFunctionURLRequest(ctx, urlReq)
+		case probe.isHTTPAPIRequest():
+			var apiReq events.APIGatewayV2HTTPRequest
+			if err := json.Unmarshal(event, &apiReq); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal API Gateway v2 request: %w", err)
+			}
+			return s.handleAPIGatewayV2Request(ctx, apiReq)
+		default:
+			var apiReq events.APIGatewayProxyRequest
+			if err := json.Unmarshal(event, &apiReq); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal API Gateway request: %w", err)
+			}
+			return s.handleAPIGatewayRequest(ctx, apiReq)
+		}
+	}
 
-	return events.APIGatewayProxyResponse{
-		StatusCode: w.StatusCode,
-		Headers:    w.Headers,
-		Body:       w.Body.String(),
-	}, nil
-}
+	// EventBridge "Object Created" events from S3
+	if probe.isObjectCreated() {
+		return s.handleEventBridgeEvent(ctx, event)
+	}
 
-func (s *Server) handleSQSEvent(ctx context.Context, sqsEvent events.SQSEvent) (interface{}, error) {
-	log := s.Logger
-	for _, record := range sqsEvent.Records {
-		log.Info(fmt.Sprintf("Processing SQS message: %s", record.MessageId))
+	// Schedules with a job input run that job
+	if probe.Job == jobDeliverWebhooks {
+		return s.handleDeliverWebhooks(ctx)
+	}
 
-		var s3Event events.S3Event
-		if err := json.Unmarshal([]byte(record.Body), &s3Event); err != nil {
-			log.Info(fmt.Sprintf("Error unmarshalling S3 event from SQS message %s: %v", record.MessageId, err))
-			continue
-		}
+	// EventBridge schedules run the purge job
+	if probe.isScheduledEvent() {
+		return s.handleScheduledEvent()
+	}
 
-		for _, s3Record := range s3Event.Records {
-			bucket := s3Record.S3.Bucket.Name
-			key := s3Record.S3.Object.Key
+	// Record based events are told apart by their event source
+	switch probe.recordsSource() {
+	case eventSourceSQS:
+		var sqsEvent events.SQSEvent
+		if err := json.Unmarshal(event, &sqsEvent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SQS event: %w", err)
+		}
+		return s.handleSQSEvent(ctx, sqsEvent)
+	case eventSourceS3:
+		return s.handleS3Event(ctx, event)
+	case eventSourceKinesis:
+		var kinesisEvent events.KinesisEvent
+		if err := json.Unmarshal(event, &kinesisEvent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Kinesis event: %w", err)
+		}
+		return s.handleKinesisEvent(ctx, kinesisEvent)
+	case eventSourceSNS:
+		var snsEvent events.SNSEvent
+		if err := json.Unmarshal(event, &snsEvent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SNS event: %w", err)
+		}
+		return s.handleSNSEvent(ctx, snsEvent)
+	}
 
-			log.Info(fmt.Sprintf("Processing S3 object: bucket=%s, key=%s", bucket, key))
+	return nil, fmt.Errorf("unsupported event type")
+}
 
-			reader, err := s.S3Service.GetObject(ctx, bucket, key)
-			if err != nil {
-				log.Error(err, fmt.Sprintf("Error getting S3 object %s/%s: %v", bucket, key, err))
-				continue
-			}
-			defer reader.Close()
+// handleSQSEvent processes each SQS message independently and reports the ones
+// that failed back to Lambda, so only those are retried (and eventually moved
+// to the DLQ) instead of the whole batch being deleted or redelivered.
+func (s *Server) handleSQSEvent(ctx context.Context, sqsEvent events.SQSEvent) (events.SQSEventResponse, error) {
+	log := s.Logger
+	batchItemFailures := []events.SQSBatchItemFailure{}
 
-			repository := repository.NewReviewRepository(s.DataSource)
-			reviewService := service.NewReviewService(repository, log)
+	for _, record := range sqsEvent.Records {
+		log.Info(fmt.Sprintf("Processing SQS message: %s", record.MessageId))
 
-			if err := reviewService.ProcessReviews(ctx, reader, key); err != nil {
-				log.Error(err, fmt.Sprintf("Error processing reviews from S3 object %s/%s: %v", bucket, key, err))
-				continue
-			}
+		if err := s.processSQSMessage(ctx, record); err != nil {
+			log.Error(err, fmt.Sprintf("Error processing SQS message %s: %v", record.MessageId, err))
+			batchItemFailures = append(batchItemFailures, events.SQSBatchItemFailure{
+				ItemIdentifier: record.MessageId,
+			})
 		}
 	}
 
-	return nil, nil
+	return events.SQSEventResponse{BatchItemFailures: batchItemFailures}, nil
 }
 
-// Helper to convert API Gateway request to http.Request
-func (s *Server) convertToHTTPRequest(req events.APIGatewayProxyRequest) (*http.Request, error) {
-	// Use the path directly from the request
-	urlPath := req.Path
-	if req.QueryStringParameters != nil {
-		queryParams := url.Values{}
-		for key, value := range req.QueryStringParameters {
-			queryParams.Set(key, value)
-		}
-		urlPath = fmt.Sprintf("%s?%s", urlPath, queryParams.Encode())
-	}
+// processSQSMessage ingests every S3 object referenced by the message body,
+// which may be an S3 notification, an SNS envelope or an EventBridge event.
+func (s *Server) processSQSMessage(ctx context.Context, record events.SQSMessage) error {
+	return s.ingestNotification(ctx, []byte(record.Body))
+}
 
-	// Create the request body
-	var body io.Reader
-	if req.Body != "" {
-		body = strings.NewReader(req.Body)
-	}
+// processS3Object fetches a review file from S3 and runs it through ingestion.
+func (s *Server) processS3Object(ctx context.Context, bucket, key string) error {
+	log := s.Logger
+	log.Info(fmt.Sprintf("Processing S3 object: bucket=%s, key=%s", bucket, key))
 
-	// Create the http.Request
-	// In Lambda, the host is not present in the request, so we add a dummy one.
-	if s.Config.RunMode != "local" {
-		urlPath = "http://localhost" + urlPath
-	}
-	httpReq, err := http.NewRequest(req.HTTPMethod, urlPath, body)
+	reader, err := s.S3Service.GetObject(ctx, bucket, key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		return fmt.Errorf("failed to get S3 object %s/%s: %w", bucket, key, err)
 	}
+	defer reader.Close()
 
-	// Add headers
-	for key, value := range req.Headers {
-		httpReq.Header.Set(key, value)
+	if err := s.newReviewService().ProcessReviews(ctx, reader, key); err != nil {
+		return fmt.Errorf("failed to process reviews from S3 object %s/%s: %w", bucket, key, err)
 	}
 
-	return httpReq, nil
+	return nil
+}
+
+// newRPCServer builds the gRPC server of the gRPC API. Jobs submitted through
+// it are run by jobs.
+func (s *Server) newRPCServer(jobs *rpc.IngestionJobs) *grpc.Server {
+	repository := repository.NewReviewRepository(s.DataSource)
+	return rpc.NewServer(rpc.Services{
+		Hotels:        service.NewHotelService(repository, s.Logger),
+		Providers:     service.NewProviderService(repository, s.Logger),
+		Reviews:       s.newReviewService(),
+		Objects:       s.S3Service,
+		IngestionJobs: jobs,
+	}, s.Logger)
+}
+
+// newReviewService builds the review service used by the ingestion handlers.
+func (s *Server) newReviewService() service.ReviewService {
+	repository := repository.NewReviewRepository(s.DataSource)
+	return service.NewReviewService(repository, s.Logger, s.Moderation, s.newDispatcher(), s.Notifier)
+}
+
+// newDispatcher builds the dispatcher that queues and delivers webhooks.
+func (s *Server) newDispatcher() *webhook.Dispatcher {
+	repository := repository.NewReviewRepository(s.DataSource)
+	return webhook.NewDispatcher(repository, webhook.NewClient(webhook.DefaultTimeout), s.Logger)
 }
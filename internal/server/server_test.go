@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockS3Service is a mock implementation of the S3Service for testing.
+type mockS3Service struct {
+	GetObjectFunc func(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+}
+
+func (m *mockS3Service) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	return m.GetObjectFunc(ctx, bucket, key)
+}
+
+func newTestServer(s3Service *mockS3Service) *Server {
+	return &Server{
+		Config:    &ServerConfig{RunMode: "lambda"},
+		Logger:    logger.NewLogger(&logger.LogConfig{LogLevel: "info"}),
+		S3Service: s3Service,
+	}
+}
+
+func TestServer_HandleSQSEvent(t *testing.T) {
+	t.Run("reports failed messages", func(t *testing.T) {
+		srv := newTestServer(&mockS3Service{
+			GetObjectFunc: func(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+				return nil, errors.New("s3 error")
+			},
+		})
+
+		sqsEvent := events.SQSEvent{
+			Records: []events.SQSMessage{
+				{MessageId: "invalid-body", Body: "not json"},
+				{MessageId: "s3-error", Body: `{"Records":[{"s3":{"bucket":{"name":"bucket"},"object":{"key":"reviews.jl"}}}]}`},
+				{MessageId: "test-event", Body: `{"Event":"s3:TestEvent"}`},
+			},
+		}
+
+		resp, err := srv.handleSQSEvent(context.TODO(), sqsEvent)
+		assert.NoError(t, err)
+		assert.Equal(t, []events.SQSBatchItemFailure{
+			{ItemIdentifier: "invalid-body"},
+			{ItemIdentifier: "s3-error"},
+		}, resp.BatchItemFailures)
+	})
+
+	t.Run("empty failures when nothing fails", func(t *testing.T) {
+		srv := newTestServer(&mockS3Service{})
+
+		resp, err := srv.handleSQSEvent(context.TODO(), events.SQSEvent{
+			Records: []events.SQSMessage{{MessageId: "test-event", Body: `{"Event":"s3:TestEvent"}`}},
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.BatchItemFailures)
+	})
+}
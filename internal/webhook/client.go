@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultTimeout is how long an endpoint has to answer a delivery.
+const DefaultTimeout = 10 * time.Second
+
+// maxErrorBody is how much of a failed response's body is kept as the error.
+const maxErrorBody = 512
+
+// Client sends deliveries to endpoints.
+type Client struct {
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewClient returns a client whose requests time out after timeout. It only
+// connects to public addresses.
+func NewClient(timeout time.Duration) *Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: checkDial}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// Through a proxy, the dialer would check the proxy's address instead of
+	// the endpoint's
+	transport.Proxy = nil
+
+	return &Client{
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// A redirect could point the signed payload anywhere
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Request is a delivery to send.
+type Request struct {
+	URL        string
+	Secret     string
+	EventType  string
+	DeliveryID uint
+	Body       []byte
+}
+
+// Send posts a signed delivery and returns the response status, or 0 when the
+// endpoint could not be reached. Any status but 2xx is an error.
+func (c *Client) Send(ctx context.Context, delivery *Request) (int, error) {
+	timestamp := c.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "review-system-webhooks/1")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(delivery.DeliveryID), 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Body))
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		return res.StatusCode, fmt.Errorf("endpoint answered %d: %s", res.StatusCode, bytes.TrimSpace(body))
+	}
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, res.Body)
+	return res.StatusCode, nil
+}
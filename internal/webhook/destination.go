@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+)
+
+// ErrForbiddenDestination is returned for webhook endpoints that are not on
+// the public internet, so subscriptions can not be used to reach the
+// services next to the server, like the instance metadata endpoint.
+var ErrForbiddenDestination = errors.New("webhooks can only be sent to public addresses")
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not routed on
+// the internet either.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublicAddr reports whether webhooks may be sent to addr. Loopback,
+// private, link-local, multicast and unspecified addresses are refused.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// CheckHost fails with ErrForbiddenDestination when host is, or resolves to,
+// an address that is not public. Hosts that do not resolve are let through:
+// the endpoint may not be live yet, and every connection is checked again
+// when it is made.
+func CheckHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%w: %s", ErrForbiddenDestination, host)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrForbiddenDestination, host, addr)
+		}
+	}
+	return nil
+}
+
+// checkDial refuses connections to addresses that are not public. It runs
+// after the host was resolved, so a host can not pass CheckHost and then be
+// pointed at a private address.
+func checkDial(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenDestination, address)
+	}
+	if !IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenDestination, addrPort.Addr())
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+)
+
+// Retry schedule of failed deliveries. The n-th failed attempt is retried
+// after BaseBackoff * 2^(n-1), at most MaxBackoff, and a delivery is
+// dead-lettered when its MaxAttempts-th attempt fails.
+const (
+	MaxAttempts = 8
+	BaseBackoff = 30 * time.Second
+	MaxBackoff  = 6 * time.Hour
+)
+
+// Backoff returns how long to wait before retrying after the attempt-th
+// failed attempt.
+func Backoff(attempt int) time.Duration {
+	backoff := BaseBackoff
+	for i := 1; i < attempt && backoff < MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, MaxBackoff)
+}
+
+const (
+	// claimBatchSize is how many deliveries are claimed at once.
+	claimBatchSize = 50
+	// claimLeaseMargin is added to the time a claimed batch can take to be
+	// attempted, for recording the outcomes.
+	claimLeaseMargin = time.Minute
+	// MaxDeliveriesPerRun bounds a DeliverDue run, so it fits in one Lambda
+	// invocation. The rest are left to the next run.
+	MaxDeliveriesPerRun = 500
+)
+
+// Store keeps subscriptions and deliveries.
+type Store interface {
+	GetMatchingWebhookSubscriptions(eventType string, hotelID, providerID uint) ([]*models.WebhookSubscription, error)
+	GetWebhookSubscriptionsByIDs(ids []uint) ([]*models.WebhookSubscription, error)
+	CreateWebhookDeliveries(deliveries []*models.WebhookDelivery) error
+	ClaimDueWebhookDeliveries(now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error)
+	// UpdateWebhookDelivery records the outcome of a delivery, unless its
+	// lease is no longer leasedUntil, and reports whether it did.
+	UpdateWebhookDelivery(delivery *models.WebhookDelivery, leasedUntil time.Time) (bool, error)
+}
+
+// Dispatcher queues events as deliveries and sends them.
+type Dispatcher struct {
+	store  Store
+	client *Client
+	logger *logger.Logger
+	now    func() time.Time
+	// lease is how long claimed deliveries are hidden from other
+	// dispatchers. It covers attempting a whole batch, so a slow batch is not
+	// claimed again while it is being sent. Deliveries a dispatcher claimed
+	// but never finished are attempted again once it has passed.
+	lease time.Duration
+}
+
+// NewDispatcher returns a dispatcher that keeps deliveries in store and sends
+// them with client.
+func NewDispatcher(store Store, client *Client, logger *logger.Logger) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		client: client,
+		logger: logger,
+		now:    time.Now,
+		lease:  claimBatchSize*client.httpClient.Timeout + claimLeaseMargin,
+	}
+}
+
+// LeaseUntil returns until when a delivery claimed at now is hidden from other
+// dispatchers. Deliveries passed to Deliver must be leased.
+func (d *Dispatcher) LeaseUntil(now time.Time) time.Time {
+	// The database keeps microseconds, and the lease is compared exactly
+	return now.Add(d.lease).Truncate(time.Microsecond)
+}
+
+// Publish queues an event for every enabled subscription it matches. The
+// deliveries are sent by the next DeliverDue run. Failures are logged rather
+// than returned, so they never fail the change that caused the event.
+func (d *Dispatcher) Publish(event *Event) {
+	subscriptions, err := d.store.GetMatchingWebhookSubscriptions(event.Type, event.HotelID, event.ProviderID)
+	if err != nil {
+		d.logger.Error(err, fmt.Sprintf("Failed to find the webhook subscriptions of a %s event", event.Type))
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	now := d.now()
+	eventID := newEventID()
+	payload, err := json.Marshal(&Payload{
+		ID:         eventID,
+		Type:       event.Type,
+		OccurredAt: now.UTC(),
+		Data:       event.Data,
+	})
+	if err != nil {
+		d.logger.Error(err, fmt.Sprintf("Failed to encode the payload of a %s event", event.Type))
+		return
+	}
+
+	deliveries := make([]*models.WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  &now,
+		}
+	}
+	if err := d.store.CreateWebhookDeliveries(deliveries); err != nil {
+		d.logger.Error(err, fmt.Sprintf("Failed to queue %d webhook deliveries of a %s event", len(deliveries), event.Type))
+	}
+}
+
+// Result counts what a DeliverDue run did.
+type Result struct {
+	Attempted    int `json:"attempted"`
+	Succeeded    int `json:"succeeded"`
+	Retrying     int `json:"retrying"`
+	DeadLettered int `json:"dead_lettered"`
+}
+
+// DeliverDue attempts the pending deliveries that are due, until none are
+// left, ctx is done or MaxDeliveriesPerRun were attempted. Several
+// dispatchers may run at once; each delivery is claimed by one of them.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (*Result, error) {
+	result := &Result{}
+	for result.Attempted < MaxDeliveriesPerRun && ctx.Err() == nil {
+		deliveries, err := d.store.ClaimDueWebhookDeliveries(d.now(), d.lease, min(claimBatchSize, MaxDeliveriesPerRun-result.Attempted))
+		if err != nil {
+			return result, fmt.Errorf("failed to claim due webhook deliveries: %w", err)
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		subscriptions, err := d.subscriptions(deliveries)
+		if err != nil {
+			return result, err
+		}
+		for _, delivery := range deliveries {
+			if err := d.Deliver(ctx, delivery, subscriptions[delivery.SubscriptionID]); err != nil {
+				return result, err
+			}
+			result.Attempted++
+			switch delivery.Status {
+			case models.WebhookDeliverySucceeded:
+				result.Succeeded++
+			case models.WebhookDeliveryDead:
+				result.DeadLettered++
+			default:
+				result.Retrying++
+			}
+		}
+	}
+	return result, nil
+}
+
+// subscriptions returns the subscriptions of deliveries by ID.
+func (d *Dispatcher) subscriptions(deliveries []*models.WebhookDelivery) (map[uint]*models.WebhookSubscription, error) {
+	ids := make([]uint, len(deliveries))
+	for i, delivery := range deliveries {
+		ids[i] = delivery.SubscriptionID
+	}
+	subscriptions, err := d.store.GetWebhookSubscriptionsByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+
+	byID := make(map[uint]*models.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		byID[subscription.ID] = subscription
+	}
+	return byID, nil
+}
+
+// Deliver attempts to send a delivery to its subscription and records the
+// outcome: a failed attempt is retried after its backoff, or dead-lettered
+// when it was the last. Deliveries of disabled subscriptions are
+// dead-lettered without an attempt. The delivery must be leased: when its
+// lease passed and another dispatcher claimed it meanwhile, the outcome is
+// left to that dispatcher. Only failing to record the outcome is returned as
+// an error.
+func (d *Dispatcher) Deliver(ctx context.Context, delivery *models.WebhookDelivery, subscription *models.WebhookSubscription) error {
+	if delivery.NextAttemptAt == nil {
+		return fmt.Errorf("webhook delivery %d is not leased", delivery.ID)
+	}
+	leasedUntil := *delivery.NextAttemptAt
+
+	if subscription == nil || subscription.Disabled {
+		delivery.Status = models.WebhookDeliveryDead
+		delivery.NextAttemptAt = nil
+		delivery.LastError = "subscription is disabled"
+		return d.update(delivery, leasedUntil)
+	}
+
+	statusCode, err := d.client.Send(ctx, &Request{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventType:  delivery.EventType,
+		DeliveryID: delivery.ID,
+		Body:       delivery.Payload,
+	})
+
+	now := d.now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = statusCode
+	delivery.LastError = ""
+	switch {
+	case err == nil:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= MaxAttempts:
+		delivery.Status = models.WebhookDeliveryDead
+		delivery.NextAttemptAt = nil
+		delivery.LastError = err.Error()
+		d.logger.Warn(err, fmt.Sprintf("Webhook delivery %d to subscription %d dead-lettered after %d attempts", delivery.ID, subscription.ID, delivery.Attempts))
+	default:
+		next := now.Add(Backoff(delivery.Attempts))
+		delivery.Status = models.WebhookDeliveryPending
+		delivery.NextAttemptAt = &next
+		delivery.LastError = err.Error()
+	}
+	return d.update(delivery, leasedUntil)
+}
+
+func (d *Dispatcher) update(delivery *models.WebhookDelivery, leasedUntil time.Time) error {
+	updated, err := d.store.UpdateWebhookDelivery(delivery, leasedUntil)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery %d: %w", delivery.ID, err)
+	}
+	if !updated {
+		d.logger.Warn(nil, fmt.Sprintf("Webhook delivery %d was claimed again before its outcome was recorded", delivery.ID))
+	}
+	return nil
+}
+
+// Run delivers the due deliveries every interval until ctx is done, for
+// servers that are not woken up by a schedule.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.DeliverDue(ctx); err != nil {
+				d.logger.Error(err, "Failed to deliver webhooks")
+			}
+		}
+	}
+}
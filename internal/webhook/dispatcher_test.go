@@ -0,0 +1,413 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kirananto/review-system/internal/logger"
+	"github.com/kirananto/review-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps subscriptions and deliveries in memory. Claimed
+// deliveries are copies, so outcomes only land through UpdateWebhookDelivery.
+type memoryStore struct {
+	mu            sync.Mutex
+	subscriptions []*models.WebhookSubscription
+	deliveries    []*models.WebhookDelivery
+}
+
+func (s *memoryStore) GetMatchingWebhookSubscriptions(eventType string, hotelID, providerID uint) ([]*models.WebhookSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matching []*models.WebhookSubscription
+	for _, subscription := range s.subscriptions {
+		if subscription.Disabled || !slices.Contains(subscription.EventTypes, eventType) {
+			continue
+		}
+		if subscription.HotelID != 0 && subscription.HotelID != hotelID {
+			continue
+		}
+		if subscription.ProviderID != 0 && subscription.ProviderID != providerID {
+			continue
+		}
+		matching = append(matching, subscription)
+	}
+	return matching, nil
+}
+
+func (s *memoryStore) GetWebhookSubscriptionsByIDs(ids []uint) ([]*models.WebhookSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var subscriptions []*models.WebhookSubscription
+	for _, subscription := range s.subscriptions {
+		if slices.Contains(ids, subscription.ID) {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (s *memoryStore) CreateWebhookDeliveries(deliveries []*models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		delivery.ID = uint(len(s.deliveries) + 1)
+		s.deliveries = append(s.deliveries, delivery)
+	}
+	return nil
+}
+
+func (s *memoryStore) ClaimDueWebhookDeliveries(now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var claimed []*models.WebhookDelivery
+	for _, delivery := range s.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		if delivery.Status != models.WebhookDeliveryPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		leased := now.Add(lease)
+		delivery.NextAttemptAt = &leased
+		claim := *delivery
+		claimed = append(claimed, &claim)
+	}
+	return claimed, nil
+}
+
+func (s *memoryStore) UpdateWebhookDelivery(delivery *models.WebhookDelivery, leasedUntil time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.deliveries {
+		if stored.ID != delivery.ID {
+			continue
+		}
+		if stored.NextAttemptAt == nil || !stored.NextAttemptAt.Equal(leasedUntil) {
+			return false, nil
+		}
+		*stored = *delivery
+		return true, nil
+	}
+	return false, nil
+}
+
+// receiver is a local endpoint that answers deliveries with status and keeps
+// the requests it was sent.
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	requests []*receivedRequest
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	r := &receiver{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests = append(r.requests, &receivedRequest{header: req.Header, body: body})
+		w.WriteHeader(r.status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received() []*receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.requests)
+}
+
+// testLogger is shared, as NewLogger sets zerolog's globals.
+var testLogger = logger.NewLogger(&logger.LogConfig{LogLevel: "info"})
+
+// newTestDispatcher returns a dispatcher whose clock is at *now.
+func newTestDispatcher(store Store, now *time.Time) *Dispatcher {
+	dispatcher := NewDispatcher(store, NewClient(time.Second), testLogger)
+	// The receivers listen on loopback, which deliveries are never sent to
+	dispatcher.client.httpClient.Transport = http.DefaultTransport
+	dispatcher.now = func() time.Time { return *now }
+	dispatcher.client.now = dispatcher.now
+	return dispatcher
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 2*time.Minute, Backoff(3))
+	assert.Equal(t, 32*time.Minute, Backoff(7))
+	assert.Equal(t, MaxBackoff, Backoff(20))
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	for addr, expected := range map[string]bool{
+		"93.184.215.14":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+		"::ffff:10.0.0.1": false,
+	} {
+		assert.Equal(t, expected, IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestClient_RefusesPrivateAddresses(t *testing.T) {
+	endpoint := newReceiver(t, http.StatusOK)
+
+	statusCode, err := NewClient(time.Second).Send(context.Background(), &Request{URL: endpoint.URL, Secret: "whsec_test"})
+
+	assert.ErrorIs(t, err, ErrForbiddenDestination)
+	assert.Equal(t, 0, statusCode)
+	assert.Empty(t, endpoint.received())
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signature := Sign("whsec_test", 1700000000, body)
+
+	assert.True(t, Verify("whsec_test", signature, 1700000000, body))
+	assert.False(t, Verify("whsec_other", signature, 1700000000, body))
+	assert.False(t, Verify("whsec_test", signature, 1700000001, body))
+	assert.False(t, Verify("whsec_test", signature, 1700000000, []byte(`{"id":"2"}`)))
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	store := &memoryStore{subscriptions: []*models.WebhookSubscription{
+		{ID: 1, EventTypes: []string{EventReviewCreated}},
+		{ID: 2, EventTypes: []string{EventReviewCreated}, HotelID: 7},
+		{ID: 3, EventTypes: []string{EventReviewCreated}, HotelID: 8},
+		{ID: 4, EventTypes: []string{EventReviewDeleted}},
+		{ID: 5, EventTypes: []string{EventReviewCreated}, Disabled: true},
+	}}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	dispatcher := newTestDispatcher(store, &now)
+
+	dispatcher.Publish(&Event{Type: EventReviewCreated, HotelID: 7, ProviderID: 1, Data: map[string]int{"id": 42}})
+
+	require.Len(t, store.deliveries, 2)
+	assert.Equal(t, uint(1), store.deliveries[0].SubscriptionID)
+	assert.Equal(t, uint(2), store.deliveries[1].SubscriptionID)
+	assert.Equal(t, store.deliveries[0].EventID, store.deliveries[1].EventID)
+	for _, delivery := range store.deliveries {
+		assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, now, *delivery.NextAttemptAt)
+	}
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(store.deliveries[0].Payload, &payload))
+	assert.Equal(t, EventReviewCreated, payload.Type)
+	assert.Equal(t, now, payload.OccurredAt)
+	assert.Equal(t, map[string]interface{}{"id": float64(42)}, payload.Data)
+}
+
+func TestDispatcher_DeliverDue(t *testing.T) {
+	t.Run("signed delivery succeeds", func(t *testing.T) {
+		endpoint := newReceiver(t, http.StatusNoContent)
+		store := &memoryStore{subscriptions: []*models.WebhookSubscription{
+			{ID: 1, URL: endpoint.URL, Secret: "whsec_test", EventTypes: []string{EventImportCompleted}},
+		}}
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		dispatcher := newTestDispatcher(store, &now)
+		dispatcher.Publish(&Event{Type: EventImportCompleted, Data: &ImportData{FileName: "reviews.jl", SuccessCount: 3, TotalCount: 3}})
+
+		result, err := dispatcher.DeliverDue(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, &Result{Attempted: 1, Succeeded: 1}, result)
+
+		requests := endpoint.received()
+		require.Len(t, requests, 1)
+		header := requests[0].header
+		assert.Equal(t, EventImportCompleted, header.Get(EventHeader))
+		assert.Equal(t, "1", header.Get(DeliveryHeader))
+		assert.Equal(t, strconv.FormatInt(now.Unix(), 10), header.Get(TimestampHeader))
+		assert.True(t, Verify("whsec_test", header.Get(SignatureHeader), now.Unix(), requests[0].body))
+		assert.JSONEq(t, string(store.deliveries[0].Payload), string(requests[0].body))
+
+		delivery := store.deliveries[0]
+		assert.Equal(t, models.WebhookDeliverySucceeded, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+		assert.Nil(t, delivery.NextAttemptAt)
+	})
+
+	t.Run("failed delivery is retried with backoff", func(t *testing.T) {
+		endpoint := newReceiver(t, http.StatusServiceUnavailable)
+		store := &memoryStore{subscriptions: []*models.WebhookSubscription{
+			{ID: 1, URL: endpoint.URL, Secret: "whsec_test", EventTypes: []string{EventReviewUpdated}},
+		}}
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		dispatcher := newTestDispatcher(store, &now)
+		dispatcher.Publish(&Event{Type: EventReviewUpdated})
+
+		result, err := dispatcher.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Result{Attempted: 1, Retrying: 1}, result)
+
+		delivery := store.deliveries[0]
+		assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+		assert.Contains(t, delivery.LastError, "endpoint answered 503")
+		assert.Equal(t, now.Add(BaseBackoff), *delivery.NextAttemptAt)
+
+		// Not due again until the backoff has passed
+		result, err = dispatcher.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Result{}, result)
+
+		now = now.Add(BaseBackoff)
+		endpoint.mu.Lock()
+		endpoint.status = http.StatusOK
+		endpoint.mu.Unlock()
+
+		result, err = dispatcher.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Result{Attempted: 1, Succeeded: 1}, result)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Empty(t, delivery.LastError)
+		assert.Len(t, endpoint.received(), 2)
+	})
+
+	t.Run("delivery is dead-lettered after the last attempt", func(t *testing.T) {
+		endpoint := newReceiver(t, http.StatusInternalServerError)
+		store := &memoryStore{subscriptions: []*models.WebhookSubscription{
+			{ID: 1, URL: endpoint.URL, Secret: "whsec_test", EventTypes: []string{EventReviewDeleted}},
+		}}
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		dispatcher := newTestDispatcher(store, &now)
+		dispatcher.Publish(&Event{Type: EventReviewDeleted})
+
+		delivery := store.deliveries[0]
+		for attempt := 1; attempt <= MaxAttempts; attempt++ {
+			result, err := dispatcher.DeliverDue(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 1, result.Attempted)
+			if delivery.NextAttemptAt != nil {
+				now = *delivery.NextAttemptAt
+			}
+		}
+
+		assert.Equal(t, models.WebhookDeliveryDead, delivery.Status)
+		assert.Equal(t, MaxAttempts, delivery.Attempts)
+		assert.Nil(t, delivery.NextAttemptAt)
+		assert.Len(t, endpoint.received(), MaxAttempts)
+
+		// Dead deliveries are never attempted again
+		now = now.Add(MaxBackoff)
+		result, err := dispatcher.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Result{}, result)
+	})
+
+	t.Run("unreachable endpoint is retried", func(t *testing.T) {
+		endpoint := newReceiver(t, http.StatusOK)
+		endpoint.Close()
+		store := &memoryStore{subscriptions: []*models.WebhookSubscription{
+			{ID: 1, URL: endpoint.URL, Secret: "whsec_test", EventTypes: []string{EventImportFailed}},
+		}}
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		dispatcher := newTestDispatcher(store, &now)
+		dispatcher.Publish(&Event{Type: EventImportFailed})
+
+		result, err := dispatcher.DeliverDue(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, &Result{Attempted: 1, Retrying: 1}, result)
+		assert.Equal(t, 0, store.deliveries[0].ResponseStatus)
+		assert.NotEmpty(t, store.deliveries[0].LastError)
+	})
+
+	t.Run("deliveries of disabled subscriptions are dead-lettered", func(t *testing.T) {
+		endpoint := newReceiver(t, http.StatusOK)
+		subscription := &models.WebhookSubscription{ID: 1, URL: endpoint.URL, Secret: "whsec_test", EventTypes: []string{EventReviewCreated}}
+		store := &memoryStore{subscriptions: []*models.WebhookSubscription{subscription}}
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		dispatcher := newTestDispatcher(store, &now)
+		dispatcher.Publish(&Event{Type: EventReviewCreated})
+		subscription.Disabled = true
+
+		result, err := dispatcher.DeliverDue(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, &Result{Attempted: 1, DeadLettered: 1}, result)
+		assert.Equal(t, 0, store.deliveries[0].Attempts)
+		assert.Empty(t, endpoint.received())
+	})
+}
+
+func TestDispatcher_Lease(t *testing.T) {
+	t.Run("lease covers a whole batch", func(t *testing.T) {
+		store := &memoryStore{subscriptions: []*models.WebhookSubscription{
+			{ID: 1, EventTypes: []string{EventReviewCreated}},
+		}}
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		dispatcher := newTestDispatcher(store, &now)
+		dispatcher.Publish(&Event{Type: EventReviewCreated})
+
+		claimed, err := store.ClaimDueWebhookDeliveries(now, dispatcher.lease, 1)
+
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.False(t, claimed[0].NextAttemptAt.Before(now.Add(claimBatchSize*time.Second)))
+	})
+
+	t.Run("outcome is dropped once claimed again", func(t *testing.T) {
+		endpoint := newReceiver(t, http.StatusOK)
+		store := &memoryStore{subscriptions: []*models.WebhookSubscription{
+			{ID: 1, URL: endpoint.URL, Secret: "whsec_test", EventTypes: []string{EventReviewCreated}},
+		}}
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		dispatcher := newTestDispatcher(store, &now)
+		dispatcher.Publish(&Event{Type: EventReviewCreated})
+		subscriptions, err := store.GetWebhookSubscriptionsByIDs([]uint{1})
+		require.NoError(t, err)
+
+		// The lease of the first claim passes before its outcome is recorded
+		stale, err := store.ClaimDueWebhookDeliveries(now, dispatcher.lease, 1)
+		require.NoError(t, err)
+		later := now.Add(dispatcher.lease)
+		current, err := store.ClaimDueWebhookDeliveries(later, dispatcher.lease, 1)
+		require.NoError(t, err)
+		require.Len(t, current, 1)
+
+		require.NoError(t, dispatcher.Deliver(context.Background(), stale[0], subscriptions[0]))
+
+		delivery := store.deliveries[0]
+		assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 0, delivery.Attempts)
+		assert.Equal(t, *current[0].NextAttemptAt, *delivery.NextAttemptAt)
+	})
+}